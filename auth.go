@@ -1,11 +1,16 @@
 package untappd
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"net"
 	"net/http"
 	"net/url"
 	"strings"
+	"sync"
+	"time"
 )
 
 const (
@@ -36,6 +41,23 @@ type AuthHandler struct {
 	oAuthURL     *url.URL
 	handler      TokenHandlerFunc
 	client       *http.Client
+	timeout      time.Duration
+}
+
+// An AuthHandlerOption is an option which can be used to configure an
+// AuthHandler using NewAuthHandler.
+type AuthHandlerOption func(a *AuthHandler)
+
+// WithAuthTimeout sets the maximum amount of time an AuthHandler will wait
+// for a response from the upstream OAuth authentication server.  If the
+// timeout elapses, HTTP 504 is returned to the client.
+//
+// The timeout applies in addition to any deadline on the incoming HTTP
+// request's context, and any timeout set on the AuthHandler's http.Client.
+func WithAuthTimeout(d time.Duration) AuthHandlerOption {
+	return func(a *AuthHandler) {
+		a.timeout = d
+	}
 }
 
 // TokenHandlerFunc is a function which is invoked at the end of a successful
@@ -75,7 +97,10 @@ var defaultTokenFn = func(token string, w http.ResponseWriter, r *http.Request)
 // obeys timeouts, etc.  This client is used to communicate with an upstream
 // OAuth authentication server.  If no http.Client is provided, http.DefaultClient
 // will be used.
-func NewAuthHandler(clientID string, clientSecret string, redirectURL string, fn TokenHandlerFunc, client *http.Client) (*AuthHandler, *url.URL, error) {
+//
+// Zero or more AuthHandlerOptions may be provided to further configure the
+// AuthHandler.
+func NewAuthHandler(clientID string, clientSecret string, redirectURL string, fn TokenHandlerFunc, client *http.Client, options ...AuthHandlerOption) (*AuthHandler, *url.URL, error) {
 	// Disallow empty ID and secret
 	if clientID == "" {
 		return nil, nil, ErrNoClientID
@@ -121,52 +146,89 @@ func NewAuthHandler(clientID string, clientSecret string, redirectURL string, fn
 		client = http.DefaultClient
 	}
 
-	return &AuthHandler{
+	a := &AuthHandler{
 		clientID:     clientID,
 		clientSecret: clientSecret,
 		redirectURL:  ru,
 		oAuthURL:     ou,
 		handler:      fn,
 		client:       client,
-	}, cu, nil
+	}
+
+	for _, o := range options {
+		o(a)
+	}
+
+	return a, cu, nil
 }
 
 // ServeHTTP implements http.Handler, and provides a simple http.Handler which
 // can properly authenticate using the Server Side Authentication method outlined
 // in Untappd documentation: https://untappd.com/api/docs#authentication.
 func (a *AuthHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	token, code, err := a.token(r)
+	if err != nil {
+		http.Error(w, err.Error(), code)
+		return
+	}
+
+	// Invoke TokenHandlerFunc to provide easy access to the generated token,
+	// so the client can do whatever they please with it
+	a.handler(token, w, r)
+}
+
+// token performs the OAuth code exchange for an incoming HTTP request, and
+// returns the generated access token.  If an error occurs, the HTTP status
+// code which should be returned to the client is also returned.
+func (a *AuthHandler) token(r *http.Request) (string, int, error) {
 	// Verify correct HTTP method
 	if r.Method != "GET" {
-		http.Error(w, "only GET requests are allowed", http.StatusMethodNotAllowed)
-		return
+		return "", http.StatusMethodNotAllowed, errors.New("only GET requests are allowed")
 	}
 
 	// Verify non-empty code parameter
 	code := r.URL.Query().Get("code")
 	if code == "" {
-		http.Error(w, "no 'code' GET parameter", http.StatusBadRequest)
-		return
+		return "", http.StatusBadRequest, errors.New("no 'code' GET parameter")
+	}
+
+	// Bound the upstream request by the incoming request's lifetime,
+	// and by the configured timeout, if any
+	ctx := r.Context()
+	if a.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, a.timeout)
+		defer cancel()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", a.oAuthURL.String()+"&code="+code, nil)
+	if err != nil {
+		return "", http.StatusInternalServerError, err
 	}
 
 	// Perform HTTP GET request to retrieve token using the
 	// code provided from query parameter
-	res, err := a.client.Get(a.oAuthURL.String() + "&code=" + code)
+	res, err := a.client.Do(req)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
+		// Report timeouts from either the context or the http.Client
+		// as a gateway timeout
+		var nerr net.Error
+		if ctx.Err() == context.DeadlineExceeded || (errors.As(err, &nerr) && nerr.Timeout()) {
+			return "", http.StatusGatewayTimeout, err
+		}
+
+		return "", http.StatusInternalServerError, err
 	}
 	defer res.Body.Close()
 
 	// Verify authentication server did not return an error
 	if c := res.StatusCode; c > 299 || c < 200 {
-		http.Error(w, fmt.Sprintf("authentication server error: HTTP %03d", c), http.StatusBadGateway)
-		return
+		return "", http.StatusBadGateway, fmt.Errorf("authentication server error: HTTP %03d", c)
 	}
 
 	// Verify authentication server returned JSON
 	if !strings.Contains(res.Header.Get("Content-Type"), jsonContentType) {
-		http.Error(w, "authentication server sent non-JSON content", http.StatusBadGateway)
-		return
+		return "", http.StatusBadGateway, errors.New("authentication server sent non-JSON content")
 	}
 
 	// Temporary struct for JSON body
@@ -178,11 +240,90 @@ func (a *AuthHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 
 	// Decode JSON body to retrieve token
 	if err := json.NewDecoder(res.Body).Decode(&v); err != nil {
-		http.Error(w, err.Error(), http.StatusBadGateway)
+		return "", http.StatusBadGateway, err
+	}
+
+	return v.Response.AccessToken, http.StatusOK, nil
+}
+
+// AuthResult is the outcome of a OneShotAuthHandler's authentication process.
+// If authentication succeeded, Token contains the generated access token.
+// Otherwise, Err contains the error which occurred.
+type AuthResult struct {
+	Token string
+	Err   error
+}
+
+// OneShotAuthHandler implements http.Handler, and performs a single OAuth
+// authentication with Untappd APIv4.  The outcome is delivered exactly once
+// on the channel returned by Result, at which point the http.Server serving
+// the handler can be shut down cleanly using its Shutdown method.
+type OneShotAuthHandler struct {
+	h *AuthHandler
+
+	mu      sync.Mutex
+	done    bool
+	resultC chan AuthResult
+}
+
+// NewOneShotAuthHandler creates a OneShotAuthHandler which can be used to
+// authenticate a single user using the Server Side Authentication process,
+// documented here: https://untappd.com/api/docs#authentication.
+//
+// The parameters and return values are the same as those of NewAuthHandler,
+// except that the generated token is always written to the HTTP response
+// body, and is also delivered using the OneShotAuthHandler's Result channel.
+func NewOneShotAuthHandler(clientID string, clientSecret string, redirectURL string, client *http.Client, options ...AuthHandlerOption) (*OneShotAuthHandler, *url.URL, error) {
+	h, cu, err := NewAuthHandler(clientID, clientSecret, redirectURL, nil, client, options...)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return &OneShotAuthHandler{
+		h: h,
+		// Buffered so that ServeHTTP never blocks on a slow receiver
+		resultC: make(chan AuthResult, 1),
+	}, cu, nil
+}
+
+// Result returns a channel which receives the outcome of the authentication
+// process.  Exactly one AuthResult is sent on the channel.
+func (o *OneShotAuthHandler) Result() <-chan AuthResult {
+	return o.resultC
+}
+
+// ServeHTTP implements http.Handler.  Malformed requests, such as those
+// with no 'code' parameter, are rejected but do not complete the
+// authentication process.  Once a token exchange succeeds or fails, all
+// further requests are rejected with HTTP 410.
+func (o *OneShotAuthHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if o.done {
+		http.Error(w, "authentication already completed", http.StatusGone)
 		return
 	}
 
-	// Invoke TokenHandlerFunc to provide easy access to the generated token,
-	// so the client can do whatever they please with it
-	a.handler(v.Response.AccessToken, w, r)
+	token, code, err := o.h.token(r)
+	if err != nil {
+		http.Error(w, err.Error(), code)
+
+		// Client errors, such as a browser requesting a favicon, are not
+		// the outcome of a token exchange
+		if code >= 500 {
+			o.finish(AuthResult{Err: err})
+		}
+		return
+	}
+
+	defaultTokenFn(token, w, r)
+	o.finish(AuthResult{Token: token})
+}
+
+// finish delivers the outcome of the authentication process.  o.mu must be
+// held when calling finish.
+func (o *OneShotAuthHandler) finish(res AuthResult) {
+	o.done = true
+	o.resultC <- res
 }
@@ -1,76 +1,191 @@
 package untappd
 
 import (
+	"errors"
+	"math"
 	"net/http"
 	"net/url"
 	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+var (
+	// ErrConflictingVenue is returned when both the VenueID and FoursquareID
+	// members of a CheckinRequest are set.
+	ErrConflictingVenue = errors.New("only one of venue ID and foursquare ID may be set")
+
+	// ErrInvalidRating is returned when the Rating member of a
+	// CheckinRequest is not zero, and is outside the range of ratings
+	// accepted by Untappd.
+	ErrInvalidRating = errors.New("rating must be between 0.5 and 5")
+
+	// ErrCommentTooLong is returned when the Comment member of a
+	// CheckinRequest is longer than MaxCheckinCommentLength characters.
+	ErrCommentTooLong = errors.New("comment must be at most " + strconv.Itoa(MaxCheckinCommentLength) + " characters")
+
+	// ErrSocialNotLinked is matched by an *Error, using errors.Is, when the
+	// Untappd APIv4 rejects a checkin because it was shared to a social
+	// network which the authenticated user has not linked to their account.
+	ErrSocialNotLinked = errors.New("social network is not linked to user account")
+
+	// ErrDuplicateCheckin is returned when a CheckinRequest's ClientKey
+	// matches that of a checkin submitted by the same Client within the
+	// last 5 minutes.
+	ErrDuplicateCheckin = errors.New("duplicate checkin")
+)
+
+// MaxCheckinCommentLength is the maximum number of characters accepted by
+// Untappd in the comment of a checkin.
+const MaxCheckinCommentLength = 140
+
+const (
+	// checkinKeyWindow is the duration for which a Client remembers the
+	// ClientKey of a submitted checkin.
+	checkinKeyWindow = 5 * time.Minute
+
+	// minRating and maxRating are the minimum and maximum ratings accepted
+	// by Untappd, and ratingStep is the increment between ratings.
+	minRating  = 0.5
+	maxRating  = 5.0
+	ratingStep = 0.25
 )
 
 // CheckinRequest represents a request to check-in a beer to Untappd.
 // To perform a successful checkin, the BeerID, GMTOffset, and TimeZone
 // members must be filled in.  The easiest way to obtain the GMTOffset
-// and TimeZone for your current system is to use the time package from
-// the standard library:
-//   beerID := 1
-//   timezone, offset := time.Now().Zone()
-//   offset = offset / 60 / 60
-//
+// and TimeZone for your current system is to use FillTimeZone:
 //   request := untappd.CheckinRequest{
-//       BeerID:    beerID,
-//       GMTOffset: offset,
-//       TimeZone:  timezone,
+//       BeerID: 1,
 //   }
+//   request.FillTimeZone(time.Now())
 type CheckinRequest struct {
 	// Mandatory parameters
-	BeerID    int
+	BeerID    int64
 	GMTOffset int
 	TimeZone  string
 
 	// Optional parameters
 
-	// Checkin location
+	// Checkin location.  VenueID and FoursquareID are mutually exclusive,
+	// and only one of them may be set.
+	VenueID      int64
 	FoursquareID string
 	Latitude     float64
 	Longitude    float64
 
-	// User comment and rating
+	// Human-readable location name, displayed with a checkin which is
+	// not associated with a Foursquare venue
+	LocationName string
+
+	// User comment and rating.  Trailing whitespace is trimmed from
+	// Comment, which may then contain at most MaxCheckinCommentLength
+	// characters.  Rating must be zero, meaning no rating, or between 0.5
+	// and 5.  Ratings are rounded to the nearest 0.25, the increment
+	// accepted by Untappd, so a rating of 3.3 is sent as 3.25.
 	Comment string
 	Rating  float64
 
-	// Send to social media?
+	// Send to social media?  Each network must be linked to the
+	// authenticated user's Untappd account, or the checkin fails with an
+	// error matching ErrSocialNotLinked.
 	Facebook bool
 	Twitter  bool
 	// FoursquareID is required if this is true
 	Foursquare bool
+
+	// Application attribution, displayed by Untappd as the source of this
+	// checkin.  Only honored for applications approved by Untappd.
+	AppName    string
+	AppVersion string
+
+	// Optional key which uniquely identifies this checkin to the client,
+	// such as a UUID generated when a user begins a checkin.  If a checkin
+	// with the same ClientKey was submitted by the same Client within the
+	// last 5 minutes, ErrDuplicateCheckin is returned and no request is
+	// performed, so that a retried submission cannot create a duplicate
+	// checkin.  ClientKey is tracked in memory, and is not sent to Untappd.
+	ClientKey string
+}
+
+// CheckinTimeZone returns the time zone abbreviation and GMT offset in hours
+// for t, suitable for use in the TimeZone and GMTOffset members of a
+// CheckinRequest.
+//
+// The Untappd APIv4 only accepts a GMT offset in whole hours.  For time zones
+// with a fractional hour offset, such as India Standard Time (+05:30) or
+// Newfoundland Standard Time (-03:30), the offset is truncated toward zero,
+// producing 5 and -3 respectively.
+func CheckinTimeZone(t time.Time) (timezone string, gmtOffset int) {
+	timezone, offset := t.Zone()
+	return timezone, offset / 60 / 60
+}
+
+// FillTimeZone sets the TimeZone and GMTOffset members of r using the
+// time zone of t.  See CheckinTimeZone for details.
+func (r *CheckinRequest) FillTimeZone(t time.Time) {
+	r.TimeZone, r.GMTOffset = CheckinTimeZone(t)
 }
 
 // Checkin checks-in a beer specified by the input CheckinRequest struct.
 // A variety of struct members can be filled in to specify the rating,
 // comment, etc. for a checkin.
+//
+// If both r.VenueID and r.FoursquareID are set, ErrConflictingVenue is
+// returned and no request is performed.  If r.Rating is not zero and is
+// outside the range of ratings accepted by Untappd, ErrInvalidRating is
+// returned and no request is performed.  If r.Comment is longer than
+// MaxCheckinCommentLength characters, ErrCommentTooLong is returned and no
+// request is performed.  If r requests sharing to a social
+// network which is not linked to the user's account, the returned error
+// matches ErrSocialNotLinked.
 func (a *AuthService) Checkin(r CheckinRequest) (*Checkin, *http.Response, error) {
+	if r.VenueID != 0 && r.FoursquareID != "" {
+		return nil, nil, ErrConflictingVenue
+	}
+
+	rating, err := checkRating(r.Rating)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	comment, err := checkComment(r.Comment)
+	if err != nil {
+		return nil, nil, err
+	}
+
 	// Add required parameters
 	q := url.Values{
-		"bid":        []string{strconv.Itoa(r.BeerID)},
+		"bid":        []string{strconv.FormatInt(r.BeerID, 10)},
 		"gmt_offset": []string{strconv.Itoa(r.GMTOffset)},
 		"timezone":   []string{r.TimeZone},
 	}
 
 	// Add optional parameters, if not empty
+	if r.VenueID != 0 {
+		q.Set("venue_id", strconv.FormatInt(r.VenueID, 10))
+	}
 	if r.FoursquareID != "" {
 		q.Set("foursquare_id", r.FoursquareID)
 	}
 	if r.Latitude != 0 {
-		q.Set("geolat", formatFloat(r.Latitude))
+		q.Set("geolat", formatCoord(r.Latitude))
 	}
 	if r.Longitude != 0 {
-		q.Set("geolng", formatFloat(r.Longitude))
+		q.Set("geolng", formatCoord(r.Longitude))
+	}
+	if r.LocationName != "" {
+		q.Set("location", r.LocationName)
 	}
 
-	if r.Comment != "" {
-		q.Set("shout", r.Comment)
+	if comment != "" {
+		q.Set("shout", comment)
 	}
-	if r.Rating != 0 {
-		q.Set("rating", formatFloat(r.Rating))
+	if rating != 0 {
+		q.Set("rating", formatRating(rating))
 	}
 
 	if r.Facebook {
@@ -83,6 +198,18 @@ func (a *AuthService) Checkin(r CheckinRequest) (*Checkin, *http.Response, error
 		q.Set("foursquare", "on")
 	}
 
+	if r.AppName != "" {
+		q.Set("app_name", r.AppName)
+	}
+	if r.AppVersion != "" {
+		q.Set("app_version", r.AppVersion)
+	}
+
+	// Suppress checkins which may duplicate one already submitted
+	if r.ClientKey != "" && !a.client.checkinKeys.add(r.ClientKey, time.Now()) {
+		return nil, nil, ErrDuplicateCheckin
+	}
+
 	// Temporary struct to unmarshal checkin JSON
 	var v struct {
 		Response rawCheckin `json:"response"`
@@ -91,8 +218,81 @@ func (a *AuthService) Checkin(r CheckinRequest) (*Checkin, *http.Response, error
 	// Perform request to check in a beer
 	res, err := a.client.request("POST", "checkin/add", q, nil, &v)
 	if err != nil {
+		// If the API explicitly rejected the checkin, no checkin was
+		// created, so the key may be used again.  Any other error leaves
+		// the outcome unknown, so the key is retained.
+		if _, ok := err.(*Error); ok && r.ClientKey != "" {
+			a.client.checkinKeys.remove(r.ClientKey)
+		}
+
 		return nil, res, err
 	}
 
 	return v.Response.export(), res, nil
 }
+
+// checkComment trims trailing whitespace from comment, and verifies that it
+// is no longer than MaxCheckinCommentLength characters.
+func checkComment(comment string) (string, error) {
+	comment = strings.TrimRightFunc(comment, unicode.IsSpace)
+	if utf8.RuneCountInString(comment) > MaxCheckinCommentLength {
+		return "", ErrCommentTooLong
+	}
+
+	return comment, nil
+}
+
+// checkRating verifies that rating is zero, or within the range of ratings
+// accepted by Untappd, and rounds it to the nearest ratingStep.
+func checkRating(rating float64) (float64, error) {
+	if rating == 0 {
+		return 0, nil
+	}
+
+	// Negated comparisons also reject NaN
+	if !(rating >= minRating && rating <= maxRating) {
+		return 0, ErrInvalidRating
+	}
+
+	return math.Round(rating/ratingStep) * ratingStep, nil
+}
+
+// checkinKeys tracks the ClientKeys of recently submitted checkins.  The zero
+// value is ready to use.
+type checkinKeys struct {
+	mu   sync.Mutex
+	keys map[string]time.Time
+}
+
+// add records key as submitted at time now.  add returns false if key was
+// already submitted within checkinKeyWindow of now.
+func (k *checkinKeys) add(key string, now time.Time) bool {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	if k.keys == nil {
+		k.keys = make(map[string]time.Time)
+	}
+
+	// Forget keys which are no longer within the window
+	for kk, t := range k.keys {
+		if now.Sub(t) >= checkinKeyWindow {
+			delete(k.keys, kk)
+		}
+	}
+
+	if _, ok := k.keys[key]; ok {
+		return false
+	}
+
+	k.keys[key] = now
+	return true
+}
+
+// remove forgets key, so that it may be submitted again.
+func (k *checkinKeys) remove(key string) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	delete(k.keys, key)
+}
@@ -0,0 +1,14 @@
+package untappd
+
+import (
+	"net/http"
+	"strconv"
+)
+
+// DeleteCheckin deletes a checkin specified by its ID.  Only checkins made
+// by the authenticated user may be deleted; attempting to delete another
+// user's checkin returns an error from the Untappd APIv4.
+func (a *AuthService) DeleteCheckin(checkinID int64) (*http.Response, error) {
+	// Perform request to delete a checkin
+	return a.client.request("POST", "checkin/delete/"+strconv.FormatInt(checkinID, 10), nil, nil, nil)
+}
@@ -0,0 +1,76 @@
+package untappd
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// TestClientAuthDeleteCheckinOK verifies that Client.Auth.DeleteCheckin
+// requests the correct checkin deletion path.
+func TestClientAuthDeleteCheckinOK(t *testing.T) {
+	checkinID := int64(137117722)
+
+	c, done := authDeleteCheckinTestClient(t, func(t *testing.T, w http.ResponseWriter, r *http.Request) {
+		path := "/v4/checkin/delete/" + strconv.FormatInt(checkinID, 10) + "/"
+		if p := r.URL.Path; p != path {
+			t.Fatalf("unexpected URL path: %q != %q", p, path)
+		}
+
+		w.Write([]byte("{}"))
+	})
+	defer done()
+
+	if _, err := c.Auth.DeleteCheckin(checkinID); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// TestClientAuthDeleteCheckinUnauthorized verifies that Client.Auth.DeleteCheckin
+// returns an error when the authenticated user does not own a checkin.
+func TestClientAuthDeleteCheckinUnauthorized(t *testing.T) {
+	c, done := authDeleteCheckinTestClient(t, func(t *testing.T, w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write(unauthorizedDeleteCheckinErrJSON)
+	})
+	defer done()
+
+	_, err := c.Auth.DeleteCheckin(1)
+	uErr := assertInvalidCommonErr(t, err)
+
+	detail := "You are not authorized to delete this check-in."
+	if d := uErr.Detail; d != detail {
+		t.Fatalf("unexpected error detail: %q != %q", d, detail)
+	}
+	eType := "invalid_auth"
+	if e := uErr.Type; e != eType {
+		t.Fatalf("unexpected error type: %q != %q", e, eType)
+	}
+}
+
+// authDeleteCheckinTestClient builds upon testClient, and adds additional sanity
+// checks for tests which target the checkin deletion API.
+func authDeleteCheckinTestClient(t *testing.T, fn func(t *testing.T, w http.ResponseWriter, r *http.Request)) (*Client, func()) {
+	return testClient(t, func(t *testing.T, w http.ResponseWriter, r *http.Request) {
+		// Always POST request
+		method := "POST"
+		if m := r.Method; m != method {
+			t.Fatalf("unexpected HTTP method: %q != %q", m, method)
+		}
+
+		// Always uses specific path prefix
+		prefix := "/v4/checkin/delete/"
+		if p := r.URL.Path; !strings.HasPrefix(p, prefix) {
+			t.Fatalf("unexpected HTTP path prefix: %q != %q", p, prefix)
+		}
+
+		// Guard against panics
+		if fn != nil {
+			fn(t, w, r)
+		}
+	})
+}
+
+// Canned error JSON returned when deleting another user's checkin
+var unauthorizedDeleteCheckinErrJSON = []byte(`{"meta":{"code":500,"error_detail":"You are not authorized to delete this check-in.","error_type":"invalid_auth","developer_friendly":"","response_time":{"time":0,"measure":"seconds"}},"response":[]}`)
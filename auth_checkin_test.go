@@ -1,6 +1,8 @@
 package untappd
 
 import (
+	"errors"
+	"math"
 	"net/http"
 	"net/url"
 	"strconv"
@@ -12,24 +14,23 @@ import (
 // TestClientAuthCheckinOK verifies that Client.Auth.Checkin always sets the
 // appropriate POST body parameters for a valid checkin.
 func TestClientAuthCheckinOK(t *testing.T) {
-	beerID := 1
-	sBeerID := strconv.Itoa(beerID)
+	beerID := int64(1)
+	sBeerID := strconv.FormatInt(beerID, 10)
 
-	timezone, offset := time.Now().Zone()
-	offset = offset / 60 / 60
+	timezone, offset := CheckinTimeZone(time.Now())
 	sOffset := strconv.Itoa(offset)
 
 	foursquareID := "ABCDEF"
 
 	latitude := 1.0
-	sLatitude := formatFloat(latitude)
+	sLatitude := "1.000000"
 	longitude := 1.0
-	sLongitude := formatFloat(longitude)
+	sLongitude := "1.000000"
 
 	comment := "hello world"
 
 	rating := 3.5
-	sRating := formatFloat(rating)
+	sRating := "3.5"
 
 	facebook := true
 	twitter := true
@@ -73,10 +74,404 @@ func TestClientAuthCheckinOK(t *testing.T) {
 	}
 }
 
+// TestClientAuthCheckinAppAttribution verifies that Client.Auth.Checkin only
+// sends application attribution parameters when they are set.
+func TestClientAuthCheckinAppAttribution(t *testing.T) {
+	var tests = []struct {
+		description string
+		name        string
+		version     string
+	}{
+		{
+			description: "no attribution",
+		},
+		{
+			description: "name only",
+			name:        "untappdctl",
+		},
+		{
+			description: "name and version",
+			name:        "untappdctl",
+			version:     "0.0.1",
+		},
+	}
+
+	for _, tt := range tests {
+		c, done := authCheckinTestClient(t, func(t *testing.T, w http.ResponseWriter, r *http.Request) {
+			if err := r.ParseForm(); err != nil {
+				t.Fatal(err)
+			}
+
+			for _, p := range []struct {
+				key   string
+				value string
+			}{
+				{key: "app_name", value: tt.name},
+				{key: "app_version", value: tt.version},
+			} {
+				_, ok := r.PostForm[p.key]
+				if got, want := ok, p.value != ""; got != want {
+					t.Fatalf("unexpected presence of parameter %q for test %q: %v != %v", p.key, tt.description, got, want)
+				}
+				if got := r.PostForm.Get(p.key); got != p.value {
+					t.Fatalf("unexpected parameter %q for test %q: %q != %q", p.key, tt.description, got, p.value)
+				}
+			}
+
+			w.Write([]byte("{}"))
+		})
+
+		_, _, err := c.Auth.Checkin(CheckinRequest{
+			BeerID:     1,
+			AppName:    tt.name,
+			AppVersion: tt.version,
+		})
+		done()
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+// TestClientAuthCheckinLocationName verifies that Client.Auth.Checkin only
+// sends a location name parameter when it is set.
+func TestClientAuthCheckinLocationName(t *testing.T) {
+	var tests = []struct {
+		description string
+		location    string
+	}{
+		{
+			description: "no location",
+		},
+		{
+			description: "location",
+			location:    "Back Porch",
+		},
+	}
+
+	for _, tt := range tests {
+		c, done := authCheckinTestClient(t, func(t *testing.T, w http.ResponseWriter, r *http.Request) {
+			if err := r.ParseForm(); err != nil {
+				t.Fatal(err)
+			}
+
+			_, ok := r.PostForm["location"]
+			if got, want := ok, tt.location != ""; got != want {
+				t.Fatalf("unexpected presence of location parameter for test %q: %v != %v", tt.description, got, want)
+			}
+			if got := r.PostForm.Get("location"); got != tt.location {
+				t.Fatalf("unexpected location parameter for test %q: %q != %q", tt.description, got, tt.location)
+			}
+
+			w.Write([]byte("{}"))
+		})
+
+		_, _, err := c.Auth.Checkin(CheckinRequest{
+			BeerID:       1,
+			LocationName: tt.location,
+		})
+		done()
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+// TestClientAuthCheckinVenueID verifies that Client.Auth.Checkin sends a
+// venue ID parameter when it is set.
+func TestClientAuthCheckinVenueID(t *testing.T) {
+	venueID := int64(2200)
+
+	c, done := authCheckinTestClient(t, func(t *testing.T, w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			t.Fatal(err)
+		}
+
+		if got, want := r.PostForm.Get("venue_id"), strconv.FormatInt(venueID, 10); got != want {
+			t.Fatalf("unexpected venue_id parameter: %q != %q", got, want)
+		}
+		if _, ok := r.PostForm["foursquare_id"]; ok {
+			t.Fatal("unexpected foursquare_id parameter")
+		}
+
+		w.Write([]byte("{}"))
+	})
+	defer done()
+
+	if _, _, err := c.Auth.Checkin(CheckinRequest{
+		BeerID:  1,
+		VenueID: venueID,
+	}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// TestClientAuthCheckinConflictingVenue verifies that Client.Auth.Checkin
+// returns an error without performing a request when both a venue ID and
+// a Foursquare ID are set.
+func TestClientAuthCheckinConflictingVenue(t *testing.T) {
+	c, done := authCheckinTestClient(t, func(t *testing.T, w http.ResponseWriter, r *http.Request) {
+		t.Fatal("request should not have been performed")
+	})
+	defer done()
+
+	_, _, err := c.Auth.Checkin(CheckinRequest{
+		BeerID:       1,
+		VenueID:      2200,
+		FoursquareID: "ABCDEF",
+	})
+	if err != ErrConflictingVenue {
+		t.Fatalf("unexpected error: %v != %v", err, ErrConflictingVenue)
+	}
+}
+
+// TestClientAuthCheckinRating verifies that Client.Auth.Checkin validates
+// and rounds ratings, and rejects invalid ratings without performing a
+// request.
+func TestClientAuthCheckinRating(t *testing.T) {
+	var tests = []struct {
+		description string
+		rating      float64
+		param       string
+		err         error
+	}{
+		{
+			description: "valid",
+			rating:      3.75,
+			param:       "3.75",
+		},
+		{
+			description: "minimum",
+			rating:      0.5,
+			param:       "0.5",
+		},
+		{
+			description: "maximum",
+			rating:      5,
+			param:       "5",
+		},
+		{
+			description: "zero, no rating",
+		},
+		{
+			description: "non-step, rounded down",
+			rating:      3.3,
+			param:       "3.25",
+		},
+		{
+			description: "non-step, rounded up",
+			rating:      4.9,
+			param:       "5",
+		},
+		{
+			description: "below minimum",
+			rating:      0.25,
+			err:         ErrInvalidRating,
+		},
+		{
+			description: "above maximum",
+			rating:      5.1,
+			err:         ErrInvalidRating,
+		},
+		{
+			description: "negative",
+			rating:      -1,
+			err:         ErrInvalidRating,
+		},
+		{
+			description: "NaN",
+			rating:      math.NaN(),
+			err:         ErrInvalidRating,
+		},
+	}
+
+	for _, tt := range tests {
+		c, done := authCheckinTestClient(t, func(t *testing.T, w http.ResponseWriter, r *http.Request) {
+			if tt.err != nil {
+				t.Fatalf("request should not have been performed for test %q", tt.description)
+			}
+
+			if err := r.ParseForm(); err != nil {
+				t.Fatal(err)
+			}
+
+			_, ok := r.PostForm["rating"]
+			if want := tt.param != ""; ok != want {
+				t.Fatalf("unexpected presence of rating parameter for test %q: %v != %v", tt.description, ok, want)
+			}
+			if got := r.PostForm.Get("rating"); got != tt.param {
+				t.Fatalf("unexpected rating parameter for test %q: %q != %q", tt.description, got, tt.param)
+			}
+
+			w.Write([]byte("{}"))
+		})
+
+		_, _, err := c.Auth.Checkin(CheckinRequest{
+			BeerID: 1,
+			Rating: tt.rating,
+		})
+		done()
+		if err != tt.err {
+			t.Fatalf("unexpected error for test %q: %v != %v", tt.description, err, tt.err)
+		}
+	}
+}
+
+// TestClientAuthCheckinComment verifies that Client.Auth.Checkin trims
+// trailing whitespace from comments, and rejects comments which are too long
+// without performing a request.
+func TestClientAuthCheckinComment(t *testing.T) {
+	max := strings.Repeat("a", MaxCheckinCommentLength)
+
+	var tests = []struct {
+		description string
+		comment     string
+		param       string
+		err         error
+	}{
+		{
+			description: "valid",
+			comment:     "Great brew!",
+			param:       "Great brew!",
+		},
+		{
+			description: "trailing whitespace",
+			comment:     "Great brew! \n\t",
+			param:       "Great brew!",
+		},
+		{
+			description: "maximum length",
+			comment:     max,
+			param:       max,
+		},
+		{
+			description: "maximum length, multi-byte characters",
+			comment:     strings.Repeat("\u00e9", MaxCheckinCommentLength),
+			param:       strings.Repeat("\u00e9", MaxCheckinCommentLength),
+		},
+		{
+			description: "maximum length, trailing whitespace",
+			comment:     max + "  ",
+			param:       max,
+		},
+		{
+			description: "too long",
+			comment:     max + "a",
+			err:         ErrCommentTooLong,
+		},
+	}
+
+	for _, tt := range tests {
+		c, done := authCheckinTestClient(t, func(t *testing.T, w http.ResponseWriter, r *http.Request) {
+			if tt.err != nil {
+				t.Fatalf("request should not have been performed for test %q", tt.description)
+			}
+
+			if err := r.ParseForm(); err != nil {
+				t.Fatal(err)
+			}
+
+			if got := r.PostForm.Get("shout"); got != tt.param {
+				t.Fatalf("unexpected shout parameter for test %q: %q != %q", tt.description, got, tt.param)
+			}
+
+			w.Write([]byte("{}"))
+		})
+
+		_, _, err := c.Auth.Checkin(CheckinRequest{
+			BeerID:  1,
+			Comment: tt.comment,
+		})
+		done()
+		if err != tt.err {
+			t.Fatalf("unexpected error for test %q: %v != %v", tt.description, err, tt.err)
+		}
+	}
+}
+
+// TestClientAuthCheckinDuplicateClientKey verifies that Client.Auth.Checkin
+// suppresses a second checkin submitted with the same client key.
+func TestClientAuthCheckinDuplicateClientKey(t *testing.T) {
+	var requests int
+	c, done := authCheckinTestClient(t, func(t *testing.T, w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Write([]byte("{}"))
+	})
+	defer done()
+
+	r := CheckinRequest{
+		BeerID:    1,
+		ClientKey: "foo",
+	}
+
+	if _, _, err := c.Auth.Checkin(r); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := c.Auth.Checkin(r); err != ErrDuplicateCheckin {
+		t.Fatalf("unexpected error: %v != %v", err, ErrDuplicateCheckin)
+	}
+
+	// A different key is not suppressed
+	r.ClientKey = "bar"
+	if _, _, err := c.Auth.Checkin(r); err != nil {
+		t.Fatal(err)
+	}
+
+	if requests != 2 {
+		t.Fatalf("unexpected number of requests: %d != %d", requests, 2)
+	}
+}
+
+// TestClientAuthCheckinClientKeyRejected verifies that Client.Auth.Checkin
+// permits a client key to be used again when the API rejects a checkin.
+func TestClientAuthCheckinClientKeyRejected(t *testing.T) {
+	var requests int
+	c, done := authCheckinTestClient(t, func(t *testing.T, w http.ResponseWriter, r *http.Request) {
+		requests++
+		if requests == 1 {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write(invalidCheckinErrJSON)
+			return
+		}
+
+		w.Write([]byte("{}"))
+	})
+	defer done()
+
+	r := CheckinRequest{
+		BeerID:    1,
+		ClientKey: "foo",
+	}
+
+	_, _, err := c.Auth.Checkin(r)
+	assertInvalidCheckinErr(t, err)
+
+	if _, _, err := c.Auth.Checkin(r); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// Test_checkinKeysWindow verifies that checkinKeys forgets keys which are
+// older than checkinKeyWindow.
+func Test_checkinKeysWindow(t *testing.T) {
+	var k checkinKeys
+	now := time.Date(2017, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	if !k.add("foo", now) {
+		t.Fatal("expected first key to be added")
+	}
+	if k.add("foo", now.Add(checkinKeyWindow-time.Second)) {
+		t.Fatal("expected key within window to be suppressed")
+	}
+	if !k.add("foo", now.Add(checkinKeyWindow)) {
+		t.Fatal("expected key outside window to be added")
+	}
+}
+
 // TestClientAuthCheckinBadBeerID verifies that Client.Auth.Checkin returns an
 // error when an invalid beer ID is checked-in.
 func TestClientAuthCheckinBadBeerID(t *testing.T) {
-	beerID := -1
+	beerID := int64(-1)
 
 	c, done := authCheckinTestClient(t, func(t *testing.T, w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusInternalServerError)
@@ -90,6 +485,120 @@ func TestClientAuthCheckinBadBeerID(t *testing.T) {
 	assertInvalidCheckinErr(t, err)
 }
 
+// TestClientAuthCheckinSocialNotLinked verifies that Client.Auth.Checkin
+// returns an error matching ErrSocialNotLinked when a checkin is shared to a
+// social network which the user has not linked.
+func TestClientAuthCheckinSocialNotLinked(t *testing.T) {
+	c, done := authCheckinTestClient(t, func(t *testing.T, w http.ResponseWriter, r *http.Request) {
+		assertBodyParameters(t, r, url.Values{
+			"twitter": []string{"on"},
+		})
+
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write(socialNotLinkedErrJSON)
+	})
+	defer done()
+
+	_, _, err := c.Auth.Checkin(CheckinRequest{
+		BeerID:  1,
+		Twitter: true,
+	})
+	if !errors.Is(err, ErrSocialNotLinked) {
+		t.Fatalf("unexpected error: %v != %v", err, ErrSocialNotLinked)
+	}
+	if errors.Is(err, ErrPrivateUser) {
+		t.Fatal("social error should not match ErrPrivateUser")
+	}
+}
+
+// TestClientAuthCheckinBadgesScore verifies that Client.Auth.Checkin returns
+// the badges and score earned by a new checkin.
+func TestClientAuthCheckinBadgesScore(t *testing.T) {
+	c, done := authCheckinTestClient(t, func(t *testing.T, w http.ResponseWriter, r *http.Request) {
+		w.Write(checkinAddJSON)
+	})
+	defer done()
+
+	checkin, _, err := c.Auth.Checkin(CheckinRequest{
+		BeerID: 1,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got, want := checkin.ID, int64(171626491); got != want {
+		t.Fatalf("unexpected ID: %d != %d", got, want)
+	}
+	if got, want := checkin.Score, 6.0; got != want {
+		t.Fatalf("unexpected Score: %v != %v", got, want)
+	}
+
+	if l := len(checkin.Badges); l != 1 {
+		t.Fatalf("unexpected number of badges: %d != %d", l, 1)
+	}
+	b := checkin.Badges[0]
+	if got, want := b.ID, int64(2); got != want {
+		t.Fatalf("unexpected badge ID: %d != %d", got, want)
+	}
+	if got, want := b.Name, "Newbie"; got != want {
+		t.Fatalf("unexpected badge Name: %q != %q", got, want)
+	}
+}
+
+// TestCheckinTimeZone verifies that CheckinTimeZone returns the correct time
+// zone and GMT offset in hours for a variety of time zones.
+func TestCheckinTimeZone(t *testing.T) {
+	var tests = []struct {
+		description string
+		loc         *time.Location
+		timezone    string
+		offset      int
+	}{
+		{
+			description: "positive",
+			loc:         time.FixedZone("CEST", 2*60*60),
+			timezone:    "CEST",
+			offset:      2,
+		},
+		{
+			description: "negative",
+			loc:         time.FixedZone("EST", -5*60*60),
+			timezone:    "EST",
+			offset:      -5,
+		},
+		{
+			description: "half-hour positive",
+			loc:         time.FixedZone("IST", (5*60*60)+(30*60)),
+			timezone:    "IST",
+			offset:      5,
+		},
+		{
+			description: "half-hour negative",
+			loc:         time.FixedZone("NST", -((3 * 60 * 60) + (30 * 60))),
+			timezone:    "NST",
+			offset:      -3,
+		},
+	}
+
+	for _, tt := range tests {
+		now := time.Date(2015, time.June, 1, 12, 0, 0, 0, tt.loc)
+
+		timezone, offset := CheckinTimeZone(now)
+		if timezone != tt.timezone {
+			t.Fatalf("unexpected timezone for test %q: %q != %q", tt.description, timezone, tt.timezone)
+		}
+		if offset != tt.offset {
+			t.Fatalf("unexpected offset for test %q: %d != %d", tt.description, offset, tt.offset)
+		}
+
+		var r CheckinRequest
+		r.FillTimeZone(now)
+		if r.TimeZone != timezone || r.GMTOffset != offset {
+			t.Fatalf("unexpected CheckinRequest for test %q: %q, %d", tt.description, r.TimeZone, r.GMTOffset)
+		}
+	}
+}
+
 // authCheckinTestClient builds upon testClient, and adds additional sanity checks
 // for tests which target the Check-in API.
 func authCheckinTestClient(t *testing.T, fn func(t *testing.T, w http.ResponseWriter, r *http.Request)) (*Client, func()) {
@@ -112,3 +621,47 @@ func authCheckinTestClient(t *testing.T, fn func(t *testing.T, w http.ResponseWr
 		}
 	})
 }
+
+// Canned checkin add JSON response, trimmed for brevity, which includes an
+// earned badge and score
+var checkinAddJSON = []byte(`{
+  "meta": {
+    "code": 200,
+    "response_time": {
+      "time": 0.311,
+      "measure": "seconds"
+    }
+  },
+  "notifications": [],
+  "response": {
+    "result": "success",
+    "checkin_id": 171626491,
+    "created_at": "Sat, 21 Mar 2015 21:36:54 +0000",
+    "checkin_comment": "hello world",
+    "rating_score": 3.5,
+    "beer": {
+      "bid": 1,
+      "beer_name": "Oberon Ale"
+    },
+    "brewery": {
+      "brewery_id": 2507,
+      "brewery_name": "Bell's Brewery, Inc."
+    },
+    "venue": [],
+    "badges": {
+      "count": 1,
+      "items": [
+        {
+          "badge_id": 2,
+          "checkin_id": 171626491,
+          "badge_name": "Newbie",
+          "badge_description": "You've checked in your first beer!",
+          "created_at": "Sat, 21 Mar 2015 21:36:54 +0000"
+        }
+      ]
+    },
+    "score": {
+      "total_points": 6
+    }
+  }
+}`)
@@ -1,7 +1,6 @@
 package untappd
 
 import (
-	"math"
 	"net/http"
 	"net/url"
 	"strconv"
@@ -9,11 +8,11 @@ import (
 	"testing"
 )
 
-// TestClientAuthCheckinsOK verifies that Client.Auth.Checkins always sets the
-// appropriate default minimum ID, maximum ID, and limit values.
+// TestClientAuthCheckinsOK verifies that Client.Auth.Checkins omits the
+// minimum and maximum IDs, and sets the appropriate default limit.
 func TestClientAuthCheckinsOK(t *testing.T) {
-	minID := "0"
-	maxID := strconv.Itoa(math.MaxInt32)
+	// No bounds are sent by default
+	minID, maxID := "", ""
 	limit := "25"
 
 	c, done := authCheckinsTestClient(t, func(t *testing.T, w http.ResponseWriter, r *http.Request) {
@@ -36,11 +35,11 @@ func TestClientAuthCheckinsOK(t *testing.T) {
 // TestClientAuthCheckinsMinMaxIDLimitOK verifies that Client.Auth.CheckinsMinMaxIDLimit
 // returns a valid checkins list, when used with correct parameters.
 func TestClientAuthCheckinsMinMaxIDLimitOffsetLimitOK(t *testing.T) {
-	var minID int
-	sMinID := strconv.Itoa(minID)
+	var minID int64 = 137117700
+	sMinID := strconv.FormatInt(minID, 10)
 
-	var maxID = math.MaxInt32
-	sMaxID := strconv.Itoa(maxID)
+	var maxID int64 = 137117722
+	sMaxID := strconv.FormatInt(maxID, 10)
 
 	var limit = 25
 	sLimit := strconv.Itoa(limit)
@@ -0,0 +1,23 @@
+package untappd
+
+import (
+	"net/http"
+)
+
+// Notifications queries for an authenticated user's notifications, including
+// the number of unread notifications of each type, and the user's recent
+// notifications, such as toasts, comments, and friend requests.
+func (a *AuthService) Notifications() (*Notifications, *http.Response, error) {
+	// Temporary struct to unmarshal notifications JSON
+	var v struct {
+		Response rawNotifications `json:"response"`
+	}
+
+	// Perform request for notifications
+	res, err := a.client.request("GET", "notifications", nil, nil, &v)
+	if err != nil {
+		return nil, res, err
+	}
+
+	return v.Response.export(), res, nil
+}
@@ -0,0 +1,216 @@
+package untappd
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+// TestClientAuthNotificationsOK verifies that Client.Auth.Notifications
+// returns a valid Notifications struct, when used with correct parameters.
+func TestClientAuthNotificationsOK(t *testing.T) {
+	c, done := authNotificationsTestClient(t, func(t *testing.T, w http.ResponseWriter, r *http.Request) {
+		w.Write(notificationsJSON)
+	})
+	defer done()
+
+	n, _, err := c.Auth.Notifications()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := NotificationCounts{
+		Comments: 2,
+		Toasts:   5,
+		Friends:  1,
+		Messages: 0,
+		News:     3,
+	}
+	if got := n.Unread; got != want {
+		t.Fatalf("unexpected unread counts: %+v != %+v", got, want)
+	}
+
+	if l := len(n.Items); l != 2 {
+		t.Fatalf("unexpected number of notifications: %d != %d", l, 2)
+	}
+
+	toast := n.Items[0]
+	if id := toast.ID; id != 8801 {
+		t.Fatalf("unexpected notification ID: %d != %d", id, 8801)
+	}
+	if typ := toast.Type; typ != "toast" {
+		t.Fatalf("unexpected notification type: %q != %q", typ, "toast")
+	}
+	if id := toast.CheckinID; id != 137117722 {
+		t.Fatalf("unexpected notification checkin ID: %d != %d", id, 137117722)
+	}
+	created := time.Date(2015, time.January, 10, 4, 12, 9, 0, time.UTC)
+	if c := toast.Created; !c.Equal(created) {
+		t.Fatalf("unexpected notification created time: %v != %v", c, created)
+	}
+	if u := toast.User; u == nil || u.UserName != "mdlayher" {
+		t.Fatalf("unexpected notification user: %+v", u)
+	}
+
+	friend := n.Items[1]
+	if typ := friend.Type; typ != "friend" {
+		t.Fatalf("unexpected notification type: %q != %q", typ, "friend")
+	}
+	if id := friend.CheckinID; id != 0 {
+		t.Fatalf("unexpected notification checkin ID: %d != %d", id, 0)
+	}
+}
+
+// TestClientAuthNotificationsEmpty verifies that Client.Auth.Notifications
+// returns zeroed counts and no items when the API returns empty arrays.
+func TestClientAuthNotificationsEmpty(t *testing.T) {
+	c, done := authNotificationsTestClient(t, func(t *testing.T, w http.ResponseWriter, r *http.Request) {
+		w.Write(emptyNotificationsJSON)
+	})
+	defer done()
+
+	n, _, err := c.Auth.Notifications()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got := n.Unread; got != (NotificationCounts{}) {
+		t.Fatalf("unexpected unread counts: %+v", got)
+	}
+	if l := len(n.Items); l != 0 {
+		t.Fatalf("unexpected number of notifications: %d != %d", l, 0)
+	}
+}
+
+// TestResponseNotifications verifies that ResponseNotifications returns the
+// notifications summary included with an arbitrary API response.
+func TestResponseNotifications(t *testing.T) {
+	tests := []struct {
+		desc   string
+		body   []byte
+		unread *NotificationCounts
+	}{
+		{
+			desc: "no notifications block",
+			body: []byte(`{"meta":{"code":200},"response":{}}`),
+		},
+		{
+			desc:   "empty array",
+			body:   userCheckinsJSON,
+			unread: &NotificationCounts{},
+		},
+		{
+			desc:   "unread counts",
+			body:   []byte(`{"meta":{"code":200},"notifications":{"type":"notifications","unread_count":{"comments":0,"toasts":4,"friends":1,"messages":0,"news":0}},"response":{}}`),
+			unread: &NotificationCounts{Toasts: 4, Friends: 1},
+		},
+	}
+
+	for _, tt := range tests {
+		c, done := testClient(t, func(t *testing.T, w http.ResponseWriter, r *http.Request) {
+			w.Write(tt.body)
+		})
+
+		res, err := c.request("GET", "foo", nil, nil, nil)
+		done()
+		if err != nil {
+			t.Fatalf("[%s] %v", tt.desc, err)
+		}
+
+		n, err := ResponseNotifications(res)
+		if err != nil {
+			t.Fatalf("[%s] %v", tt.desc, err)
+		}
+
+		if tt.unread == nil {
+			if n != nil {
+				t.Fatalf("[%s] unexpected notifications: %+v", tt.desc, n)
+			}
+			continue
+		}
+
+		if n == nil {
+			t.Fatalf("[%s] expected non-nil notifications", tt.desc)
+		}
+		if n.Unread != *tt.unread {
+			t.Fatalf("[%s] unexpected unread counts: %+v != %+v", tt.desc, n.Unread, *tt.unread)
+		}
+	}
+}
+
+// authNotificationsTestClient builds upon testClient, and adds additional
+// sanity checks for tests which target the notifications API.
+func authNotificationsTestClient(t *testing.T, fn func(t *testing.T, w http.ResponseWriter, r *http.Request)) (*Client, func()) {
+	return testClient(t, func(t *testing.T, w http.ResponseWriter, r *http.Request) {
+		// Always GET request
+		method := "GET"
+		if m := r.Method; m != method {
+			t.Fatalf("unexpected HTTP method: %q != %q", m, method)
+		}
+
+		// Always uses specific path
+		path := "/v4/notifications/"
+		if p := r.URL.Path; p != path {
+			t.Fatalf("unexpected HTTP path: %q != %q", p, path)
+		}
+
+		// Guard against panics
+		if fn != nil {
+			fn(t, w, r)
+		}
+	})
+}
+
+// Canned notifications JSON response, taken from Untappd APIv4 documentation
+// and trimmed for brevity
+var notificationsJSON = []byte(`{
+  "meta": {
+    "code": 200,
+    "response_time": {
+      "time": 0.041,
+      "measure": "seconds"
+    }
+  },
+  "notifications": [],
+  "response": {
+    "unread_count": {
+      "comments": 2,
+      "toasts": 5,
+      "friends": 1,
+      "messages": 0,
+      "news": 3
+    },
+    "notifications": {
+      "count": 2,
+      "items": [
+        {
+          "notification_id": 8801,
+          "notification_type": "toast",
+          "checkin_id": 137117722,
+          "created_at": "Sat, 10 Jan 2015 04:12:09 +0000",
+          "user": {
+            "uid": 1,
+            "user_name": "mdlayher",
+            "first_name": "Matt",
+            "last_name": "Layher"
+          }
+        },
+        {
+          "notification_id": 8802,
+          "notification_type": "friend",
+          "created_at": "Sat, 10 Jan 2015 05:00:00 +0000",
+          "user": {
+            "uid": 2,
+            "user_name": "gregavola",
+            "first_name": "Greg",
+            "last_name": "Avola"
+          }
+        }
+      ]
+    }
+  }
+}`)
+
+// Canned notifications JSON response for a user with no notifications,
+// where the API returns empty arrays in place of objects
+var emptyNotificationsJSON = []byte(`{"meta":{"code":200,"response_time":{"time":0,"measure":"seconds"}},"notifications":[],"response":{"unread_count":[],"notifications":[]}}`)
@@ -8,6 +8,7 @@ import (
 	"net/url"
 	"reflect"
 	"testing"
+	"time"
 )
 
 // TestNewAuthHandler verifies that NewAuthHandler returns appropriate errors
@@ -158,6 +159,136 @@ func TestAuthHandlerServeHTTPOK(t *testing.T) {
 	}
 }
 
+// TestAuthHandlerServeHTTPOAuthTimeout verifies that AuthHandler returns a
+// HTTP 504 if the upstream server does not respond before the configured
+// timeout elapses.
+func TestAuthHandlerServeHTTPOAuthTimeout(t *testing.T) {
+	// Block every upstream request until its context is canceled
+	client := &http.Client{
+		Transport: roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+			<-r.Context().Done()
+			return nil, r.Context().Err()
+		}),
+	}
+
+	h, _, err := NewAuthHandler(
+		"foo",
+		"bar",
+		"http://foo.com",
+		nil,
+		client,
+		WithAuthTimeout(50*time.Millisecond),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("GET", "/?code=foo", nil)
+
+	doneC := make(chan struct{})
+	go func() {
+		h.ServeHTTP(w, r)
+		close(doneC)
+	}()
+
+	select {
+	case <-doneC:
+	case <-time.After(5 * time.Second):
+		t.Fatal("handler did not return after timeout")
+	}
+
+	if got, want := w.Code, http.StatusGatewayTimeout; got != want {
+		t.Fatalf("unexpected HTTP status code: %d != %d", got, want)
+	}
+}
+
+// roundTripperFunc is an adapter which allows a function to be used as
+// a http.RoundTripper.
+type roundTripperFunc func(r *http.Request) (*http.Response, error)
+
+// RoundTrip implements http.RoundTripper.
+func (fn roundTripperFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return fn(r)
+}
+
+// TestOneShotAuthHandlerOK verifies that OneShotAuthHandler delivers the
+// access token on its Result channel, and rejects further requests.
+func TestOneShotAuthHandlerOK(t *testing.T) {
+	expectedToken := "ABCDEF0123456789"
+
+	oauthHost, done := testOAuthServer(t, func(t *testing.T, w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", jsonContentType)
+		w.Write([]byte(`{"response":{"access_token":"` + expectedToken + `"}}`))
+	})
+	defer done()
+
+	h, url, done2 := testOneShotAuthHandler(t, oauthHost)
+	defer done2()
+
+	// A request with no code parameter must not complete authentication
+	res, err := http.Get(url)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := res.StatusCode, http.StatusBadRequest; got != want {
+		t.Fatalf("unexpected HTTP status code: %d != %d", got, want)
+	}
+
+	res, err = http.Get(url + "?code=foo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := res.StatusCode, http.StatusOK; got != want {
+		t.Fatalf("unexpected HTTP status code: %d != %d", got, want)
+	}
+
+	result := <-h.Result()
+	if err := result.Err; err != nil {
+		t.Fatal(err)
+	}
+	if got, want := result.Token, expectedToken; got != want {
+		t.Fatalf("unexpected access token: %q != %q", got, want)
+	}
+
+	// Authentication already completed
+	res, err = http.Get(url + "?code=foo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := res.StatusCode, http.StatusGone; got != want {
+		t.Fatalf("unexpected HTTP status code: %d != %d", got, want)
+	}
+}
+
+// TestOneShotAuthHandlerOAuthError verifies that OneShotAuthHandler delivers
+// an error on its Result channel if the upstream server returns an error.
+func TestOneShotAuthHandlerOAuthError(t *testing.T) {
+	oauthHost, done := testOAuthServer(t, func(t *testing.T, w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+	})
+	defer done()
+
+	h, url, done2 := testOneShotAuthHandler(t, oauthHost)
+	defer done2()
+
+	res, err := http.Get(url + "?code=foo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := res.StatusCode, http.StatusBadGateway; got != want {
+		t.Fatalf("unexpected HTTP status code: %d != %d", got, want)
+	}
+
+	result := <-h.Result()
+	if result.Err == nil {
+		t.Fatal("error should have occurred, but error is nil")
+	}
+	if result.Token != "" {
+		t.Fatalf("unexpected access token: %q", result.Token)
+	}
+}
+
 // errBrokenWriter is always returned by brokenResponseWriter.Write.
 var errBrokenWriter = errors.New("broken writer")
 
@@ -223,6 +354,29 @@ func testAuthHandler(t *testing.T, redirectURL string, oauthHost string, fn Toke
 	}
 }
 
+// testOneShotAuthHandler creates a mocked OneShotAuthHandler which points at a
+// httptest server, and returns the handler, that server's URL, and a function
+// to shut it down.
+func testOneShotAuthHandler(t *testing.T, oauthHost string) (*OneShotAuthHandler, string, func()) {
+	h, _, err := NewOneShotAuthHandler(
+		"foo",
+		"bar",
+		"http://foo.com",
+		nil,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	h.h.oAuthURL.Scheme = "http"
+	h.h.oAuthURL.Host = oauthHost
+
+	srv := httptest.NewServer(h)
+	return h, srv.URL, func() {
+		srv.Close()
+	}
+}
+
 // testAuthHandler creates a httptest server which mocks an upstream OAuth server,
 // and which invokes an input closure, returning that server's host and a function
 // to shut it down.
@@ -0,0 +1,32 @@
+package untappd
+
+import (
+	"net/http"
+	"strconv"
+)
+
+// Toast toasts a checkin specified by its ID, on behalf of the authenticated
+// user.  The returned ToastResult contains the created Toast and the updated
+// number of toasts for the checkin, so that a toast count can be updated
+// without querying for the checkin again.
+//
+// If the authenticated user has already toasted the checkin, the Untappd
+// APIv4 removes the existing toast instead, and the returned ToastResult's
+// Toast field is nil.
+//
+// Checkin.ApplyToast may be used to update a Checkin using the returned
+// ToastResult.
+func (a *AuthService) Toast(checkinID int64) (*ToastResult, *http.Response, error) {
+	// Temporary struct to unmarshal toast JSON
+	var v struct {
+		Response rawToastResult `json:"response"`
+	}
+
+	// Perform request to toast a checkin
+	res, err := a.client.request("POST", "checkin/toast/"+strconv.FormatInt(checkinID, 10), nil, nil, &v)
+	if err != nil {
+		return nil, res, err
+	}
+
+	return v.Response.export(), res, nil
+}
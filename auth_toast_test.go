@@ -0,0 +1,167 @@
+package untappd
+
+import (
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+)
+
+// TestClientAuthToastOK verifies that Client.Auth.Toast returns the created
+// Toast and the updated toast count for a checkin.
+func TestClientAuthToastOK(t *testing.T) {
+	checkinID := int64(137117722)
+
+	c, done := authToastTestClient(t, func(t *testing.T, w http.ResponseWriter, r *http.Request) {
+		path := "/v4/checkin/toast/" + strconv.FormatInt(checkinID, 10) + "/"
+		if p := r.URL.Path; p != path {
+			t.Fatalf("unexpected URL path: %q != %q", p, path)
+		}
+
+		w.Write(toastJSON)
+	})
+	defer done()
+
+	tr, _, err := c.Auth.Toast(checkinID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if n := tr.TotalCount; n != 4 {
+		t.Fatalf("unexpected total toast count: %d != %d", n, 4)
+	}
+
+	toast := tr.Toast
+	if toast == nil {
+		t.Fatal("expected non-nil toast")
+	}
+	if id := toast.ID; id != 46651001 {
+		t.Fatalf("unexpected toast ID: %d != %d", id, 46651001)
+	}
+	if u := toast.User; u == nil || u.UserName != "mdlayher" {
+		t.Fatalf("unexpected toast user: %+v", u)
+	}
+	if c, want := toast.Created, time.Date(2015, time.January, 10, 4, 12, 9, 0, time.UTC); !c.Equal(want) {
+		t.Fatalf("unexpected toast Created: %v != %v", c, want)
+	}
+}
+
+// TestCheckinApplyToast verifies that Checkin.ApplyToast updates a Checkin
+// using the result of toasting it, or of removing a toast.
+func TestCheckinApplyToast(t *testing.T) {
+	// A toast returned by the API is never removed by ApplyToast
+	existing := &Toast{ID: 2}
+	c := &Checkin{
+		Toasts:     []*Toast{existing},
+		ToastCount: 3,
+	}
+
+	toast := &Toast{ID: 1}
+	c.ApplyToast(&ToastResult{Toast: toast, TotalCount: 4})
+
+	if !c.AuthToasted {
+		t.Fatal("expected checkin to be toasted")
+	}
+	if n := c.ToastCount; n != 4 {
+		t.Fatalf("unexpected toast count: %d != %d", n, 4)
+	}
+	if l := len(c.Toasts); l != 2 || c.Toasts[0] != existing || c.Toasts[1] != toast {
+		t.Fatalf("unexpected toasts: %+v", c.Toasts)
+	}
+
+	c.ApplyToast(&ToastResult{TotalCount: 3})
+
+	if c.AuthToasted {
+		t.Fatal("expected checkin to not be toasted")
+	}
+	if n := c.ToastCount; n != 3 {
+		t.Fatalf("unexpected toast count: %d != %d", n, 3)
+	}
+	if l := len(c.Toasts); l != 1 || c.Toasts[0] != existing {
+		t.Fatalf("unexpected toasts after removing toast: %+v", c.Toasts)
+	}
+
+	c.ApplyToast(nil)
+	if n := c.ToastCount; n != 3 {
+		t.Fatalf("unexpected toast count after nil result: %d != %d", n, 3)
+	}
+}
+
+// TestClientAuthToastUntoast verifies that Client.Auth.Toast returns a nil
+// Toast when an existing toast is removed.
+func TestClientAuthToastUntoast(t *testing.T) {
+	c, done := authToastTestClient(t, func(t *testing.T, w http.ResponseWriter, r *http.Request) {
+		w.Write(untoastJSON)
+	})
+	defer done()
+
+	tr, _, err := c.Auth.Toast(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if tr.Toast != nil {
+		t.Fatalf("unexpected toast: %+v", tr.Toast)
+	}
+	if n := tr.TotalCount; n != 3 {
+		t.Fatalf("unexpected total toast count: %d != %d", n, 3)
+	}
+}
+
+// authToastTestClient builds upon testClient, and adds additional sanity
+// checks for tests which target the toast API.
+func authToastTestClient(t *testing.T, fn func(t *testing.T, w http.ResponseWriter, r *http.Request)) (*Client, func()) {
+	return testClient(t, func(t *testing.T, w http.ResponseWriter, r *http.Request) {
+		// Always POST request
+		method := "POST"
+		if m := r.Method; m != method {
+			t.Fatalf("unexpected HTTP method: %q != %q", m, method)
+		}
+
+		// Guard against panics
+		if fn != nil {
+			fn(t, w, r)
+		}
+	})
+}
+
+// Canned toast JSON response, taken from Untappd APIv4 documentation
+// and trimmed for brevity
+var toastJSON = []byte(`{
+  "meta": {
+    "code": 200,
+    "response_time": {
+      "time": 0.061,
+      "measure": "seconds"
+    }
+  },
+  "notifications": [],
+  "response": {
+    "result": "success",
+    "like_type": "toast",
+    "like_id": 46651001,
+    "like_owner": false,
+    "toasts": {
+      "total_count": 4,
+      "count": 1,
+      "auth_toast": true,
+      "items": [
+        {
+          "uid": 1,
+          "like_id": 46651001,
+          "like_owner": false,
+          "created_at": "Sat, 10 Jan 2015 04:12:09 +0000",
+          "user": {
+            "uid": 1,
+            "user_name": "mdlayher",
+            "first_name": "Matt",
+            "last_name": "Layher"
+          }
+        }
+      ]
+    }
+  }
+}`)
+
+// Canned toast JSON response when an existing toast is removed
+var untoastJSON = []byte(`{"meta":{"code":200,"response_time":{"time":0,"measure":"seconds"}},"notifications":[],"response":{"result":"success","like_type":"untoast","like_id":0,"toasts":{"total_count":3,"count":0,"auth_toast":false,"items":[]}}}`)
@@ -0,0 +1,63 @@
+package untappd
+
+import (
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// WishListToggle adds a beer specified by its ID to the authenticated user's
+// wish list if it is not present, or removes it from the wish list if it is
+// present.  WishListToggle returns whether or not the beer is present in the
+// wish list after the toggle.
+//
+// The current state of the wish list is determined by querying for compact
+// information about the beer, so two requests are performed.  The returned
+// *http.Response is the response from the request which modified the
+// wish list.
+func (a *AuthService) WishListToggle(beerID int64) (bool, *http.Response, error) {
+	sID := strconv.FormatInt(beerID, 10)
+
+	// Determine if beer is currently present in the user's wish list
+	var info struct {
+		Response struct {
+			Beer rawBeer `json:"beer"`
+		} `json:"response"`
+	}
+
+	res, err := a.client.request("GET", "beer/info/"+sID, nil, url.Values{
+		"compact": []string{"true"},
+	}, &info)
+	if err != nil {
+		return false, res, err
+	}
+
+	endpoint := "user/wishlist/add"
+	if info.Response.Beer.WishList {
+		endpoint = "user/wishlist/delete"
+	}
+
+	// Temporary struct to unmarshal wish list JSON
+	var v struct {
+		Response struct {
+			Result string   `json:"result"`
+			Beer   *rawBeer `json:"beer"`
+		} `json:"response"`
+	}
+
+	// Perform request to add or remove beer from wish list
+	res, err = a.client.request("GET", endpoint, nil, url.Values{
+		"bid": []string{sID},
+	}, &v)
+	if err != nil {
+		return false, res, err
+	}
+
+	// Prefer the state reported by the API, but if it was not returned,
+	// the beer's state must have been toggled
+	if b := v.Response.Beer; b != nil {
+		return b.WishList, res, nil
+	}
+
+	return !info.Response.Beer.WishList, res, nil
+}
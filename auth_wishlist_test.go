@@ -0,0 +1,90 @@
+package untappd
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+)
+
+// TestClientAuthWishListToggleAdd verifies that Client.Auth.WishListToggle
+// adds a beer which is not present in the wish list.
+func TestClientAuthWishListToggleAdd(t *testing.T) {
+	c, done := authWishListTestClient(t, false, "/v4/user/wishlist/add/", wishListAddJSON)
+	defer done()
+
+	added, _, err := c.Auth.WishListToggle(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !added {
+		t.Fatal("expected beer to be added to wish list")
+	}
+}
+
+// TestClientAuthWishListToggleRemove verifies that Client.Auth.WishListToggle
+// removes a beer which is present in the wish list.
+func TestClientAuthWishListToggleRemove(t *testing.T) {
+	c, done := authWishListTestClient(t, true, "/v4/user/wishlist/delete/", wishListRemoveJSON)
+	defer done()
+
+	added, _, err := c.Auth.WishListToggle(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if added {
+		t.Fatal("expected beer to be removed from wish list")
+	}
+}
+
+// authWishListTestClient builds upon testClient, and serves beer info
+// indicating whether or not a beer is present in the wish list, followed
+// by the input body for the expected wish list modification path.
+func authWishListTestClient(t *testing.T, present bool, path string, body []byte) (*Client, func()) {
+	info := wishListBeerInfoJSON(present)
+
+	return testClient(t, func(t *testing.T, w http.ResponseWriter, r *http.Request) {
+		// Always GET request
+		method := "GET"
+		if m := r.Method; m != method {
+			t.Fatalf("unexpected HTTP method: %q != %q", m, method)
+		}
+
+		switch p := r.URL.Path; p {
+		case "/v4/beer/info/1/":
+			assertParameters(t, r, url.Values{
+				"compact": []string{"true"},
+			})
+
+			w.Write(info)
+		case path:
+			assertParameters(t, r, url.Values{
+				"bid": []string{"1"},
+			})
+
+			w.Write(body)
+		default:
+			t.Fatalf("unexpected URL path: %q", p)
+		}
+	})
+}
+
+// wishListBeerInfoJSON generates canned compact beer info JSON, indicating
+// whether or not the beer is present in the authenticated user's wish list.
+func wishListBeerInfoJSON(present bool) []byte {
+	wish := "false"
+	if present {
+		wish = "true"
+	}
+
+	return []byte(`{"meta":{"code":200},"response":{"beer":{"bid":1,"beer_name":"Pale Ale","wish_list":` + wish + `}}}`)
+}
+
+// Canned wish list add JSON response, taken from Untappd APIv4 documentation
+// and trimmed for brevity
+var wishListAddJSON = []byte(`{"meta":{"code":200},"response":{"result":"success","beer":{"bid":1,"beer_name":"Pale Ale","wish_list":true}}}`)
+
+// Canned wish list remove JSON response, taken from Untappd APIv4
+// documentation and trimmed for brevity
+var wishListRemoveJSON = []byte(`{"meta":{"code":200},"response":{"result":"success","beer":{"bid":1,"beer_name":"Pale Ale","wish_list":false}}}`)
@@ -1,6 +1,7 @@
 package untappd
 
 import (
+	"encoding/json"
 	"net/url"
 	"time"
 )
@@ -9,44 +10,60 @@ import (
 // description, when it was earned, and various media associated with the badge.
 type Badge struct {
 	// Metadata from Untappd.
-	ID          int
-	CheckinID   int
-	Name        string
-	Description string
-	Hint        string
-	Active      bool
+	ID          int64  `json:"id"`
+	CheckinID   int64  `json:"checkin_id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Hint        string `json:"hint"`
+	Active      bool   `json:"active"`
 
 	// Links to images of the badge.
-	Media BadgeMedia
+	Media BadgeMedia `json:"media"`
 
 	// If applicable, time when the specified user earned this badge.
-	Earned time.Time
+	Earned time.Time `json:"earned"`
 
 	// If applicable, badge levels which the specified user has obtained.
 	// If the slice has zero length, no levels exist for this badge.
-	Levels []*Badge
+	//
+	// The Untappd APIv4 does not support paging through badge levels, so
+	// Levels contains only the levels returned with the badge itself.
+	Levels []*Badge `json:"levels"`
 }
 
 // BadgeMedia contains links to media regarding a Badge.  Included are links
 // to a small, medium, and large image for a given Badge.
 type BadgeMedia struct {
-	SmallImage  url.URL
-	MediumImage url.URL
-	LargeImage  url.URL
+	SmallImage  url.URL `json:"small_image"`
+	MediumImage url.URL `json:"medium_image"`
+	LargeImage  url.URL `json:"large_image"`
+}
+
+// BestImage returns the highest resolution image available for a Badge.
+// The large image is preferred, falling back to the medium and then small
+// images if larger images are not present.
+func (m BadgeMedia) BestImage() url.URL {
+	for _, u := range []url.URL{m.LargeImage, m.MediumImage} {
+		if u.String() != "" {
+			return u
+		}
+	}
+
+	return m.SmallImage
 }
 
 // rawBadge is the raw JSON representation of an Untappd badge.  Its data is
 // unmarshaled from JSON and then exported to a Badge struct.
 type rawBadge struct {
-	ID          int                 `json:"badge_id"`
-	CheckinID   int                 `json:"checkin_id"`
-	Name        string              `json:"badge_name"`
-	Description string              `json:"badge_description"`
-	Hint        string              `json:"badge_hint"`
-	Active      responseBool        `json:"badge_active_status"`
-	Media       rawBadgeMedia       `json:"media"`
-	Earned      responseTime        `json:"created_at"`
-	Levels      responseBadgeLevels `json:"levels"`
+	ID          int64          `json:"badge_id"`
+	CheckinID   int64          `json:"checkin_id"`
+	Name        string         `json:"badge_name"`
+	Description string         `json:"badge_description"`
+	Hint        string         `json:"badge_hint"`
+	Active      responseBool   `json:"badge_active_status"`
+	Media       rawBadgeMedia  `json:"media"`
+	Earned      responseTime   `json:"created_at"`
+	Levels      responseBadges `json:"levels"`
 }
 
 // export creates an exported Badge from a rawBadge struct, allowing for more
@@ -63,10 +80,16 @@ func (r *rawBadge) export() *Badge {
 		Earned:      time.Time(r.Earned),
 	}
 
-	// Export badge levels as a slice of badges belonging to parent badge
-	levels := make([]*Badge, r.Levels.Count)
-	for i := range r.Levels.Items {
-		levels[i] = r.Levels.Items[i].export()
+	// Export badge levels as a slice of badges belonging to parent badge,
+	// sized by the number of levels actually returned, since the reported
+	// count may not match
+	levels := make([]*Badge, 0, len(r.Levels.Items))
+	for _, l := range r.Levels.Items {
+		if l == nil {
+			continue
+		}
+
+		levels = append(levels, l.export())
 	}
 	b.Levels = levels
 
@@ -90,3 +113,48 @@ func (r *rawBadgeMedia) export() BadgeMedia {
 		LargeImage:  url.URL(r.LargeImage),
 	}
 }
+
+// MarshalJSON implements json.Marshaler, so that BadgeMedia's URLs are
+// encoded as strings.
+func (m BadgeMedia) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		SmallImage  string `json:"small_image"`
+		MediumImage string `json:"medium_image"`
+		LargeImage  string `json:"large_image"`
+	}{
+		SmallImage:  m.SmallImage.String(),
+		MediumImage: m.MediumImage.String(),
+		LargeImage:  m.LargeImage.String(),
+	})
+}
+
+// UnmarshalJSON implements json.Unmarshaler, so that JSON produced by
+// MarshalJSON can be decoded into BadgeMedia.
+func (m *BadgeMedia) UnmarshalJSON(data []byte) error {
+	var v struct {
+		SmallImage  string `json:"small_image"`
+		MediumImage string `json:"medium_image"`
+		LargeImage  string `json:"large_image"`
+	}
+
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+
+	for _, p := range []struct {
+		s string
+		u *url.URL
+	}{
+		{s: v.SmallImage, u: &m.SmallImage},
+		{s: v.MediumImage, u: &m.MediumImage},
+		{s: v.LargeImage, u: &m.LargeImage},
+	} {
+		u, err := url.Parse(p.s)
+		if err != nil {
+			return err
+		}
+		*p.u = *u
+	}
+
+	return nil
+}
@@ -0,0 +1,100 @@
+package untappd
+
+import (
+	"encoding/json"
+	"net/url"
+	"reflect"
+	"testing"
+)
+
+// TestBadgeMediaBestImage verifies that BadgeMedia.BestImage returns the
+// largest image available.
+func TestBadgeMediaBestImage(t *testing.T) {
+	small := url.URL{Scheme: "https", Host: "untappd.akamaized.net", Path: "/badges/bdg_sm.jpg"}
+	medium := url.URL{Scheme: "https", Host: "untappd.akamaized.net", Path: "/badges/bdg_md.jpg"}
+	large := url.URL{Scheme: "https", Host: "untappd.akamaized.net", Path: "/badges/bdg_lg.jpg"}
+
+	var tests = []struct {
+		description string
+		media       BadgeMedia
+		image       url.URL
+	}{
+		{
+			description: "all images present",
+			media: BadgeMedia{
+				SmallImage:  small,
+				MediumImage: medium,
+				LargeImage:  large,
+			},
+			image: large,
+		},
+		{
+			description: "large image absent",
+			media: BadgeMedia{
+				SmallImage:  small,
+				MediumImage: medium,
+			},
+			image: medium,
+		},
+		{
+			description: "only small image present",
+			media: BadgeMedia{
+				SmallImage: small,
+			},
+			image: small,
+		},
+	}
+
+	for _, tt := range tests {
+		if i := tt.media.BestImage(); i.String() != tt.image.String() {
+			t.Fatalf("unexpected image for test %q: %q != %q", tt.description, i.String(), tt.image.String())
+		}
+	}
+}
+
+// Test_rawBadgeExportLevelsCountMismatch verifies that all badge levels
+// present in a response are exported, even if the reported count does not
+// match the number of levels returned.
+func Test_rawBadgeExportLevelsCountMismatch(t *testing.T) {
+	var tests = []struct {
+		description string
+		body        string
+		ids         []int64
+	}{
+		{
+			description: "count larger than items",
+			body:        `{"badge_id":1,"levels":{"count":5,"items":[{"badge_id":2},{"badge_id":3}]}}`,
+			ids:         []int64{2, 3},
+		},
+		{
+			description: "count smaller than items",
+			body:        `{"badge_id":1,"levels":{"count":1,"items":[{"badge_id":2},{"badge_id":3}]}}`,
+			ids:         []int64{2, 3},
+		},
+		{
+			description: "null items",
+			body:        `{"badge_id":1,"levels":{"count":2,"items":[null,{"badge_id":3}]}}`,
+			ids:         []int64{3},
+		},
+		{
+			description: "no levels",
+			body:        `{"badge_id":1,"levels":[]}`,
+		},
+	}
+
+	for _, tt := range tests {
+		var r rawBadge
+		if err := json.Unmarshal([]byte(tt.body), &r); err != nil {
+			t.Fatalf("[%s] %v", tt.description, err)
+		}
+
+		var ids []int64
+		for _, l := range r.export().Levels {
+			ids = append(ids, l.ID)
+		}
+
+		if !reflect.DeepEqual(ids, tt.ids) {
+			t.Fatalf("[%s] unexpected level IDs: %v != %v", tt.description, ids, tt.ids)
+		}
+	}
+}
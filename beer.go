@@ -1,7 +1,11 @@
 package untappd
 
 import (
+	"encoding/json"
+	"math"
 	"net/url"
+	"sort"
+	"strings"
 	"time"
 )
 
@@ -16,88 +20,168 @@ type BeerService struct {
 // If available, a beer's brewery information can be accessed via the Brewery
 // member.
 type Beer struct {
-	// Metadata from Untappd.
-	ID          int
-	Name        string
-	Label       url.URL
-	ABV         float64
-	IBU         int
-	Slug        string
-	Style       string
-	Description string
+	// Metadata from Untappd.  The Untappd APIv4 reports an ABV or IBU of
+	// zero for beers where this information is unknown.
+	ID          int64   `json:"id"`
+	Name        string  `json:"name"`
+	Label       url.URL `json:"label"`
+	LabelHD     url.URL `json:"label_hd"`
+	ABV         float64 `json:"abv"`
+	IBU         int     `json:"ibu"`
+	Slug        string  `json:"slug"`
+	Style       string  `json:"style"`
+	Description string  `json:"description"`
 
 	// Time when this beer was added to Untappd.
-	Created time.Time
+	Created time.Time `json:"created"`
 
 	// Is this beer present in the specified user's wish list?
-	WishList bool
+	WishList bool `json:"wish_list"`
+
+	// Is this beer active on Untappd?  Beers which are discontinued or
+	// have been merged into another beer are inactive.  If the Untappd
+	// APIv4 did not report this information, Active is true.
+	Active bool `json:"active"`
+
+	// Flags which describe this beer's production status.
+	Status BeerStatus `json:"status"`
 
 	// Global Untappd rating for this beer.
-	OverallRating float64
+	OverallRating float64 `json:"overall_rating"`
+
+	// Global Untappd rating for this beer, weighted by Untappd to account
+	// for the number of ratings.  This is the rating displayed on the
+	// Untappd website.  Only available for beer info requests.
+	WeightedRating float64 `json:"weighted_rating"`
 
 	// For beer search requests this is the global checkin count, for beer info
 	// requests this is the rating count.
-	OverallCount int
+	//
+	// Because its meaning differs between requests, new code should prefer
+	// RatingCount or CheckinCount instead.
+	OverallCount int `json:"overall_count"`
+
+	// Global number of ratings for this beer.
+	RatingCount int `json:"rating_count"`
 
-	// If applicable, the specified user's rating for this beer.
-	UserRating float64
+	// Global number of checkins for this beer.  Available for beer search
+	// and beer info requests; for beer info requests, this is the
+	// total_count statistic.
+	CheckinCount int `json:"checkin_count"`
+
+	// Global number of unique users who have checked in this beer.  Only
+	// available for beer info requests.
+	TotalUserCount int `json:"total_user_count"`
+
+	// If applicable, the specified user's rating for this beer.  For beer
+	// search requests, populated only by Beer.SearchForUser.
+	UserRating float64 `json:"user_rating"`
 
 	// If applicable, time when the specified user first, or most recently
 	// checked in this beer.
-	FirstHad  time.Time
-	RecentHad time.Time
+	FirstHad  time.Time `json:"first_had"`
+	RecentHad time.Time `json:"recent_had"`
+
+	// If applicable, time when the specified user most recently checked
+	// in this beer, in the user's local timezone, if the Untappd APIv4
+	// provided a timezone hint.  Otherwise, RecentHadLocal is identical
+	// to RecentHad.
+	RecentHadLocal time.Time `json:"recent_had_local"`
 
 	// If applicable, time when the specified user added this beer to
 	// their wish list.
-	WishListed time.Time
+	WishListed time.Time `json:"wish_listed"`
 
 	// If applicable, number of times the specified user has checked
 	// in this beer.
-	Count int
+	Count int `json:"count"`
 
 	// If available, information regarding the brewery which created
-	// this beer.
-	Brewery *Brewery
+	// this beer.  Populated for beers returned by any method, whether the
+	// Untappd APIv4 nests the brewery inside the beer, or returns it
+	// alongside the beer.
+	Brewery *Brewery `json:"brewery"`
+
+	// If this beer is a collaboration, the other breweries which took part
+	// in creating it.  Only populated for beer info results; otherwise
+	// empty.
+	Collaborations []*Brewery `json:"collaborations"`
+}
+
+// BeerStatus contains flags which describe the production status of a Beer,
+// such as whether it is a homebrew, or is still in production.
+type BeerStatus struct {
+	Homebrew      bool `json:"homebrew"`
+	InProduction  bool `json:"in_production"`
+	Collaboration bool `json:"collaboration"`
 }
 
 // rawBeer is the raw JSON representation of an Untappd beer.  Its data is
 // unmarshaled from JSON and then exported to a Beer struct.
 type rawBeer struct {
-	ID            int          `json:"bid"`
-	Name          string       `json:"beer_name"`
-	Label         responseURL  `json:"beer_label"`
-	ABV           float64      `json:"beer_abv"`
-	IBU           int          `json:"beer_ibu"`
-	Slug          string       `json:"beer_slug"`
-	Style         string       `json:"beer_style"`
-	Description   string       `json:"beer_description"`
-	Created       responseTime `json:"created_at"`
-	WishList      bool         `json:"wish_list"`
-	OverallRating float64      `json:"rating_score"`
-	OverallCount  int          `json:"rating_count"`
+	ID             int64          `json:"bid"`
+	Name           string         `json:"beer_name"`
+	Label          responseURL    `json:"beer_label"`
+	LabelHD        responseURL    `json:"beer_label_hd"`
+	ABV            responseNumber `json:"beer_abv"`
+	IBU            responseNumber `json:"beer_ibu"`
+	Slug           string         `json:"beer_slug"`
+	Style          string         `json:"beer_style"`
+	Description    string         `json:"beer_description"`
+	Created        responseTime   `json:"created_at"`
+	WishList       bool           `json:"wish_list"`
+	AuthRating     float64        `json:"auth_rating"`
+	Active         *responseBool  `json:"beer_active"`
+	OverallRating  float64        `json:"rating_score"`
+	WeightedRating float64        `json:"weighted_rating_score"`
+	OverallCount   int            `json:"rating_count"`
+	Homebrew       responseBool   `json:"is_homebrew"`
+	InProduction   responseBool   `json:"is_in_production"`
+	Collaboration  responseBool   `json:"is_collaboration"`
+
+	// Only available for /v4/beer/info/ID.
+	Stats struct {
+		TotalCount     int `json:"total_count"`
+		TotalUserCount int `json:"total_user_count"`
+	} `json:"stats"`
 
 	// For /v4/beer/info/ID, brewery is located inside the rawBeer struct.
 	// This is not the case with /v4/user/beers/username, where it is
 	// added by the client method.
 	Brewery *rawBrewery `json:"brewery"`
+
+	// Only available for /v4/beer/info/ID.
+	Collaborations responseCollaborations `json:"collaborations_with"`
 }
 
 // export creates an exported Beer from a rawBeer struct, allowing for more
 // useful structures to be created for client consumption.
 func (r *rawBeer) export() *Beer {
 	b := &Beer{
-		ID:            r.ID,
-		Name:          r.Name,
-		Label:         url.URL(r.Label),
-		ABV:           r.ABV,
-		IBU:           r.IBU,
-		Slug:          r.Slug,
-		Style:         r.Style,
-		Description:   r.Description,
-		Created:       time.Time(r.Created),
-		WishList:      r.WishList,
-		OverallRating: r.OverallRating,
-		OverallCount:  r.OverallCount,
+		ID:             r.ID,
+		Name:           r.Name,
+		Label:          url.URL(r.Label),
+		LabelHD:        url.URL(r.LabelHD),
+		ABV:            float64(r.ABV),
+		IBU:            int(math.Round(float64(r.IBU))),
+		Slug:           r.Slug,
+		Style:          r.Style,
+		Description:    r.Description,
+		Created:        time.Time(r.Created),
+		WishList:       r.WishList,
+		UserRating:     r.AuthRating,
+		Active:         r.Active.or(true),
+		OverallRating:  r.OverallRating,
+		WeightedRating: r.WeightedRating,
+		OverallCount:   r.OverallCount,
+		RatingCount:    r.OverallCount,
+		CheckinCount:   r.Stats.TotalCount,
+		TotalUserCount: r.Stats.TotalUserCount,
+		Status: BeerStatus{
+			Homebrew:      bool(r.Homebrew),
+			InProduction:  bool(r.InProduction),
+			Collaboration: bool(r.Collaboration),
+		},
 	}
 
 	// If brewery was present inside the Beer struct, as is the case
@@ -106,5 +190,160 @@ func (r *rawBeer) export() *Beer {
 		b.Brewery = r.Brewery.export()
 	}
 
+	b.Collaborations = make([]*Brewery, len(r.Collaborations))
+	for i := range r.Collaborations {
+		b.Collaborations[i] = r.Collaborations[i].export()
+	}
+
 	return b
 }
+
+// BestLabel returns the highest resolution label image available for a Beer.
+// If a high resolution label is present, it is returned.  Otherwise, the
+// standard label is returned.
+func (b *Beer) BestLabel() url.URL {
+	if b.LabelHD.String() != "" {
+		return b.LabelHD
+	}
+
+	return b.Label
+}
+
+// DistinctStyles returns the distinct, non-empty styles of the input Beers,
+// sorted alphabetically.  Styles which differ only in case or surrounding
+// whitespace are considered identical, and the first spelling seen is used.
+//
+// The Untappd APIv4 does not provide a list of the beer styles it
+// recognizes, so DistinctStyles can be used to build a list of styles from
+// the results of searches or a User's beers.
+func DistinctStyles(beers []*Beer) []string {
+	seen := make(map[string]struct{})
+	var styles []string
+	for _, b := range beers {
+		if b == nil {
+			continue
+		}
+
+		style := strings.TrimSpace(b.Style)
+		if style == "" {
+			continue
+		}
+
+		key := strings.ToLower(style)
+		if _, ok := seen[key]; ok {
+			continue
+		}
+
+		seen[key] = struct{}{}
+		styles = append(styles, style)
+	}
+
+	sort.Slice(styles, func(i, j int) bool {
+		return strings.ToLower(styles[i]) < strings.ToLower(styles[j])
+	})
+
+	return styles
+}
+
+// String returns a concise string representation of a Beer, containing its
+// name, style, and brewery, such as "Oberon (Pale Wheat Ale) by Bell's
+// Brewery".  Style and brewery are omitted if unavailable.
+func (b *Beer) String() string {
+	if b == nil {
+		return "<nil>"
+	}
+
+	s := withDetail(b.Name, b.Style)
+	if b.Brewery != nil && b.Brewery.Name != "" {
+		s += " by " + b.Brewery.Name
+	}
+
+	return s
+}
+
+// FilterBeersByStyle returns only the Beers from the input slice whose style
+// contains style, ignoring case.  For example, a style of "ipa" matches both
+// "IPA - American" and "IPA - Imperial / Double".  An empty style matches
+// every Beer.
+//
+// The Untappd APIv4 cannot filter a User's beers by style, so this
+// filtering is performed by the client.  When used with a single page of
+// beers, the result may contain fewer beers than were requested.
+func FilterBeersByStyle(beers []*Beer, style string) []*Beer {
+	style = strings.ToLower(strings.TrimSpace(style))
+
+	var out []*Beer
+	for _, b := range beers {
+		if b == nil {
+			continue
+		}
+
+		if strings.Contains(strings.ToLower(b.Style), style) {
+			out = append(out, b)
+		}
+	}
+
+	return out
+}
+
+// exportWithBrewery creates an exported Beer from a rawBeer struct, as with
+// export, for responses where the beer's brewery is a sibling of the beer
+// rather than nested inside it, such as /v4/user/beers/username.  If the
+// beer also contains a nested brewery, as with /v4/beer/info/ID, the nested
+// brewery is used instead.
+func (r *rawBeer) exportWithBrewery(brewery rawBrewery) *Beer {
+	b := r.export()
+	if b.Brewery == nil {
+		b.Brewery = brewery.export()
+	}
+
+	return b
+}
+
+// MarshalJSON implements json.Marshaler, so that a Beer's URLs are encoded
+// as strings.
+func (b Beer) MarshalJSON() ([]byte, error) {
+	type beer Beer
+	return json.Marshal(struct {
+		beer
+		Label   string `json:"label"`
+		LabelHD string `json:"label_hd"`
+	}{
+		beer:    beer(b),
+		Label:   b.Label.String(),
+		LabelHD: b.LabelHD.String(),
+	})
+}
+
+// UnmarshalJSON implements json.Unmarshaler, so that JSON produced by
+// MarshalJSON can be decoded into a Beer.
+func (b *Beer) UnmarshalJSON(data []byte) error {
+	type beer Beer
+	v := struct {
+		*beer
+		Label   string `json:"label"`
+		LabelHD string `json:"label_hd"`
+	}{
+		beer: (*beer)(b),
+	}
+
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+
+	for _, p := range []struct {
+		s string
+		u *url.URL
+	}{
+		{s: v.Label, u: &b.Label},
+		{s: v.LabelHD, u: &b.LabelHD},
+	} {
+		u, err := url.Parse(p.s)
+		if err != nil {
+			return err
+		}
+		*p.u = *u
+	}
+
+	return nil
+}
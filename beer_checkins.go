@@ -1,37 +1,60 @@
 package untappd
 
 import (
-	"math"
 	"net/http"
-	"net/url"
 	"strconv"
+	"strings"
 )
 
 // Checkins queries for information about a Beer's checkins.
 // The ID parameter specifies the Beer ID, which will return
 // a list of recent checkins for a given Beer.
 //
-// This method returns up to 25 of the Beer's most recent checkins.
-// For more granular control, and to page through the checkins list using ID
-// parameters, use CheckinsMinMaxIDLimit instead.
-func (b *BeerService) Checkins(id int) ([]*Checkin, *http.Response, error) {
-	// Use default parameters as specified by API.  Max ID is somewhat
-	// arbitrary, but should provide plenty of headroom, just in case.
-	return b.CheckinsMinMaxIDLimit(id, 0, math.MaxInt32, 25)
+// This method returns up to Client.DefaultLimit of the Beer's most recent
+// checkins.  For more granular control, and to page through the checkins list
+// using ID parameters, use CheckinsMinMaxIDLimit instead.
+func (b *BeerService) Checkins(id int64) ([]*Checkin, *http.Response, error) {
+	return b.CheckinsMinMaxIDLimit(id, 0, 0, b.client.limit(MaxCheckinsLimit))
 }
 
 // CheckinsMinMaxIDLimit queries for information about a Beer's checkins,
 // but also accepts minimum checkin ID, maximum checkin ID, and a limit
 // parameter to enable paging through checkins. The ID parameter
 // specifies the Beer ID, which will return a list of recent checkins
-// for a given Beer.
+// for a given Beer.  A minID or maxID of zero means the checkins are not
+// bounded in that direction.
 //
 // 25 checkins is the maximum number of checkins which may be returned by
-// one call.
-func (b *BeerService) CheckinsMinMaxIDLimit(id int, minID int, maxID int, limit int) ([]*Checkin, *http.Response, error) {
-	return b.client.getCheckins("beer/checkins/"+strconv.Itoa(id), url.Values{
-		"min_id": []string{strconv.Itoa(minID)},
-		"max_id": []string{strconv.Itoa(maxID)},
-		"limit":  []string{strconv.Itoa(limit)},
-	})
+// one call.  If limit exceeds MaxCheckinsLimit, ErrInvalidLimit is returned.
+func (b *BeerService) CheckinsMinMaxIDLimit(id int64, minID int64, maxID int64, limit int) ([]*Checkin, *http.Response, error) {
+	if err := checkLimit(limit, MaxCheckinsLimit); err != nil {
+		return nil, nil, err
+	}
+
+	return b.client.getCheckins("beer/checkins/"+strconv.FormatInt(id, 10), checkinsQuery(minID, maxID, limit))
+}
+
+// CheckinsByUser queries for a Beer's checkins, but only returns checkins
+// made by the User with the specified username.  This can be used to answer
+// the question "when did this user drink this beer?" without paging through
+// the User's entire activity feed.  The ID, minimum ID, maximum ID, and limit
+// parameters are the same as those of CheckinsMinMaxIDLimit.
+//
+// The Untappd APIv4 cannot filter a Beer's activity feed by user, so this
+// filtering is performed by the client.  As a result, fewer checkins than
+// the limit may be returned, even if more checkins by the User exist.
+func (b *BeerService) CheckinsByUser(id int64, username string, minID int64, maxID int64, limit int) ([]*Checkin, *http.Response, error) {
+	checkins, res, err := b.CheckinsMinMaxIDLimit(id, minID, maxID, limit)
+	if err != nil {
+		return nil, res, err
+	}
+
+	out := []*Checkin{}
+	for _, c := range checkins {
+		if c != nil && c.User != nil && strings.EqualFold(c.User.UserName, username) {
+			out = append(out, c)
+		}
+	}
+
+	return out, res, nil
 }
@@ -1,7 +1,6 @@
 package untappd
 
 import (
-	"math"
 	"net/http"
 	"net/url"
 	"strconv"
@@ -9,11 +8,11 @@ import (
 	"testing"
 )
 
-// TestClientBeerCheckinsOK verifies that Client.Beer.Checkins always sets the
-// appropriate default minimum ID, maximum ID, and limit values.
+// TestClientBeerCheckinsOK verifies that Client.Beer.Checkins omits the
+// minimum and maximum IDs, and sets the appropriate default limit.
 func TestClientBeerCheckinsOK(t *testing.T) {
-	minID := "0"
-	maxID := strconv.Itoa(math.MaxInt32)
+	// No bounds are sent by default
+	minID, maxID := "", ""
 	limit := "25"
 
 	c, done := beerCheckinsTestClient(t, func(t *testing.T, w http.ResponseWriter, r *http.Request) {
@@ -43,24 +42,24 @@ func TestClientBeerCheckinsMinMaxIDLimitBadBeer(t *testing.T) {
 	})
 	defer done()
 
-	_, _, err := c.Beer.CheckinsMinMaxIDLimit(-1, 0, math.MaxInt32, 25)
+	_, _, err := c.Beer.CheckinsMinMaxIDLimit(-1, 0, 0, 25)
 	assertInvalidBeerErr(t, err)
 }
 
 // TestClientBeerCheckinsMinMaxIDLimitOK verifies that Client.Beer.CheckinsMinMaxIDLimit
 // returns a valid checkins list, when used with correct parameters.
 func TestClientBeerCheckinsMinMaxIDLimitOffsetLimitOK(t *testing.T) {
-	var minID int
-	sMinID := strconv.Itoa(minID)
+	var minID int64 = 137117700
+	sMinID := strconv.FormatInt(minID, 10)
 
-	var maxID = math.MaxInt32
-	sMaxID := strconv.Itoa(maxID)
+	var maxID int64 = 137117722
+	sMaxID := strconv.FormatInt(maxID, 10)
 
 	var limit = 25
 	sLimit := strconv.Itoa(limit)
 
-	id := 1
-	sID := strconv.Itoa(id)
+	id := int64(1)
+	sID := strconv.FormatInt(id, 10)
 	c, done := beerCheckinsTestClient(t, func(t *testing.T, w http.ResponseWriter, r *http.Request) {
 		path := "/v4/beer/checkins/" + sID + "/"
 		if p := r.URL.Path; p != path {
@@ -88,6 +87,47 @@ func TestClientBeerCheckinsMinMaxIDLimitOffsetLimitOK(t *testing.T) {
 	assertExpectedCheckins(t, checkins)
 }
 
+// TestClientBeerCheckinsByUserOK verifies that Client.Beer.CheckinsByUser
+// requests a Beer's checkins using the correct parameters, and only returns
+// checkins by the specified user.
+func TestClientBeerCheckinsByUserOK(t *testing.T) {
+	beerID := int64(7481)
+
+	c, done := beerCheckinsTestClient(t, func(t *testing.T, w http.ResponseWriter, r *http.Request) {
+		path := "/v4/beer/checkins/" + strconv.FormatInt(beerID, 10) + "/"
+		if p := r.URL.Path; p != path {
+			t.Fatalf("unexpected URL path: %q != %q", p, path)
+		}
+
+		assertParameters(t, r, url.Values{
+			"min_id": []string{"10"},
+			"max_id": []string{"20"},
+			"limit":  []string{"25"},
+		})
+
+		w.Write([]byte(`{"response":{"checkins":{"count":3,"items":[
+			{"checkin_id":3,"user":{"user_name":"gregavola"}},
+			{"checkin_id":2,"user":{"user_name":"mdlayher"}},
+			{"checkin_id":1,"user":{"user_name":"GregAvola"}}
+		]}}}`))
+	})
+	defer done()
+
+	checkins, _, err := c.Beer.CheckinsByUser(beerID, "gregavola", 10, 20, 25)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if l := len(checkins); l != 2 {
+		t.Fatalf("unexpected number of checkins: %d != %d", l, 2)
+	}
+	for i, id := range []int64{3, 1} {
+		if checkins[i].ID != id {
+			t.Fatalf("unexpected checkin ID: %d != %d", checkins[i].ID, id)
+		}
+	}
+}
+
 // beerCheckinTestClient builds upon testClient, and adds additional sanity checks
 // for tests which target the beer checkin API.
 func beerCheckinsTestClient(t *testing.T, fn func(t *testing.T, w http.ResponseWriter, r *http.Request)) (*Client, func()) {
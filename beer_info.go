@@ -1,15 +1,102 @@
 package untappd
 
 import (
+	"context"
+	"errors"
 	"net/http"
 	"net/url"
 	"strconv"
+	"sync"
 )
 
 // Info queries for information about a Beer with the specified ID.
-// If the compact parameter is set to 'true', only basic beer information will
-// be populated.
-func (b *BeerService) Info(id int, compact bool) (*Beer, *http.Response, error) {
+//
+// If the compact parameter is set to 'true', the Untappd APIv4 returns a much
+// smaller response containing only basic beer information, such as the beer's
+// name, style, ABV, and brewery.  Fields which are omitted from a compact
+// response, such as the description, high resolution label, statistics, and
+// collaborations, are left as their zero values.
+func (b *BeerService) Info(id int64, compact bool) (*Beer, *http.Response, error) {
+	return b.info(context.Background(), id, compact)
+}
+
+// InfoMulti queries for information about several Beers with the specified
+// IDs, using concurrent requests.  The resulting slice contains one Beer for
+// each ID, in the same order as the input IDs.
+//
+// No more than Client.Concurrency requests are performed at once.  When a
+// request fails, any other requests in progress are canceled, no further
+// requests are performed, and InfoMulti returns a MultiError.  The first
+// failure is the first member of the MultiError, followed by any other
+// failures which occurred concurrently; requests which failed only because
+// they were canceled are omitted.  If ctx is canceled, the error from ctx is
+// returned.
+func (b *BeerService) InfoMulti(ctx context.Context, ids []int64, compact bool) ([]*Beer, error) {
+	parent := ctx
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		beers = make([]*Beer, len(ids))
+		wg    sync.WaitGroup
+
+		mu   sync.Mutex
+		merr MultiError
+	)
+
+	// Each worker receives indices into ids, so results can be stored
+	// in input order
+	idxC := make(chan int)
+	for i := 0; i < b.client.concurrency(); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			for idx := range idxC {
+				beer, _, err := b.info(ctx, ids[idx], compact)
+				if err == nil {
+					beers[idx] = beer
+					continue
+				}
+
+				// Requests canceled due to an earlier failure are not
+				// failures of their own
+				if errors.Is(err, context.Canceled) && parent.Err() == nil {
+					continue
+				}
+
+				mu.Lock()
+				merr = append(merr, err)
+				mu.Unlock()
+
+				cancel()
+			}
+		}()
+	}
+
+feed:
+	for i := range ids {
+		select {
+		case idxC <- i:
+		case <-ctx.Done():
+			break feed
+		}
+	}
+	close(idxC)
+	wg.Wait()
+
+	if err := parent.Err(); err != nil {
+		return nil, err
+	}
+	if len(merr) > 0 {
+		return nil, merr
+	}
+
+	return beers, nil
+}
+
+// info is the backing method for Info and InfoMulti.
+func (b *BeerService) info(ctx context.Context, id int64, compact bool) (*Beer, *http.Response, error) {
 	// Determine if a compact response is requested
 	q := url.Values{}
 	if compact {
@@ -24,7 +111,7 @@ func (b *BeerService) Info(id int, compact bool) (*Beer, *http.Response, error)
 	}
 
 	// Perform request for beer information by ID
-	res, err := b.client.request("GET", "beer/info/"+strconv.Itoa(id), nil, q, &v)
+	res, err := b.client.requestContext(ctx, "GET", "beer/info/"+strconv.FormatInt(id, 10), nil, q, &v)
 	if err != nil {
 		return nil, res, err
 	}
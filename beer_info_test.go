@@ -1,18 +1,24 @@
 package untappd
 
 import (
+	"bytes"
+	"context"
+	"errors"
+	"io/ioutil"
 	"net/http"
 	"net/url"
 	"strconv"
 	"strings"
+	"sync"
 	"testing"
+	"time"
 )
 
 // TestClientBeerInfoBadBeer verifies that Client.Beer.Info returns an error when
 // an invalid beer is queried.
 func TestClientBeerInfoBadBeer(t *testing.T) {
-	beerID := -1
-	sBeerID := strconv.Itoa(beerID)
+	beerID := int64(-1)
+	sBeerID := strconv.FormatInt(beerID, 10)
 
 	c, done := beerInfoTestClient(t, func(t *testing.T, w http.ResponseWriter, r *http.Request) {
 		path := "/v4/beer/info/" + sBeerID + "/"
@@ -37,23 +43,51 @@ func TestClientBeerInfoCompactOK(t *testing.T) {
 			"compact": []string{"true"},
 		})
 
-		// In the future, we may return compact canned beer data here.
-		// For now, write a mostly empty JSON object is enough to get
-		// test coverage.
-		w.Write([]byte(`{"response":{"beer":{"id":1}}}`))
+		w.Write(compactBeerJSON)
 	})
 	defer done()
 
-	if _, _, err := c.Beer.Info(1, true); err != nil {
+	b, _, err := c.Beer.Info(1, true)
+	if err != nil {
 		t.Fatal(err)
 	}
+
+	if got, want := b.ID, int64(1); got != want {
+		t.Fatalf("unexpected ID: %d != %d", got, want)
+	}
+	if got, want := b.Name, "Black Note Stout"; got != want {
+		t.Fatalf("unexpected Name: %q != %q", got, want)
+	}
+	if got, want := b.Style, "Stout - Imperial / Double"; got != want {
+		t.Fatalf("unexpected Style: %q != %q", got, want)
+	}
+	if got, want := b.ABV, 11.2; got != want {
+		t.Fatalf("unexpected ABV: %v != %v", got, want)
+	}
+	if b.Brewery == nil || b.Brewery.Name != "Bell's Brewery, Inc." {
+		t.Fatalf("unexpected Brewery: %+v", b.Brewery)
+	}
+
+	// Fields omitted or null in a compact response are left empty
+	if b.Description != "" {
+		t.Fatalf("unexpected Description: %q", b.Description)
+	}
+	if u := b.LabelHD.String(); u != "" {
+		t.Fatalf("unexpected LabelHD: %q", u)
+	}
+	if !b.Created.IsZero() {
+		t.Fatalf("unexpected Created: %v", b.Created)
+	}
+	if c := b.CheckinCount; c != 0 {
+		t.Fatalf("unexpected CheckinCount: %d", c)
+	}
 }
 
 // TestClientBeerInfoOK verifies that Client.Beer.Info returns a valid beer when
 // provided with correct input parameters.
 func TestClientBeerInfoOK(t *testing.T) {
-	beerID := 1
-	sBeerID := strconv.Itoa(beerID)
+	beerID := int64(1)
+	sBeerID := strconv.FormatInt(beerID, 10)
 
 	c, done := beerInfoTestClient(t, func(t *testing.T, w http.ResponseWriter, r *http.Request) {
 		path := "/v4/beer/info/" + sBeerID + "/"
@@ -85,6 +119,219 @@ func TestClientBeerInfoOK(t *testing.T) {
 	if c := b.OverallCount; c != overallCount {
 		t.Fatalf("unexpected OverallCount: %q != %q", c, overallCount)
 	}
+	ratingCount := 123
+	if c := b.RatingCount; c != ratingCount {
+		t.Fatalf("unexpected RatingCount: %d != %d", c, ratingCount)
+	}
+	checkinCount := 456
+	if c := b.CheckinCount; c != checkinCount {
+		t.Fatalf("unexpected CheckinCount: %d != %d", c, checkinCount)
+	}
+	totalUserCount := 78
+	if c := b.TotalUserCount; c != totalUserCount {
+		t.Fatalf("unexpected TotalUserCount: %d != %d", c, totalUserCount)
+	}
+	status := BeerStatus{
+		Homebrew:      false,
+		InProduction:  true,
+		Collaboration: true,
+	}
+	if s := b.Status; s != status {
+		t.Fatalf("unexpected Status: %+v != %+v", s, status)
+	}
+}
+
+// TestClientBeerInfoStats verifies that Client.Beer.Info returns a beer's
+// global rating and count statistics.
+func TestClientBeerInfoStats(t *testing.T) {
+	c, done := beerInfoTestClient(t, func(t *testing.T, w http.ResponseWriter, r *http.Request) {
+		w.Write(blackNoteBeerJSON)
+	})
+	defer done()
+
+	b, _, err := c.Beer.Info(1, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got, want := b.OverallRating, 4.52; got != want {
+		t.Fatalf("unexpected OverallRating: %v != %v", got, want)
+	}
+	if got, want := b.WeightedRating, 4.49; got != want {
+		t.Fatalf("unexpected WeightedRating: %v != %v", got, want)
+	}
+	if got, want := b.RatingCount, 123; got != want {
+		t.Fatalf("unexpected RatingCount: %d != %d", got, want)
+	}
+	if got, want := b.CheckinCount, 456; got != want {
+		t.Fatalf("unexpected CheckinCount: %d != %d", got, want)
+	}
+}
+
+// TestClientBeerInfoCollaborations verifies that Client.Beer.Info returns
+// each brewery which collaborated on a beer.
+func TestClientBeerInfoCollaborations(t *testing.T) {
+	c, done := beerInfoTestClient(t, func(t *testing.T, w http.ResponseWriter, r *http.Request) {
+		w.Write(collaborationBeerJSON)
+	})
+	defer done()
+
+	b, _, err := c.Beer.Info(1, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if l := len(b.Collaborations); l != 2 {
+		t.Fatalf("unexpected number of collaborations: %d != %d", l, 2)
+	}
+
+	for i, name := range []string{"Stone Brewing", "Dogfish Head Craft Brewery"} {
+		if n := b.Collaborations[i].Name; n != name {
+			t.Fatalf("unexpected Collaborations[%d].Name: %q != %q", i, n, name)
+		}
+	}
+}
+
+// TestClientBeerInfoNoCollaborations verifies that Client.Beer.Info returns
+// an empty list of collaborating breweries for a beer which is not a
+// collaboration.
+func TestClientBeerInfoNoCollaborations(t *testing.T) {
+	c, done := beerInfoTestClient(t, func(t *testing.T, w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"response":{"beer":{"bid":1,"collaborations_with":[]}}}`))
+	})
+	defer done()
+
+	b, _, err := c.Beer.Info(1, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if b.Collaborations == nil || len(b.Collaborations) != 0 {
+		t.Fatalf("unexpected collaborations: %v", b.Collaborations)
+	}
+}
+
+// TestClientBeerInfoMultiOK verifies that Client.Beer.InfoMulti returns beers
+// in the same order as the input IDs, and never exceeds its concurrency limit.
+func TestClientBeerInfoMultiOK(t *testing.T) {
+	const concurrency = 2
+
+	var (
+		mu       sync.Mutex
+		inFlight int
+		max      int
+	)
+
+	c, done := beerInfoTestClient(t, func(t *testing.T, w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		inFlight++
+		if inFlight > max {
+			max = inFlight
+		}
+		mu.Unlock()
+
+		// Give other requests a chance to arrive concurrently
+		time.Sleep(10 * time.Millisecond)
+
+		mu.Lock()
+		inFlight--
+		mu.Unlock()
+
+		id := strings.Trim(strings.TrimPrefix(r.URL.Path, "/v4/beer/info/"), "/")
+		w.Write([]byte(`{"response":{"beer":{"bid":` + id + `}}}`))
+	})
+	defer done()
+
+	c.Concurrency = concurrency
+
+	ids := []int64{5, 1, 4, 2, 3, 8, 7, 6}
+	beers, err := c.Beer.InfoMulti(context.Background(), ids, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got, want := len(beers), len(ids); got != want {
+		t.Fatalf("unexpected number of beers: %d != %d", got, want)
+	}
+	for i := range ids {
+		if got, want := beers[i].ID, ids[i]; got != want {
+			t.Fatalf("unexpected beer ID at index %d: %d != %d", i, got, want)
+		}
+	}
+
+	if max > concurrency {
+		t.Fatalf("too many concurrent requests: %d > %d", max, concurrency)
+	}
+}
+
+// TestClientBeerInfoMultiBadBeer verifies that Client.Beer.InfoMulti returns
+// an error when any one beer is invalid.
+func TestClientBeerInfoMultiBadBeer(t *testing.T) {
+	c, done := beerInfoTestClient(t, func(t *testing.T, w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/v4/beer/info/-1/" {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write(invalidBeerErrJSON)
+			return
+		}
+
+		w.Write(blackNoteBeerJSON)
+	})
+	defer done()
+
+	c.Concurrency = 1
+
+	beers, err := c.Beer.InfoMulti(context.Background(), []int64{1, 2, -1, 3, 4}, false)
+	if beers != nil {
+		t.Fatalf("unexpected beers: %+v", beers)
+	}
+
+	var merr MultiError
+	if !errors.As(err, &merr) {
+		t.Fatalf("unexpected error type: %T", err)
+	}
+	if l := len(merr); l != 1 {
+		t.Fatalf("unexpected number of errors: %d != %d", l, 1)
+	}
+	assertInvalidBeerErr(t, merr[0])
+}
+
+// TestClientBeerInfoMultiConcurrentErrors verifies that Client.Beer.InfoMulti
+// reports every failure which occurs concurrently with the first failure.
+func TestClientBeerInfoMultiConcurrentErrors(t *testing.T) {
+	// Both requests must be in flight before either fails, and the
+	// transport ignores cancelation so that both failures are reported
+	var wg sync.WaitGroup
+	wg.Add(2)
+
+	rt := roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+		wg.Done()
+		wg.Wait()
+
+		return &http.Response{
+			StatusCode: http.StatusInternalServerError,
+			Header:     http.Header{"Content-Type": []string{jsonContentType}},
+			Body:       ioutil.NopCloser(bytes.NewReader(invalidBeerErrJSON)),
+		}, nil
+	})
+
+	c, err := NewClient("foo", "bar", nil, WithTransport(rt))
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.Concurrency = 2
+
+	_, err = c.Beer.InfoMulti(context.Background(), []int64{-1, -2}, false)
+
+	var merr MultiError
+	if !errors.As(err, &merr) {
+		t.Fatalf("unexpected error type: %T", err)
+	}
+	if l := len(merr); l != 2 {
+		t.Fatalf("unexpected number of errors: %d != %d", l, 2)
+	}
+	for _, err := range merr {
+		assertInvalidBeerErr(t, err)
+	}
 }
 
 // beerInfoTestClient builds upon testClient, and adds additional sanity checks
@@ -125,10 +372,88 @@ var blackNoteBeerJSON = []byte(`
   "beer": {
     "bid": 1,
     "beer_name": "Black Note Stout",
+    "rating_score": 4.52,
+    "weighted_rating_score": 4.49,
     "rating_count": 123,
+    "is_homebrew": 0,
+    "is_in_production": 1,
+    "is_collaboration": true,
+    "stats": {
+      "total_count": 456,
+      "monthly_count": 12,
+      "total_user_count": 78,
+      "user_count": 0
+    },
     "brewery": {
       "brewery_name": "Bell's Brewery, Inc."
     }
   }
   }
 }`)
+
+// Canned compact beer info JSON, which omits most optional fields
+var compactBeerJSON = []byte(`{
+  "meta": {
+    "code": 200,
+    "response_time": {
+      "time": 0.02,
+      "measure": "seconds"
+    }
+  },
+  "notifications": [],
+  "response": {
+    "beer": {
+      "bid": 1,
+      "beer_name": "Black Note Stout",
+      "beer_label": "https://untappd.akamaized.net/site/beer_logos/beer-1.jpeg",
+      "beer_label_hd": null,
+      "beer_abv": 11.2,
+      "beer_style": "Stout - Imperial / Double",
+      "is_in_production": 1,
+      "brewery": {
+        "brewery_id": 2,
+        "brewery_name": "Bell's Brewery, Inc."
+      }
+    }
+  }
+}`)
+
+// Canned beer info JSON for a collaboration beer, trimmed for brevity
+var collaborationBeerJSON = []byte(`{
+  "meta": {
+    "code": 200,
+    "response_time": {
+      "time": 0.1,
+      "measure": "seconds"
+    }
+  },
+  "notifications": [],
+  "response": {
+    "beer": {
+      "bid": 1,
+      "beer_name": "Collaboration Ale",
+      "is_collaboration": 1,
+      "brewery": {
+        "brewery_id": 3,
+        "brewery_name": "Victory Brewing Company"
+      },
+      "collaborations_with": {
+        "count": 2,
+        "items": [
+          {
+            "brewery": {
+              "brewery_id": 1204,
+              "brewery_name": "Stone Brewing"
+            }
+          },
+          {
+            "brewery": {
+              "brewery_id": 459,
+              "brewery_name": "Dogfish Head Craft Brewery"
+            }
+          }
+        ]
+      }
+    }
+  }
+}`)
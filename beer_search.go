@@ -8,14 +8,15 @@ import (
 
 // Search searches for information about beers, using the specified search query.
 //
-// This method returns up to 25 search results.  For more granular control,
-// and to page through and sort the results list, use SearchOffsetLimitSort instead.
+// This method returns up to Client.DefaultLimit search results.  For more
+// granular control, and to page through and sort the results list, use
+// SearchOffsetLimitSort instead.
 //
 // It is recommended to search using a "Brewery Name + Beer Name" query, such as
 // "Dogfish 60 Minute".
 func (b *BeerService) Search(query string) ([]*Beer, *http.Response, error) {
 	// Use default parameters as specified by API
-	return b.SearchOffsetLimitSort(query, 0, 25, SortDate)
+	return b.SearchOffsetLimitSort(query, 0, b.client.limit(MaxBeersLimit), SortDate)
 }
 
 // SearchOffsetLimitSort searches for information about beers, using the specified
@@ -24,23 +25,53 @@ func (b *BeerService) Search(query string) ([]*Beer, *http.Response, error) {
 // any of the provided Sort constants with this package.
 //
 // 50 beers is the maximum number of results which may be returned by one call.
+// If limit exceeds MaxBeersLimit, ErrInvalidLimit is returned.  To determine
+// whether more results exist, pass the returned *http.Response to
+// ResponseSearchTotal.
 //
 // It is recommended to search using a "Brewery Name + Beer Name" query, such as
 // "Dogfish 60 Minute".
 func (b *BeerService) SearchOffsetLimitSort(query string, offset int, limit int, sort Sort) ([]*Beer, *http.Response, error) {
+	return b.search(query, "", offset, limit, sort)
+}
+
+// SearchForUser searches for information about beers, using the specified
+// search query, and personalizes the results for the specified user.  Each
+// resulting Beer's WishList and UserRating members indicate whether the
+// beer is on the user's wish list, and the user's rating for the beer.
+//
+// Personalized results are only returned for a Client created using
+// NewAuthenticatedClient.
+//
+// This method returns up to Client.DefaultLimit search results, using the
+// same defaults as Search.
+func (b *BeerService) SearchForUser(query string, username string) ([]*Beer, *http.Response, error) {
+	return b.search(query, username, 0, b.client.limit(MaxBeersLimit), SortDate)
+}
+
+// search is the backing method for the beer search methods.  If username is
+// not empty, the results are personalized for that user.
+func (b *BeerService) search(query string, username string, offset int, limit int, sort Sort) ([]*Beer, *http.Response, error) {
+	if err := checkLimit(limit, MaxBeersLimit); err != nil {
+		return nil, nil, err
+	}
+
 	q := url.Values{
 		"q":      []string{query},
 		"offset": []string{strconv.Itoa(offset)},
 		"limit":  []string{strconv.Itoa(limit)},
 		"sort":   []string{string(sort)},
 	}
+	if username != "" {
+		q.Set("user", username)
+	}
 
 	// Temporary struct to unmarshal beers JSON
 	var v struct {
 		Response struct {
 			Beers struct {
 				Count int `json:"count"`
-				Items []struct {
+				Items []*struct {
 					CheckinCount int        `json:"checkin_count"`
 					Beer         rawBeer    `json:"beer"`
 					Brewery      rawBrewery `json:"brewery"`
@@ -55,15 +86,20 @@ func (b *BeerService) SearchOffsetLimitSort(query string, offset int, limit int,
 		return nil, res, err
 	}
 
-	// Build result slice from struct
-	beers := make([]*Beer, v.Response.Beers.Count)
-	for i, item := range v.Response.Beers.Items {
-		// Information about the beer itself
-		beers[i] = item.Beer.export()
-		beers[i].OverallCount = item.CheckinCount
+	// Build result slice from struct, sized by the number of items actually
+	// returned, since the reported count may not match
+	beers := make([]*Beer, 0, len(v.Response.Beers.Items))
+	for _, item := range v.Response.Beers.Items {
+		if item == nil {
+			continue
+		}
+
+		// Information about the beer itself, and its brewery
+		beer := item.Beer.exportWithBrewery(item.Brewery)
+		beer.OverallCount = item.CheckinCount
+		beer.CheckinCount = item.CheckinCount
 
-		// Information about the beer's brewery
-		beers[i].Brewery = item.Brewery.export()
+		beers = append(beers, beer)
 	}
 
 	return beers, res, nil
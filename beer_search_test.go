@@ -86,6 +86,8 @@ func TestClientBeerSearchOffsetLimitOK(t *testing.T) {
 				Name: "Russian River Brewing Company",
 			},
 			OverallCount: 123,
+			RatingCount:  12,
+			CheckinCount: 123,
 		},
 		&Beer{
 			ID:    2,
@@ -95,6 +97,8 @@ func TestClientBeerSearchOffsetLimitOK(t *testing.T) {
 				Name: "Russian River Brewing Company",
 			},
 			OverallCount: 456,
+			RatingCount:  45,
+			CheckinCount: 456,
 		},
 	}
 
@@ -114,6 +118,134 @@ func TestClientBeerSearchOffsetLimitOK(t *testing.T) {
 		if beers[i].OverallCount != expected[i].OverallCount {
 			t.Fatalf("unexpected beer OverallCount: %q != %q", beers[i].OverallCount, expected[i].OverallCount)
 		}
+		if beers[i].RatingCount != expected[i].RatingCount {
+			t.Fatalf("unexpected beer RatingCount: %d != %d", beers[i].RatingCount, expected[i].RatingCount)
+		}
+		if beers[i].CheckinCount != expected[i].CheckinCount {
+			t.Fatalf("unexpected beer CheckinCount: %d != %d", beers[i].CheckinCount, expected[i].CheckinCount)
+		}
+		if beers[i].TotalUserCount != 0 {
+			t.Fatalf("unexpected beer TotalUserCount for search: %d", beers[i].TotalUserCount)
+		}
+	}
+}
+
+// TestClientBeerSearchTotal verifies that ResponseSearchTotal returns the
+// total number of results for a beer search, so callers know when to stop
+// paging.
+func TestClientBeerSearchTotal(t *testing.T) {
+	c, done := beerSearchTestClient(t, func(t *testing.T, w http.ResponseWriter, r *http.Request) {
+		w.Write(beerSearchJSON)
+	})
+	defer done()
+
+	_, res, err := c.Beer.SearchOffsetLimitSort("pliny", 0, 2, SortDate)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	total, err := ResponseSearchTotal(res)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := (SearchTotal{Found: 120, Count: 2}); *total != want {
+		t.Fatalf("unexpected SearchTotal: %+v != %+v", *total, want)
+	}
+	if !total.More(0) {
+		t.Fatal("expected more results after first page")
+	}
+	if total.More(118) {
+		t.Fatal("expected no more results after last page")
+	}
+}
+
+// TestClientBeerSearchForUser verifies that Client.Beer.SearchForUser passes
+// the user context to the API, and returns personalized results.
+func TestClientBeerSearchForUser(t *testing.T) {
+	query := "pliny"
+	username := "gregavola"
+
+	c, done := beerSearchTestClient(t, func(t *testing.T, w http.ResponseWriter, r *http.Request) {
+		assertParameters(t, r, url.Values{
+			"q":      []string{query},
+			"user":   []string{username},
+			"offset": []string{"0"},
+			"limit":  []string{"25"},
+			"sort":   []string{"date"},
+		})
+
+		w.Write(beerSearchForUserJSON)
+	})
+	defer done()
+
+	beers, _, err := c.Beer.SearchForUser(query, username)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if l := len(beers); l != 2 {
+		t.Fatalf("unexpected number of beers: %d != %d", l, 2)
+	}
+
+	var tests = []struct {
+		wishList   bool
+		userRating float64
+	}{
+		{wishList: true},
+		{userRating: 4.75},
+	}
+
+	for i, tt := range tests {
+		if got := beers[i].WishList; got != tt.wishList {
+			t.Fatalf("unexpected beer %d WishList: %v != %v", i, got, tt.wishList)
+		}
+		if got := beers[i].UserRating; got != tt.userRating {
+			t.Fatalf("unexpected beer %d UserRating: %v != %v", i, got, tt.userRating)
+		}
+	}
+}
+
+// TestClientBeerSearchCountMismatch verifies that
+// Client.Beer.Search returns only the beers present in a
+// response, even if the reported count does not match.
+func TestClientBeerSearchCountMismatch(t *testing.T) {
+	var tests = []struct {
+		description string
+		body        string
+		n           int
+	}{
+		{
+			description: "count lower than items",
+			body:        `{"meta":{"code":200},"response":{"beers":{"count":1,"items":[{"beer":{"bid":1}},{"beer":{"bid":2}}]}}}`,
+			n:           2,
+		},
+		{
+			description: "count higher than items, with null item",
+			body:        `{"meta":{"code":200},"response":{"beers":{"count":25,"items":[{"beer":{"bid":1}},null]}}}`,
+			n:           1,
+		},
+	}
+
+	for _, tt := range tests {
+		c, done := beerSearchTestClient(t, func(t *testing.T, w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(tt.body))
+		})
+
+		beers, _, err := c.Beer.Search("foo")
+		done()
+		if err != nil {
+			t.Fatalf("unexpected error for test %q: %v", tt.description, err)
+		}
+
+		if l := len(beers); l != tt.n {
+			t.Fatalf("unexpected number of beers for test %q: %d != %d", tt.description, l, tt.n)
+		}
+		for _, x := range beers {
+			if x == nil || x.ID == 0 {
+				t.Fatalf("unexpected beer for test %q: %+v", tt.description, x)
+			}
+		}
 	}
 }
 
@@ -152,6 +284,7 @@ var beerSearchJSON = []byte(`{
   },
   "notifications": {},
   "response": {
+  "found": 120,
   "beers": {
     "count": 2,
     "items": [
@@ -160,7 +293,8 @@ var beerSearchJSON = []byte(`{
       "beer": {
         "bid": 1,
         "beer_name": "Pliny the Elder",
-        "beer_style": "Imperial / Double IPA"
+        "beer_style": "Imperial / Double IPA",
+        "rating_count": 12
       },
       "brewery": {
         "brewery_name": "Russian River Brewing Company"
@@ -171,7 +305,8 @@ var beerSearchJSON = []byte(`{
       "beer": {
         "bid": 2,
         "beer_name": "Pliny the Younger",
-        "beer_style": "Triple IPA"
+        "beer_style": "Triple IPA",
+        "rating_count": 45
       },
       "brewery": {
         "brewery_name": "Russian River Brewing Company"
@@ -180,3 +315,42 @@ var beerSearchJSON = []byte(`{
     ]
   }
 }}`)
+
+// Canned beer search JSON response, personalized for the authenticated user
+var beerSearchForUserJSON = []byte(`{
+  "meta": {
+    "code": 200
+  },
+  "response": {
+    "found": 2,
+    "beers": {
+      "count": 2,
+      "items": [
+        {
+          "checkin_count": 123,
+          "beer": {
+            "bid": 1,
+            "beer_name": "Pliny the Elder",
+            "auth_rating": 0,
+            "wish_list": true
+          },
+          "brewery": {
+            "brewery_name": "Russian River Brewing Company"
+          }
+        },
+        {
+          "checkin_count": 456,
+          "beer": {
+            "bid": 2,
+            "beer_name": "Pliny the Younger",
+            "auth_rating": 4.75,
+            "wish_list": false
+          },
+          "brewery": {
+            "brewery_name": "Russian River Brewing Company"
+          }
+        }
+      ]
+    }
+  }
+}`)
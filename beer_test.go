@@ -0,0 +1,520 @@
+package untappd
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// TestBeerMarshalJSON verifies that a Beer is encoded to JSON using the
+// expected keys, and that its URLs are encoded as strings.
+func TestBeerMarshalJSON(t *testing.T) {
+	label := "https://untappd.akamaized.net/site/beer_logos/beer-1.jpeg"
+	u, err := url.Parse(label)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	logo := "https://untappd.akamaized.net/site/brewery_logos/brewery-1.jpeg"
+	lu, err := url.Parse(logo)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	beer := &Beer{
+		ID:      1,
+		Name:    "Oberon Ale",
+		Label:   *u,
+		LabelHD: *u,
+		ABV:     5.8,
+		Style:   "American Pale Wheat Ale",
+		Created: time.Date(2016, 12, 26, 1, 2, 3, 0, time.UTC),
+		Brewery: &Brewery{
+			ID:   1,
+			Name: "Bell's Brewery, Inc.",
+			Logo: *lu,
+		},
+	}
+
+	b, err := json.Marshal(beer)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var v map[string]interface{}
+	if err := json.Unmarshal(b, &v); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, k := range []string{
+		"id",
+		"name",
+		"label",
+		"abv",
+		"style",
+		"created",
+		"brewery",
+	} {
+		if _, ok := v[k]; !ok {
+			t.Fatalf("missing JSON key: %q", k)
+		}
+	}
+
+	if got, want := v["label"], label; got != want {
+		t.Fatalf("unexpected label: %v != %v", got, want)
+	}
+
+	brewery, ok := v["brewery"].(map[string]interface{})
+	if !ok {
+		t.Fatalf("unexpected brewery type: %T", v["brewery"])
+	}
+	if got, want := brewery["logo"], logo; got != want {
+		t.Fatalf("unexpected brewery logo: %v != %v", got, want)
+	}
+
+	// Ensure the JSON can be decoded back into an identical Beer
+	var out Beer
+	if err := json.Unmarshal(b, &out); err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(beer, &out) {
+		t.Fatalf("unexpected Beer:\n- want: %+v\n-  got: %+v", beer, &out)
+	}
+}
+
+// Test_rawBeerExportStatus verifies that each of a beer's status flags is
+// decoded from either integer or boolean JSON values.
+func Test_rawBeerExportStatus(t *testing.T) {
+	var tests = []struct {
+		description string
+		body        []byte
+		status      BeerStatus
+	}{
+		{
+			description: "no flags",
+			body:        []byte(`{}`),
+		},
+		{
+			description: "homebrew",
+			body:        []byte(`{"is_homebrew":1,"is_in_production":0,"is_collaboration":false}`),
+			status:      BeerStatus{Homebrew: true},
+		},
+		{
+			description: "in production",
+			body:        []byte(`{"is_homebrew":0,"is_in_production":true,"is_collaboration":0}`),
+			status:      BeerStatus{InProduction: true},
+		},
+		{
+			description: "collaboration",
+			body:        []byte(`{"is_homebrew":false,"is_in_production":0,"is_collaboration":1}`),
+			status:      BeerStatus{Collaboration: true},
+		},
+	}
+
+	for _, tt := range tests {
+		var r rawBeer
+		if err := json.Unmarshal(tt.body, &r); err != nil {
+			t.Fatalf("unexpected error for test %q: %v", tt.description, err)
+		}
+
+		if s := r.export().Status; s != tt.status {
+			t.Fatalf("unexpected Status for test %q: %+v != %+v", tt.description, s, tt.status)
+		}
+	}
+}
+
+// Test_rawBeerExportActive verifies that a beer and its brewery are only
+// reported as inactive when the Untappd APIv4 explicitly says so.
+func Test_rawBeerExportActive(t *testing.T) {
+	var tests = []struct {
+		description string
+		body        []byte
+		beer        bool
+		brewery     bool
+	}{
+		{
+			description: "not reported",
+			body:        []byte(`{"brewery":{}}`),
+			beer:        true,
+			brewery:     true,
+		},
+		{
+			description: "active",
+			body:        []byte(`{"beer_active":1,"brewery":{"brewery_active":true}}`),
+			beer:        true,
+			brewery:     true,
+		},
+		{
+			description: "inactive beer",
+			body:        []byte(`{"beer_active":0,"brewery":{"brewery_active":1}}`),
+			brewery:     true,
+		},
+		{
+			description: "inactive brewery",
+			body:        []byte(`{"beer_active":1,"brewery":{"brewery_active":0}}`),
+			beer:        true,
+		},
+	}
+
+	for _, tt := range tests {
+		var r rawBeer
+		if err := json.Unmarshal(tt.body, &r); err != nil {
+			t.Fatalf("unexpected error for test %q: %v", tt.description, err)
+		}
+
+		b := r.export()
+		if got, want := b.Active, tt.beer; got != want {
+			t.Fatalf("unexpected beer Active for test %q: %v != %v", tt.description, got, want)
+		}
+		if got, want := b.Brewery.Active, tt.brewery; got != want {
+			t.Fatalf("unexpected brewery Active for test %q: %v != %v", tt.description, got, want)
+		}
+	}
+}
+
+// TestClientBeerMetadataShapes verifies that a beer's ABV, IBU, and style are
+// populated from each shape of response which contains beers, whether the
+// Untappd APIv4 returns numbers or strings.
+func TestClientBeerMetadataShapes(t *testing.T) {
+	const beer = `{"bid":1,"beer_abv":%s,"beer_ibu":%s,"beer_style":"Stout"}`
+
+	var tests = []struct {
+		description string
+		body        string
+		call        func(c *Client) (*Beer, error)
+	}{
+		{
+			description: "beer info",
+			body:        `{"response":{"beer":` + beer + `}}`,
+			call: func(c *Client) (*Beer, error) {
+				b, _, err := c.Beer.Info(1, false)
+				return b, err
+			},
+		},
+		{
+			description: "beer search",
+			body:        `{"response":{"beers":{"count":1,"items":[{"beer":` + beer + `}]}}}`,
+			call: func(c *Client) (*Beer, error) {
+				bs, _, err := c.Beer.Search("foo")
+				if err != nil {
+					return nil, err
+				}
+				return bs[0], nil
+			},
+		},
+		{
+			description: "user beers",
+			body:        `{"response":{"beers":{"count":1,"items":[{"beer":` + beer + `}]}}}`,
+			call: func(c *Client) (*Beer, error) {
+				bs, _, err := c.User.Beers("foo")
+				if err != nil {
+					return nil, err
+				}
+				return bs[0], nil
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		for _, v := range []struct {
+			abv, ibu string
+		}{
+			{abv: `5.8`, ibu: `35`},
+			{abv: `"5.8"`, ibu: `"35"`},
+			{abv: `5.8`, ibu: `35.0`},
+		} {
+			body := fmt.Sprintf(tt.body, v.abv, v.ibu)
+			c, done := testClient(t, func(t *testing.T, w http.ResponseWriter, r *http.Request) {
+				w.Write([]byte(body))
+			})
+
+			b, err := tt.call(c)
+			done()
+			if err != nil {
+				t.Fatalf("unexpected error for test %q with %s: %v", tt.description, body, err)
+			}
+
+			if got, want := b.ABV, 5.8; got != want {
+				t.Fatalf("unexpected ABV for test %q with %s: %v != %v", tt.description, body, got, want)
+			}
+			if got, want := b.IBU, 35; got != want {
+				t.Fatalf("unexpected IBU for test %q with %s: %v != %v", tt.description, body, got, want)
+			}
+			if got, want := b.Style, "Stout"; got != want {
+				t.Fatalf("unexpected Style for test %q with %s: %q != %q", tt.description, body, got, want)
+			}
+		}
+	}
+}
+
+// TestClientBeerBreweryShapes verifies that a Beer's Brewery is populated
+// whether the brewery is nested inside the beer, as with beer info, or is
+// a sibling of the beer, as with user beers and checkins.
+func TestClientBeerBreweryShapes(t *testing.T) {
+	var tests = []struct {
+		description string
+		body        []byte
+		call        func(c *Client) (*Beer, error)
+		brewery     string
+	}{
+		{
+			description: "beer info",
+			body:        blackNoteBeerJSON,
+			call: func(c *Client) (*Beer, error) {
+				b, _, err := c.Beer.Info(1, false)
+				return b, err
+			},
+			brewery: "Bell's Brewery, Inc.",
+		},
+		{
+			description: "user beers",
+			body:        userBeersJSON,
+			call: func(c *Client) (*Beer, error) {
+				bs, _, err := c.User.Beers("foo")
+				if err != nil {
+					return nil, err
+				}
+				return bs[0], nil
+			},
+			brewery: "Bell's Brewery, Inc.",
+		},
+		{
+			description: "user checkins",
+			body:        userCheckinsJSON,
+			call: func(c *Client) (*Beer, error) {
+				cs, _, err := c.User.Checkins("foo")
+				if err != nil {
+					return nil, err
+				}
+				return cs[0].Beer, nil
+			},
+			brewery: "Kelso of Brooklyn",
+		},
+	}
+
+	for _, tt := range tests {
+		c, done := testClient(t, func(t *testing.T, w http.ResponseWriter, r *http.Request) {
+			w.Write(tt.body)
+		})
+
+		b, err := tt.call(c)
+		done()
+		if err != nil {
+			t.Fatalf("unexpected error for test %q: %v", tt.description, err)
+		}
+
+		if b.Brewery == nil {
+			t.Fatalf("unexpected nil Brewery for test %q", tt.description)
+		}
+		if got := b.Brewery.Name; got != tt.brewery {
+			t.Fatalf("unexpected Brewery.Name for test %q: %q != %q", tt.description, got, tt.brewery)
+		}
+	}
+}
+
+// Test_rawBeerExportWithBreweryPrefersNested verifies that a brewery nested
+// inside a beer is preferred over a sibling brewery.
+func Test_rawBeerExportWithBreweryPrefersNested(t *testing.T) {
+	r := &rawBeer{
+		Brewery: &rawBrewery{Name: "nested"},
+	}
+
+	b := r.exportWithBrewery(rawBrewery{Name: "sibling"})
+	if got, want := b.Brewery.Name, "nested"; got != want {
+		t.Fatalf("unexpected Brewery.Name: %q != %q", got, want)
+	}
+}
+
+// TestBeerBestLabel verifies that Beer.BestLabel prefers a high resolution
+// label, and falls back to the standard label when one is not present.
+func TestBeerBestLabel(t *testing.T) {
+	label, err := url.Parse("https://untappd.akamaized.net/site/beer_logos/beer-1.jpeg")
+	if err != nil {
+		t.Fatal(err)
+	}
+	labelHD, err := url.Parse("https://untappd.akamaized.net/site/beer_logos_hd/beer-1.jpeg")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var tests = []struct {
+		description string
+		beer        *Beer
+		label       string
+	}{
+		{
+			description: "HD label present",
+			beer: &Beer{
+				Label:   *label,
+				LabelHD: *labelHD,
+			},
+			label: labelHD.String(),
+		},
+		{
+			description: "HD label absent",
+			beer: &Beer{
+				Label: *label,
+			},
+			label: label.String(),
+		},
+	}
+
+	for _, tt := range tests {
+		if l := tt.beer.BestLabel(); l.String() != tt.label {
+			t.Fatalf("unexpected label for test %q: %q != %q", tt.description, l.String(), tt.label)
+		}
+	}
+}
+
+// TestDistinctStyles verifies that DistinctStyles deduplicates and sorts
+// the styles of a list of Beers.
+func TestDistinctStyles(t *testing.T) {
+	tests := []struct {
+		desc   string
+		beers  []*Beer
+		styles []string
+	}{
+		{
+			desc: "no beers",
+		},
+		{
+			desc: "empty and nil entries",
+			beers: []*Beer{
+				nil,
+				{Style: ""},
+				{Style: "   "},
+			},
+		},
+		{
+			desc: "deduplicated and sorted",
+			beers: []*Beer{
+				{Style: "Stout - Imperial / Double"},
+				{Style: "American Pale Ale"},
+				{Style: "IPA - American"},
+				{Style: "american pale ale "},
+				{Style: "IPA - American"},
+				nil,
+			},
+			styles: []string{
+				"American Pale Ale",
+				"IPA - American",
+				"Stout - Imperial / Double",
+			},
+		},
+		{
+			desc: "sorted without regard to case",
+			beers: []*Beer{
+				{Style: "porter"},
+				{Style: "Lager"},
+				{Style: "altbier"},
+			},
+			styles: []string{
+				"altbier",
+				"Lager",
+				"porter",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		if got := DistinctStyles(tt.beers); !reflect.DeepEqual(got, tt.styles) {
+			t.Fatalf("[%s] unexpected styles:\n- want: %v\n-  got: %v", tt.desc, tt.styles, got)
+		}
+	}
+}
+
+// TestBeerString verifies that Beer.String produces a concise description
+// of a Beer, including when fields are unavailable.
+func TestBeerString(t *testing.T) {
+	tests := []struct {
+		b *Beer
+		s string
+	}{
+		{
+			b: nil,
+			s: "<nil>",
+		},
+		{
+			b: &Beer{Name: "Oberon"},
+			s: "Oberon",
+		},
+		{
+			b: &Beer{Name: "Oberon", Style: "Pale Wheat Ale"},
+			s: "Oberon (Pale Wheat Ale)",
+		},
+		{
+			b: &Beer{
+				Name:    "Oberon",
+				Style:   "Pale Wheat Ale",
+				Brewery: &Brewery{Name: "Bell's Brewery"},
+			},
+			s: "Oberon (Pale Wheat Ale) by Bell's Brewery",
+		},
+	}
+
+	for i, tt := range tests {
+		if s := tt.b.String(); s != tt.s {
+			t.Fatalf("%02d: unexpected string: %q != %q", i, s, tt.s)
+		}
+	}
+}
+
+// TestFilterBeersByStyle verifies that FilterBeersByStyle returns only beers
+// whose style contains the input style, ignoring case.
+func TestFilterBeersByStyle(t *testing.T) {
+	beers := []*Beer{
+		{ID: 1, Style: "IPA - American"},
+		{ID: 2, Style: "Stout - Imperial / Double"},
+		nil,
+		{ID: 3, Style: "IPA - Imperial / Double"},
+		{ID: 4, Style: "Pale Ale - American"},
+		{ID: 5},
+	}
+
+	tests := []struct {
+		desc  string
+		style string
+		ids   []int64
+	}{
+		{
+			desc:  "exact match",
+			style: "Stout - Imperial / Double",
+			ids:   []int64{2},
+		},
+		{
+			desc:  "partial match, ignoring case",
+			style: "ipa",
+			ids:   []int64{1, 3},
+		},
+		{
+			desc:  "partial match across styles",
+			style: "Imperial",
+			ids:   []int64{2, 3},
+		},
+		{
+			desc:  "no match",
+			style: "Lager",
+		},
+		{
+			desc:  "empty style matches all",
+			style: "",
+			ids:   []int64{1, 2, 3, 4, 5},
+		},
+	}
+
+	for _, tt := range tests {
+		var ids []int64
+		for _, b := range FilterBeersByStyle(beers, tt.style) {
+			ids = append(ids, b.ID)
+		}
+
+		if !reflect.DeepEqual(ids, tt.ids) {
+			t.Fatalf("[%s] unexpected beer IDs: %v != %v", tt.desc, ids, tt.ids)
+		}
+	}
+}
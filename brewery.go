@@ -1,6 +1,10 @@
 package untappd
 
-import "net/url"
+import (
+	"encoding/json"
+	"net/url"
+	"strconv"
+)
 
 // BreweryService is a "service" which allows access to API methods involving
 // breweries.
@@ -11,16 +15,103 @@ type BreweryService struct {
 // Brewery represents an Untappd brewery, and contains information about a
 // brewery's name, location, logo, and various other metadata.
 type Brewery struct {
-	ID       int
-	Name     string
-	Slug     string
-	Logo     url.URL
-	Country  string
-	Active   bool
-	Location BreweryLocation
-	Contact  BreweryContact
-	Type     string
-	TypeID   int
+	ID       int64           `json:"id"`
+	Name     string          `json:"name"`
+	Slug     string          `json:"slug"`
+	Logo     url.URL         `json:"logo"`
+	Country  string          `json:"country"`
+	Active   bool            `json:"active"`
+	Location BreweryLocation `json:"location"`
+	Contact  BreweryContact  `json:"contact"`
+	Type     string          `json:"type"`
+	TypeID   int             `json:"type_id"`
+
+	// If applicable, the total number of beers produced by this brewery,
+	// and a page of those beers.  Only populated for brewery info results.
+	BeerCount int     `json:"beer_count"`
+	Beers     []*Beer `json:"beers"`
+}
+
+// BreweryType is the type of an Untappd brewery, as reported by the
+// brewery_type_id field of the Untappd APIv4.  A set of BreweryType constants
+// are provided for ease of use.
+type BreweryType int
+
+// Constants that define the types of breweries known to the Untappd APIv4.
+const (
+	// BreweryTypeUnknown indicates that no brewery type was reported.
+	BreweryTypeUnknown BreweryType = 0
+
+	// BreweryTypeMacro is a large, national or international brewery.
+	BreweryTypeMacro BreweryType = 1
+
+	// BreweryTypeMicro is a small, independent brewery.
+	BreweryTypeMicro BreweryType = 2
+
+	// BreweryTypeBrewPub is a restaurant or bar which brews its own beer.
+	BreweryTypeBrewPub BreweryType = 3
+
+	// BreweryTypeHome is a home brewer.
+	BreweryTypeHome BreweryType = 4
+
+	// BreweryTypeBarRestaurantStore is a bar, restaurant, or store which
+	// sells beer under its own name, but does not brew it.
+	BreweryTypeBarRestaurantStore BreweryType = 5
+
+	// BreweryTypeRegional is a brewery which distributes regionally.
+	BreweryTypeRegional BreweryType = 6
+
+	// BreweryTypeNano is a very small brewery.
+	BreweryTypeNano BreweryType = 10
+
+	// BreweryTypeContract is a brewery which contracts another brewery to
+	// produce its beer.
+	BreweryTypeContract BreweryType = 11
+
+	// BreweryTypeCidery is a producer of cider.
+	BreweryTypeCidery BreweryType = 12
+
+	// BreweryTypeMeadery is a producer of mead.
+	BreweryTypeMeadery BreweryType = 13
+)
+
+// breweryTypeNames maps known BreweryType constants to the names used for
+// them by Untappd.
+var breweryTypeNames = map[BreweryType]string{
+	BreweryTypeUnknown:            "Unknown",
+	BreweryTypeMacro:              "Macro Brewery",
+	BreweryTypeMicro:              "Micro Brewery",
+	BreweryTypeBrewPub:            "Brew Pub",
+	BreweryTypeHome:               "Home Brewery",
+	BreweryTypeBarRestaurantStore: "Bar / Restaurant / Store",
+	BreweryTypeRegional:           "Regional Brewery",
+	BreweryTypeNano:               "Nano Brewery",
+	BreweryTypeContract:           "Contract Brewery",
+	BreweryTypeCidery:             "Cidery",
+	BreweryTypeMeadery:            "Meadery",
+}
+
+// String returns the name used by Untappd for a BreweryType, such as
+// "Micro Brewery".  Unknown types are formatted as "BreweryType(N)".
+func (t BreweryType) String() string {
+	if s, ok := breweryTypeNames[t]; ok {
+		return s
+	}
+
+	return "BreweryType(" + strconv.Itoa(int(t)) + ")"
+}
+
+// TypeConst returns the BreweryType for a Brewery, using its TypeID.  If the
+// Brewery is nil, BreweryTypeUnknown is returned.
+//
+// TypeConst allows breweries to be filtered by type without comparing
+// strings, such as by comparing against BreweryTypeMicro.
+func (b *Brewery) TypeConst() BreweryType {
+	if b == nil {
+		return BreweryTypeUnknown
+	}
+
+	return BreweryType(b.TypeID)
 }
 
 // BreweryLocation represent's an Untappd brewery's location, and contains
@@ -44,31 +135,102 @@ type BreweryContact struct {
 // rawBrewery is the raw JSON representation of an Untappd brewery.  Its data is
 // unmarshaled from JSON and then exported to a Brewery struct.
 type rawBrewery struct {
-	ID       int             `json:"brewery_id"`
+	ID       int64           `json:"brewery_id"`
 	Name     string          `json:"brewery_name"`
 	Slug     string          `json:"brewery_slug"`
 	Logo     responseURL     `json:"brewery_label"`
 	Country  string          `json:"country_name"`
-	Active   responseBool    `json:"brewery_active"`
+	Active   *responseBool   `json:"brewery_active"`
 	Location BreweryLocation `json:"location"`
 	Contact  BreweryContact  `json:"contact"`
 	Type     string          `json:"brewery_type"`
 	TypeID   int             `json:"brewery_type_id"`
+
+	BeerCount int `json:"beer_count"`
+	BeerList  struct {
+		Count int `json:"count"`
+		Items []struct {
+			Beer    rawBeer    `json:"beer"`
+			Brewery rawBrewery `json:"brewery"`
+		} `json:"items"`
+	} `json:"beer_list"`
 }
 
 // export creates an exported Brewery from a rawBrewery struct, allowing for
 // more useful structures to be created for client consumption.
 func (r *rawBrewery) export() *Brewery {
-	return &Brewery{
-		ID:       r.ID,
-		Name:     r.Name,
-		Slug:     r.Slug,
-		Logo:     url.URL(r.Logo),
-		Country:  r.Country,
-		Active:   bool(r.Active),
-		Location: r.Location,
-		Contact:  r.Contact,
-		Type:     r.Type,
-		TypeID:   r.TypeID,
+	b := &Brewery{
+		ID:        r.ID,
+		Name:      r.Name,
+		Slug:      r.Slug,
+		Logo:      url.URL(r.Logo),
+		Country:   r.Country,
+		Active:    r.Active.or(true),
+		Location:  r.Location,
+		Contact:   r.Contact,
+		Type:      r.Type,
+		TypeID:    r.TypeID,
+		BeerCount: r.BeerCount,
+	}
+
+	// If a beer list is present, as is the case with /v4/brewery/info/ID,
+	// add it now.  Each beer's brewery is exported from its own list item,
+	// rather than pointing back at b, so that a Brewery can be safely
+	// encoded as JSON.
+	if len(r.BeerList.Items) > 0 {
+		b.Beers = make([]*Beer, len(r.BeerList.Items))
+		for i := range r.BeerList.Items {
+			b.Beers[i] = r.BeerList.Items[i].Beer.exportWithBrewery(r.BeerList.Items[i].Brewery)
+		}
 	}
+
+	return b
+}
+
+// String returns a concise string representation of a Brewery, containing
+// its name and location, such as "Bell's Brewery (Comstock, MI, United
+// States)".  Location is omitted if unavailable.
+func (b *Brewery) String() string {
+	if b == nil {
+		return "<nil>"
+	}
+
+	return withDetail(b.Name, joinNonEmpty(", ", b.Location.City, b.Location.State, b.Country))
+}
+
+// MarshalJSON implements json.Marshaler, so that a Brewery's URLs are encoded
+// as strings.
+func (b Brewery) MarshalJSON() ([]byte, error) {
+	type brewery Brewery
+	return json.Marshal(struct {
+		brewery
+		Logo string `json:"logo"`
+	}{
+		brewery: brewery(b),
+		Logo:    b.Logo.String(),
+	})
+}
+
+// UnmarshalJSON implements json.Unmarshaler, so that JSON produced by
+// MarshalJSON can be decoded into a Brewery.
+func (b *Brewery) UnmarshalJSON(data []byte) error {
+	type brewery Brewery
+	v := struct {
+		*brewery
+		Logo string `json:"logo"`
+	}{
+		brewery: (*brewery)(b),
+	}
+
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+
+	u, err := url.Parse(v.Logo)
+	if err != nil {
+		return err
+	}
+	b.Logo = *u
+
+	return nil
 }
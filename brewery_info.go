@@ -9,14 +9,36 @@ import (
 // Info queries for information about a Brewery with the specified ID.
 // If the compact parameter is set to 'true', only basic brewery information will
 // be populated.
-func (b *BreweryService) Info(id int, compact bool) (*Brewery, *http.Response, error) {
+func (b *BreweryService) Info(id int64, compact bool) (*Brewery, *http.Response, error) {
+	return b.info(id, compact, 0, 0)
+}
+
+// InfoBeers queries for information about a Brewery with the specified ID,
+// but also accepts offset and limit parameters to enable paging through the
+// Brewery's list of beers.  The beers are available in the Beers member
+// of the returned Brewery, and the total number of beers produced by the
+// Brewery is available in its BeerCount member.
+func (b *BreweryService) InfoBeers(id int64, offset int, limit int) (*Brewery, *http.Response, error) {
+	return b.info(id, false, offset, limit)
+}
+
+// info is the backing method for Info and InfoBeers.  The offset and limit
+// parameters are only sent when they are greater than zero, so that the API
+// uses its defaults otherwise.
+func (b *BreweryService) info(id int64, compact bool, offset int, limit int) (*Brewery, *http.Response, error) {
 	// Determine if a compact response is requested
 	q := url.Values{}
 	if compact {
 		q.Set("compact", "true")
 	}
+	if offset > 0 {
+		q.Set("offset", strconv.Itoa(offset))
+	}
+	if limit > 0 {
+		q.Set("limit", strconv.Itoa(limit))
+	}
 
-	// Temporary struct to unmarshal raw user JSON
+	// Temporary struct to unmarshal raw brewery JSON
 	var v struct {
 		Response struct {
 			Brewery rawBrewery `json:"brewery"`
@@ -24,7 +46,7 @@ func (b *BreweryService) Info(id int, compact bool) (*Brewery, *http.Response, e
 	}
 
 	// Perform request for brewery information by ID
-	res, err := b.client.request("GET", "brewery/info/"+strconv.Itoa(id), nil, q, &v)
+	res, err := b.client.request("GET", "brewery/info/"+strconv.FormatInt(id, 10), nil, q, &v)
 	if err != nil {
 		return nil, res, err
 	}
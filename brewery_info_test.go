@@ -11,8 +11,8 @@ import (
 // TestClientBreweryInfoBadBrewery verifies that Client.Brewery.Info returns an error when
 // an invalid brewery is queried.
 func TestClientBreweryInfoBadBrewery(t *testing.T) {
-	breweryID := -1
-	sBreweryID := strconv.Itoa(breweryID)
+	breweryID := int64(-1)
+	sBreweryID := strconv.FormatInt(breweryID, 10)
 
 	c, done := breweryInfoTestClient(t, func(t *testing.T, w http.ResponseWriter, r *http.Request) {
 		path := "/v4/brewery/info/" + sBreweryID + "/"
@@ -52,8 +52,8 @@ func TestClientBreweryInfoCompactOK(t *testing.T) {
 // TestClientBreweryInfoOK verifies that Client.Brewery.Info returns a valid brewery when
 // provided with correct input parameters.
 func TestClientBreweryInfoOK(t *testing.T) {
-	breweryID := 1
-	sBreweryID := strconv.Itoa(breweryID)
+	breweryID := int64(1)
+	sBreweryID := strconv.FormatInt(breweryID, 10)
 
 	c, done := breweryInfoTestClient(t, func(t *testing.T, w http.ResponseWriter, r *http.Request) {
 		path := "/v4/brewery/info/" + sBreweryID + "/"
@@ -95,6 +95,42 @@ func TestClientBreweryInfoOK(t *testing.T) {
 	}
 }
 
+// TestClientBreweryInfoBeersOK verifies that Client.Brewery.InfoBeers sends
+// the appropriate paging parameters, and returns a brewery with its embedded
+// beer list.
+func TestClientBreweryInfoBeersOK(t *testing.T) {
+	c, done := breweryInfoTestClient(t, func(t *testing.T, w http.ResponseWriter, r *http.Request) {
+		assertParameters(t, r, url.Values{
+			"offset": []string{"25"},
+			"limit":  []string{"2"},
+		})
+
+		w.Write(bellsBreweryBeersJSON)
+	})
+	defer done()
+
+	b, _, err := c.Brewery.InfoBeers(1, 25, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if n := b.BeerCount; n != 312 {
+		t.Fatalf("unexpected Brewery.BeerCount: %d != %d", n, 312)
+	}
+	if l := len(b.Beers); l != 2 {
+		t.Fatalf("unexpected number of beers: %d != %d", l, 2)
+	}
+
+	for i, name := range []string{"Two Hearted Ale", "Oberon Ale"} {
+		if n := b.Beers[i].Name; n != name {
+			t.Fatalf("unexpected beer Name: %q != %q", n, name)
+		}
+		if n := b.Beers[i].Brewery.Name; n != b.Name {
+			t.Fatalf("unexpected beer Brewery.Name: %q != %q", n, b.Name)
+		}
+	}
+}
+
 // breweryInfoTestClient builds upon testClient, and adds additional sanity checks
 // for tests which target the brewery info API.
 func breweryInfoTestClient(t *testing.T, fn func(t *testing.T, w http.ResponseWriter, r *http.Request)) (*Client, func()) {
@@ -144,3 +180,143 @@ var bellsBreweryJSON = []byte(`
     }
   }
 }`)
+
+// Canned JSON for a brewery with an embedded beer list
+var bellsBreweryBeersJSON = []byte(`
+{
+  "meta": {
+    "code": 200,
+    "response_time": {
+      "time": 0,
+      "measure": "seconds"
+    }
+  },
+  "notifications": {},
+  "response": {
+    "brewery": {
+      "brewery_id": 1,
+      "brewery_name": "Bell's Brewery, Inc.",
+      "beer_count": 312,
+      "beer_list": {
+        "count": 2,
+        "items": [
+          {
+            "beer": {
+              "bid": 4473,
+              "beer_name": "Two Hearted Ale"
+            },
+            "brewery": {
+              "brewery_id": 1,
+              "brewery_name": "Bell's Brewery, Inc."
+            }
+          },
+          {
+            "beer": {
+              "bid": 3784,
+              "beer_name": "Oberon Ale"
+            },
+            "brewery": {
+              "brewery_id": 1,
+              "brewery_name": "Bell's Brewery, Inc."
+            }
+          }
+        ]
+      }
+    }
+  }
+}`)
+
+// TestBreweryString verifies that Brewery.String produces a concise
+// description of a Brewery, including when fields are unavailable.
+func TestBreweryString(t *testing.T) {
+	tests := []struct {
+		b *Brewery
+		s string
+	}{
+		{
+			b: nil,
+			s: "<nil>",
+		},
+		{
+			b: &Brewery{Name: "Bell's Brewery"},
+			s: "Bell's Brewery",
+		},
+		{
+			b: &Brewery{
+				Name:    "Bell's Brewery",
+				Country: "United States",
+				Location: BreweryLocation{
+					City:  "Comstock",
+					State: "MI",
+				},
+			},
+			s: "Bell's Brewery (Comstock, MI, United States)",
+		},
+	}
+
+	for i, tt := range tests {
+		if s := tt.b.String(); s != tt.s {
+			t.Fatalf("%02d: unexpected string: %q != %q", i, s, tt.s)
+		}
+	}
+}
+
+// TestBreweryType verifies that BreweryType.String maps known brewery type
+// IDs to names, and that Brewery.TypeConst uses a Brewery's TypeID.
+func TestBreweryType(t *testing.T) {
+	tests := []struct {
+		id   int
+		t    BreweryType
+		name string
+	}{
+		{id: 0, t: BreweryTypeUnknown, name: "Unknown"},
+		{id: 1, t: BreweryTypeMacro, name: "Macro Brewery"},
+		{id: 2, t: BreweryTypeMicro, name: "Micro Brewery"},
+		{id: 3, t: BreweryTypeBrewPub, name: "Brew Pub"},
+		{id: 4, t: BreweryTypeHome, name: "Home Brewery"},
+		{id: 5, t: BreweryTypeBarRestaurantStore, name: "Bar / Restaurant / Store"},
+		{id: 6, t: BreweryTypeRegional, name: "Regional Brewery"},
+		{id: 10, t: BreweryTypeNano, name: "Nano Brewery"},
+		{id: 11, t: BreweryTypeContract, name: "Contract Brewery"},
+		{id: 12, t: BreweryTypeCidery, name: "Cidery"},
+		{id: 13, t: BreweryTypeMeadery, name: "Meadery"},
+		{id: 99, t: BreweryType(99), name: "BreweryType(99)"},
+	}
+
+	for _, tt := range tests {
+		b := &Brewery{TypeID: tt.id}
+
+		if bt := b.TypeConst(); bt != tt.t {
+			t.Fatalf("unexpected brewery type for ID %d: %d != %d", tt.id, bt, tt.t)
+		}
+		if s := b.TypeConst().String(); s != tt.name {
+			t.Fatalf("unexpected brewery type name for ID %d: %q != %q", tt.id, s, tt.name)
+		}
+	}
+
+	var b *Brewery
+	if bt := b.TypeConst(); bt != BreweryTypeUnknown {
+		t.Fatalf("unexpected brewery type for nil Brewery: %d != %d", bt, BreweryTypeUnknown)
+	}
+}
+
+// TestClientBreweryInfoTypeConst verifies that a Brewery's type from
+// Client.Brewery.Info matches the reported brewery type name.
+func TestClientBreweryInfoTypeConst(t *testing.T) {
+	c, done := breweryInfoTestClient(t, func(t *testing.T, w http.ResponseWriter, r *http.Request) {
+		w.Write(bellsBreweryJSON)
+	})
+	defer done()
+
+	b, _, err := c.Brewery.Info(1, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if bt := b.TypeConst(); bt != BreweryTypeMicro {
+		t.Fatalf("unexpected brewery type: %v != %v", bt, BreweryTypeMicro)
+	}
+	if s := b.TypeConst().String(); s != b.Type {
+		t.Fatalf("unexpected brewery type name: %q != %q", s, b.Type)
+	}
+}
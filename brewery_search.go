@@ -8,11 +8,12 @@ import (
 
 // Search searches for information about breweries, using the specified search query.
 //
-// This method returns up to 25 search results.  For more granular control,
-// and to page through the results list, use SearchOffsetLimit instead.
+// This method returns up to Client.DefaultLimit search results.  For more
+// granular control, and to page through the results list, use SearchOffsetLimit
+// instead.
 func (b *BreweryService) Search(query string) ([]*Brewery, *http.Response, error) {
 	// Use default parameters as specified by API
-	return b.SearchOffsetLimit(query, 0, 25)
+	return b.SearchOffsetLimit(query, 0, b.client.limit(MaxBreweriesLimit))
 }
 
 // SearchOffsetLimit searches for information about breweries, using the specified
@@ -20,7 +21,14 @@ func (b *BreweryService) Search(query string) ([]*Brewery, *http.Response, error
 // paging through more than 25 breweries.
 //
 // 50 breweries is the maximum number of results which may be returned by one call.
+// If limit exceeds MaxBreweriesLimit, ErrInvalidLimit is returned.  To determine
+// whether more results exist, pass the returned *http.Response to
+// ResponseSearchTotal.
 func (b *BreweryService) SearchOffsetLimit(query string, offset int, limit int) ([]*Brewery, *http.Response, error) {
+	if err := checkLimit(limit, MaxBreweriesLimit); err != nil {
+		return nil, nil, err
+	}
+
 	q := url.Values{
 		"q":      []string{query},
 		"offset": []string{strconv.Itoa(offset)},
@@ -32,7 +40,7 @@ func (b *BreweryService) SearchOffsetLimit(query string, offset int, limit int)
 		Response struct {
 			Brewery struct {
 				Count int `json:"count"`
-				Items []struct {
+				Items []*struct {
 					Brewery rawBrewery `json:"brewery"`
 				} `json:"items"`
 			} `json:"brewery"`
@@ -45,10 +53,15 @@ func (b *BreweryService) SearchOffsetLimit(query string, offset int, limit int)
 		return nil, res, err
 	}
 
-	// Build result slice from struct
-	breweries := make([]*Brewery, v.Response.Brewery.Count)
-	for i := range v.Response.Brewery.Items {
-		breweries[i] = v.Response.Brewery.Items[i].Brewery.export()
+	// Build result slice from struct, sized by the number of items actually
+	// returned, since the reported count may not match
+	breweries := make([]*Brewery, 0, len(v.Response.Brewery.Items))
+	for _, item := range v.Response.Brewery.Items {
+		if item == nil {
+			continue
+		}
+
+		breweries = append(breweries, item.Brewery.export())
 	}
 
 	return breweries, res, nil
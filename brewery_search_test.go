@@ -93,6 +93,75 @@ func TestClientBrewerySearchOffsetLimitOK(t *testing.T) {
 	}
 }
 
+// TestClientBrewerySearchTotal verifies that ResponseSearchTotal returns the
+// total number of results for a brewery search.
+func TestClientBrewerySearchTotal(t *testing.T) {
+	c, done := brewerySearchTestClient(t, func(t *testing.T, w http.ResponseWriter, r *http.Request) {
+		w.Write(brewerySearchJSON)
+	})
+	defer done()
+
+	_, res, err := c.Brewery.SearchOffsetLimit("russian river", 0, 25)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	total, err := ResponseSearchTotal(res)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := (SearchTotal{Found: 1, Count: 1}); *total != want {
+		t.Fatalf("unexpected SearchTotal: %+v != %+v", *total, want)
+	}
+	if total.More(0) {
+		t.Fatal("expected no more results after only page")
+	}
+}
+
+// TestClientBrewerySearchCountMismatch verifies that
+// Client.Brewery.Search returns only the breweries present in a
+// response, even if the reported count does not match.
+func TestClientBrewerySearchCountMismatch(t *testing.T) {
+	var tests = []struct {
+		description string
+		body        string
+		n           int
+	}{
+		{
+			description: "count lower than items",
+			body:        `{"meta":{"code":200},"response":{"brewery":{"count":1,"items":[{"brewery":{"brewery_id":1}},{"brewery":{"brewery_id":2}}]}}}`,
+			n:           2,
+		},
+		{
+			description: "count higher than items, with null item",
+			body:        `{"meta":{"code":200},"response":{"brewery":{"count":25,"items":[{"brewery":{"brewery_id":1}},null]}}}`,
+			n:           1,
+		},
+	}
+
+	for _, tt := range tests {
+		c, done := brewerySearchTestClient(t, func(t *testing.T, w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(tt.body))
+		})
+
+		breweries, _, err := c.Brewery.Search("foo")
+		done()
+		if err != nil {
+			t.Fatalf("unexpected error for test %q: %v", tt.description, err)
+		}
+
+		if l := len(breweries); l != tt.n {
+			t.Fatalf("unexpected number of breweries for test %q: %d != %d", tt.description, l, tt.n)
+		}
+		for _, x := range breweries {
+			if x == nil || x.ID == 0 {
+				t.Fatalf("unexpected brewery for test %q: %+v", tt.description, x)
+			}
+		}
+	}
+}
+
 // brewerySearchTestClient builds upon testClient, and adds additional sanity checks
 // for tests which target the user breweries API.
 func brewerySearchTestClient(t *testing.T, fn func(t *testing.T, w http.ResponseWriter, r *http.Request)) (*Client, func()) {
@@ -127,6 +196,7 @@ var brewerySearchJSON = []byte(`{
   },
   "notifications": {},
   "response": {
+  "found": 1,
   "brewery": {
     "count": 1,
     "items": [
@@ -1,6 +1,9 @@
 package untappd
 
 import (
+	"encoding/json"
+	"math"
+	"net/url"
 	"time"
 )
 
@@ -9,46 +12,87 @@ import (
 // information about the user, beer, and brewery for a given checkin.
 type Checkin struct {
 	// Metadata from Untappd.
-	ID int
+	ID int64 `json:"id"`
 
 	// Time when this checkin was added to Untappd.
-	Created time.Time
+	Created time.Time `json:"created"`
+
+	// Time when this checkin was added to Untappd, in the local timezone
+	// of the user who checked in, if the Untappd APIv4 provided a timezone
+	// hint.  Otherwise, CreatedLocal is identical to Created.
+	CreatedLocal time.Time `json:"created_local"`
 
 	// User comment for this checkin.  May be blank.
-	Comment string
+	Comment string `json:"comment"`
 
 	// If applicable, the specified user's rating for this beer.
-	UserRating float64
+	UserRating float64 `json:"user_rating"`
 
 	// The user checking in.
-	User *User
+	User *User `json:"user"`
 
 	// The checkin beer.
-	Beer *Beer
+	Beer *Beer `json:"beer"`
 
 	// If available, information regarding the brewery which created
 	// this beer.
-	Brewery *Brewery
+	Brewery *Brewery `json:"brewery"`
 
 	// If available, information regarding the venue where this checkin
 	// occurred.  If a venue was not added to the checkin, this member
-	// will be nil.
-	Venue *Venue
+	// will be nil; HasVenue and VenueName may be used to avoid checking
+	// for nil.
+	Venue *Venue `json:"venue"`
 
 	// Badges earned when this checkin was submitted.
-	Badges []*Badge
+	Badges []*Badge `json:"badges"`
+
+	// Untappd points earned when this checkin was submitted.  Only
+	// populated for checkins returned by Auth.Checkin.
+	Score float64 `json:"score"`
 
 	// Toasts by Untappd users for this checkin.
-	Toasts []*Toast
+	Toasts []*Toast `json:"toasts"`
+
+	// Total number of toasts for this checkin, which may be greater than
+	// the number of Toasts returned.
+	ToastCount int `json:"toast_count"`
+
+	// Whether or not the authenticated user has toasted this checkin.
+	// Only populated for authenticated requests.  To toast a checkin,
+	// or to remove an existing toast, use Auth.Toast, and then apply its
+	// result to the checkin using ApplyToast.
+	AuthToasted bool `json:"auth_toasted"`
 
 	// Comments by Untappd users about this checkin.
-	Comments []*Comment
+	Comments []*Comment `json:"comments"`
+
+	// Total number of comments for this checkin, which may be greater than
+	// the number of Comments returned.
+	CommentCount int `json:"comment_count"`
+
+	// Photos attached to this checkin.
+	Media []*CheckinMedia `json:"media"`
+
+	// The toast appended to Toasts by ApplyToast, so that it can be
+	// removed again if the toast is later removed.
+	appliedToast *Toast
+}
+
+// CheckinMedia contains links to a photo attached to a Checkin.  Included
+// are links to a small, medium, large, and original size image.
+type CheckinMedia struct {
+	ID            int64
+	SmallImage    url.URL
+	MediumImage   url.URL
+	LargeImage    url.URL
+	OriginalImage url.URL
 }
 
 // rawCheckin is the raw JSON representation of an Untappd checkin.  Its data is
 // unmarshaled from JSON and then exported to a Checkin struct.
 type rawCheckin struct {
-	ID         int           `json:"checkin_id"`
+	ID         int64         `json:"checkin_id"`
 	Beer       rawBeer       `json:"beer"`
 	Brewery    rawBrewery    `json:"brewery"`
 	User       rawUser       `json:"user"`
@@ -57,20 +101,28 @@ type rawCheckin struct {
 	Comment    string        `json:"checkin_comment"`
 	Created    responseTime  `json:"created_at"`
 
-	Badges struct {
-		Count int         `json:"count"`
-		Items []*rawBadge `json:"items"`
-	} `json:"badges"`
+	CreatedTimeZone responseTimeZone `json:"created_at_timezone"`
+
+	Badges responseBadges `json:"badges"`
+	Score  responseScore  `json:"score"`
 
 	Toasts struct {
-		Count int         `json:"count"`
-		Items []*rawToast `json:"items"`
+		TotalCount int          `json:"total_count"`
+		Count      int          `json:"count"`
+		AuthToast  responseBool `json:"auth_toast"`
+		Items      []*rawToast  `json:"items"`
 	} `json:"toasts"`
 
 	Comments struct {
-		Count int           `json:"count"`
-		Items []*rawComment `json:"items"`
+		TotalCount int           `json:"total_count"`
+		Count      int           `json:"count"`
+		Items      []*rawComment `json:"items"`
 	} `json:"comments"`
+
+	Media struct {
+		Count int                `json:"count"`
+		Items []*rawCheckinMedia `json:"items"`
+	} `json:"media"`
 }
 
 // export creates an exported Checkin from a rawCheckin struct, allowing for more
@@ -81,11 +133,13 @@ func (r *rawCheckin) export() *Checkin {
 		Comment:    r.Comment,
 		UserRating: r.UserRating,
 		Created:    time.Time(r.Created),
-		Beer:       r.Beer.export(),
+		Beer:       r.Beer.exportWithBrewery(r.Brewery),
 		Brewery:    r.Brewery.export(),
 		User:       r.User.export(),
 	}
 
+	c.CreatedLocal = r.CreatedTimeZone.in(c.Created)
+
 	// If no venue was set in the response JSON, venue will be nil
 	if r.Venue.ID != 0 && r.Venue.Name != "" {
 		// Since venue was not empty, add it to the struct
@@ -93,23 +147,297 @@ func (r *rawCheckin) export() *Checkin {
 		c.Venue = rv.export()
 	}
 
-	badges := make([]*Badge, r.Badges.Count)
-	for i := range r.Badges.Items {
-		badges[i] = r.Badges.Items[i].export()
+	badges := make([]*Badge, 0, len(r.Badges.Items))
+	for _, b := range r.Badges.Items {
+		if b == nil {
+			continue
+		}
+
+		badges = append(badges, b.export())
 	}
 	c.Badges = badges
+	c.Score = float64(r.Score)
 
-	toasts := make([]*Toast, r.Toasts.Count)
-	for i := range r.Toasts.Items {
-		toasts[i] = r.Toasts.Items[i].export()
+	toasts := make([]*Toast, 0, len(r.Toasts.Items))
+	for _, t := range r.Toasts.Items {
+		if t == nil {
+			continue
+		}
+
+		toasts = append(toasts, t.export())
 	}
 	c.Toasts = toasts
+	c.ToastCount = r.Toasts.TotalCount
+	c.AuthToasted = bool(r.Toasts.AuthToast)
+
+	comments := make([]*Comment, 0, len(r.Comments.Items))
+	for _, cm := range r.Comments.Items {
+		if cm == nil {
+			continue
+		}
 
-	comments := make([]*Comment, r.Comments.Count)
-	for i := range r.Comments.Items {
-		comments[i] = r.Comments.Items[i].export()
+		comments = append(comments, cm.export())
 	}
 	c.Comments = comments
+	c.CommentCount = r.Comments.TotalCount
+
+	media := make([]*CheckinMedia, len(r.Media.Items))
+	for i := range r.Media.Items {
+		media[i] = r.Media.Items[i].export()
+	}
+	c.Media = media
 
 	return c
 }
+
+// HasVenue reports whether a venue was added to the Checkin.  When HasVenue
+// returns false, the Checkin's Venue member is nil.  If the Checkin is nil,
+// HasVenue returns false.
+func (c *Checkin) HasVenue() bool {
+	return c != nil && c.Venue != nil
+}
+
+// VenueName returns the name of the venue where the Checkin occurred, or an
+// empty string if a venue was not added to the Checkin, or the Checkin is nil.
+func (c *Checkin) VenueName() string {
+	if !c.HasVenue() {
+		return ""
+	}
+
+	return c.Venue.Name
+}
+
+// ApplyToast updates the Checkin's ToastCount and AuthToasted members using
+// the result of toasting the Checkin with Auth.Toast, so that the Checkin
+// reflects the toast without querying for it again.  If the toast was
+// created, it is also appended to Toasts.  If the toast was removed, a toast
+// appended by an earlier call to ApplyToast is removed from Toasts; toasts
+// returned by the Untappd APIv4 are left in place, because they cannot be
+// attributed to the authenticated user.
+//
+// A typical "toast back" flow for an authenticated user viewing a checkin
+// is:
+//
+//	c, _, err := client.Checkin(id)
+//	// ...
+//	if !c.AuthToasted {
+//		tr, _, err := client.Auth.Toast(c.ID)
+//		// ...
+//		c.ApplyToast(tr)
+//	}
+//
+// If r is nil, ApplyToast has no effect.
+func (c *Checkin) ApplyToast(r *ToastResult) {
+	if r == nil {
+		return
+	}
+
+	c.ToastCount = r.TotalCount
+	c.AuthToasted = r.Toast != nil
+	if r.Toast != nil {
+		c.Toasts = append(c.Toasts, r.Toast)
+		c.appliedToast = r.Toast
+		return
+	}
+
+	if c.appliedToast == nil {
+		return
+	}
+
+	toasts := make([]*Toast, 0, len(c.Toasts))
+	for _, t := range c.Toasts {
+		if t != c.appliedToast {
+			toasts = append(toasts, t)
+		}
+	}
+	c.Toasts = toasts
+	c.appliedToast = nil
+}
+
+// FilterWithMedia returns only the Checkins from the input slice which have
+// at least one photo attached.
+//
+// The Untappd APIv4 cannot filter checkins by media, so this filtering is
+// performed by the client.  When used with a single page of checkins, the
+// result may contain fewer checkins than were requested.
+func FilterWithMedia(checkins []*Checkin) []*Checkin {
+	var out []*Checkin
+	for _, c := range checkins {
+		if len(c.Media) > 0 {
+			out = append(out, c)
+		}
+	}
+
+	return out
+}
+
+// RatingHistogram returns the number of Checkins from the input slice with
+// each rating, keyed by rating.  Ratings are rounded to the nearest 0.25, as
+// with CheckinRequest.Rating, so that each key is one of the ratings from
+// 0.5 to 5 accepted by Untappd.
+//
+// Unrated checkins, nil checkins, and checkins whose ratings are outside of
+// the range accepted by Untappd are ignored.
+func RatingHistogram(checkins []*Checkin) map[float64]int {
+	h := make(map[float64]int)
+	for _, c := range checkins {
+		if c == nil || c.UserRating == 0 {
+			continue
+		}
+
+		r, err := checkRating(c.UserRating)
+		if err != nil {
+			continue
+		}
+
+		h[r]++
+	}
+
+	return h
+}
+
+// RoundToHalf rounds a rating to the nearest 0.5, such as for display as a
+// number of stars.  Halves are rounded up, so a rating of 3.75 becomes 4.
+// A rating of zero, meaning unrated, remains zero.
+func RoundToHalf(f float64) float64 {
+	return math.Round(f*2) / 2
+}
+
+// A Rating is a rating of a beer, such as Checkin.UserRating or
+// Beer.UserRating, converted to a Rating to access its helper methods.
+// A Rating of zero means the beer is unrated.
+type Rating float64
+
+// Stars returns the number of full and half stars used to display a Rating,
+// after rounding it to the nearest 0.5 using RoundToHalf.  half is always
+// either 0 or 1.  Ratings above 5 are displayed as 5 full stars.
+//
+// An unrated beer, or a negative or otherwise invalid Rating, has no stars.
+func (r Rating) Stars() (full int, half int) {
+	f := RoundToHalf(float64(r))
+	if !(f > 0) {
+		return 0, 0
+	}
+	if f > maxRating {
+		f = maxRating
+	}
+
+	full = int(f)
+	if f > float64(full) {
+		half = 1
+	}
+
+	return full, half
+}
+
+// DedupCheckins returns the Checkins from the input slice with duplicate IDs
+// removed, preserving the order in which each Checkin first appears.  Nil
+// entries are removed as well.
+//
+// Checkin IDs are globally unique across Untappd, so DedupCheckins may be
+// used to merge overlapping pages of checkins, even when they originate
+// from different activity feeds.
+func DedupCheckins(checkins []*Checkin) []*Checkin {
+	seen := make(map[int64]struct{}, len(checkins))
+
+	var out []*Checkin
+	for _, c := range checkins {
+		if c == nil {
+			continue
+		}
+		if _, ok := seen[c.ID]; ok {
+			continue
+		}
+
+		seen[c.ID] = struct{}{}
+		out = append(out, c)
+	}
+
+	return out
+}
+
+// rawCheckinMedia is the raw JSON representation of Untappd checkin media.  Its
+// data is unmarshaled from JSON and then exported to a CheckinMedia struct.
+type rawCheckinMedia struct {
+	ID    int64 `json:"photo_id"`
+	Photo struct {
+		SmallImage  responseURL `json:"photo_img_sm"`
+		MediumImage responseURL `json:"photo_img_md"`
+		// Some responses use this key for the medium image instead.
+		MediumImageAlt responseURL `json:"photo_img_med"`
+		LargeImage     responseURL `json:"photo_img_lg"`
+		OriginalImage  responseURL `json:"photo_img_og"`
+	} `json:"photo"`
+}
+
+// export creates an exported CheckinMedia from a rawCheckinMedia struct,
+// allowing for more useful structures to be created for client consumption.
+func (r *rawCheckinMedia) export() *CheckinMedia {
+	m := &CheckinMedia{
+		ID:            r.ID,
+		SmallImage:    url.URL(r.Photo.SmallImage),
+		MediumImage:   url.URL(r.Photo.MediumImage),
+		LargeImage:    url.URL(r.Photo.LargeImage),
+		OriginalImage: url.URL(r.Photo.OriginalImage),
+	}
+
+	// If the medium image was sent using the alternate key, use it instead
+	if m.MediumImage.String() == "" {
+		m.MediumImage = url.URL(r.Photo.MediumImageAlt)
+	}
+
+	return m
+}
+
+// MarshalJSON implements json.Marshaler, so that CheckinMedia's URLs are
+// encoded as strings.
+func (m CheckinMedia) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		ID            int64  `json:"id"`
+		SmallImage    string `json:"small_image"`
+		MediumImage   string `json:"medium_image"`
+		LargeImage    string `json:"large_image"`
+		OriginalImage string `json:"original_image"`
+	}{
+		ID:            m.ID,
+		SmallImage:    m.SmallImage.String(),
+		MediumImage:   m.MediumImage.String(),
+		LargeImage:    m.LargeImage.String(),
+		OriginalImage: m.OriginalImage.String(),
+	})
+}
+
+// UnmarshalJSON implements json.Unmarshaler, so that JSON produced by
+// MarshalJSON can be decoded into CheckinMedia.
+func (m *CheckinMedia) UnmarshalJSON(data []byte) error {
+	var v struct {
+		ID            int64  `json:"id"`
+		SmallImage    string `json:"small_image"`
+		MediumImage   string `json:"medium_image"`
+		LargeImage    string `json:"large_image"`
+		OriginalImage string `json:"original_image"`
+	}
+
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+
+	m.ID = v.ID
+	for _, p := range []struct {
+		s string
+		u *url.URL
+	}{
+		{s: v.SmallImage, u: &m.SmallImage},
+		{s: v.MediumImage, u: &m.MediumImage},
+		{s: v.LargeImage, u: &m.LargeImage},
+		{s: v.OriginalImage, u: &m.OriginalImage},
+	} {
+		u, err := url.Parse(p.s)
+		if err != nil {
+			return err
+		}
+		*p.u = *u
+	}
+
+	return nil
+}
@@ -1,7 +1,12 @@
 package untappd
 
 import (
+	"encoding/json"
+	"math"
+	"net/url"
+	"reflect"
 	"testing"
+	"time"
 )
 
 // assertExpectedCheckins validates a set of mock checkins from a test function
@@ -30,6 +35,8 @@ func assertExpectedCheckins(t *testing.T, checkins []*Checkin) {
 					UserName: "gregavola",
 				},
 			}},
+			ToastCount:  3,
+			AuthToasted: true,
 			Comments: []*Comment{{
 				ID:      1,
 				Comment: "hello, world",
@@ -37,6 +44,7 @@ func assertExpectedCheckins(t *testing.T, checkins []*Checkin) {
 					UserName: "gregavola",
 				},
 			}},
+			CommentCount: 12,
 		},
 	}
 
@@ -68,6 +76,12 @@ func assertExpectedCheckins(t *testing.T, checkins []*Checkin) {
 		if checkins[i].Toasts[0].User.UserName != expected[i].Toasts[0].User.UserName {
 			t.Fatalf("unexpected checkin Toast.User.UserName: %q != %q", checkins[i].Toasts[0].User.UserName, expected[i].Toasts[0].User.UserName)
 		}
+		if checkins[i].ToastCount != expected[i].ToastCount {
+			t.Fatalf("unexpected checkin ToastCount: %d != %d", checkins[i].ToastCount, expected[i].ToastCount)
+		}
+		if checkins[i].AuthToasted != expected[i].AuthToasted {
+			t.Fatalf("unexpected checkin AuthToasted: %v != %v", checkins[i].AuthToasted, expected[i].AuthToasted)
+		}
 		if checkins[i].Comments[0].ID != expected[i].Comments[0].ID {
 			t.Fatalf("unexpected checkin Toast.ID: %d != %d", checkins[i].Comments[0].ID, expected[i].Comments[0].ID)
 		}
@@ -77,9 +91,372 @@ func assertExpectedCheckins(t *testing.T, checkins []*Checkin) {
 		if checkins[i].Comments[0].User.UserName != expected[i].Comments[0].User.UserName {
 			t.Fatalf("unexpected checkin Toast.User.UserName: %q != %q", checkins[i].Comments[0].User.UserName, expected[i].Comments[0].User.UserName)
 		}
+
+		// Only one comment is present in the response, but the total
+		// count should reflect all comments on the checkin
+		if checkins[i].CommentCount != expected[i].CommentCount {
+			t.Fatalf("unexpected checkin CommentCount: %d != %d", checkins[i].CommentCount, expected[i].CommentCount)
+		}
 	}
 }
 
+// Test_rawCheckinExportCreatedLocal verifies that a timezone hint is applied
+// to produce Checkin.CreatedLocal, without modifying Checkin.Created.
+func Test_rawCheckinExportCreatedLocal(t *testing.T) {
+	tests := []struct {
+		desc string
+		json string
+		hour int
+	}{
+		{
+			desc: "no timezone hint",
+			json: `{"created_at":"Sun, 01 Jan 2017 00:48:38 +0000"}`,
+			hour: 0,
+		},
+		{
+			desc: "string timezone hint",
+			json: `{"created_at":"Sun, 01 Jan 2017 00:48:38 +0000","created_at_timezone":"-5"}`,
+			hour: 19,
+		},
+		{
+			desc: "numeric fractional timezone hint",
+			json: `{"created_at":"Sun, 01 Jan 2017 00:48:38 +0000","created_at_timezone":5.5}`,
+			hour: 6,
+		},
+	}
+
+	created := time.Date(2017, time.January, 1, 0, 48, 38, 0, time.UTC)
+
+	for _, tt := range tests {
+		var r rawCheckin
+		if err := json.Unmarshal([]byte(tt.json), &r); err != nil {
+			t.Fatalf("[%s] %v", tt.desc, err)
+		}
+
+		c := r.export()
+		if !c.Created.Equal(created) {
+			t.Fatalf("[%s] unexpected Created: %v != %v", tt.desc, c.Created, created)
+		}
+		if !c.CreatedLocal.Equal(created) {
+			t.Fatalf("[%s] unexpected CreatedLocal instant: %v != %v", tt.desc, c.CreatedLocal, created)
+		}
+		if h := c.CreatedLocal.Hour(); h != tt.hour {
+			t.Fatalf("[%s] unexpected CreatedLocal hour: %d != %d", tt.desc, h, tt.hour)
+		}
+	}
+}
+
+// Test_rawCheckinExportVenueIcon verifies that a checkin's venue icon is
+// populated from the sm, md, and lg keys of a venue_icon block.
+func Test_rawCheckinExportVenueIcon(t *testing.T) {
+	var v struct {
+		Response struct {
+			Checkins struct {
+				Items []*rawCheckin `json:"items"`
+			} `json:"checkins"`
+		} `json:"response"`
+	}
+
+	if err := json.Unmarshal(userCheckinsJSON, &v); err != nil {
+		t.Fatal(err)
+	}
+
+	venue := v.Response.Checkins.Items[0].export().Venue
+	if venue == nil {
+		t.Fatal("unexpected nil venue")
+	}
+
+	base := "https://ss3.4sqi.net/img/categories_v2/arts_entertainment/"
+	for _, p := range []struct {
+		u    url.URL
+		want string
+	}{
+		{u: venue.Icon.SmallImage, want: base + "bowling_bg_64.png"},
+		{u: venue.Icon.MediumImage, want: base + "bowling_bg_88.png"},
+		{u: venue.Icon.LargeImage, want: base + "bowling_bg_88.png"},
+	} {
+		if s := p.u.String(); s != p.want {
+			t.Fatalf("unexpected venue icon URL: %q != %q", s, p.want)
+		}
+	}
+
+	if l := len(venue.Photos); l != 0 {
+		t.Fatalf("unexpected number of venue photos: %d != %d", l, 0)
+	}
+}
+
+// Test_rawCheckinExportVenueContact verifies that a checkin's venue contact
+// information is exported from the checkin JSON.
+func Test_rawCheckinExportVenueContact(t *testing.T) {
+	var v struct {
+		Response struct {
+			Checkins struct {
+				Items []*rawCheckin `json:"items"`
+			} `json:"checkins"`
+		} `json:"response"`
+	}
+
+	if err := json.Unmarshal(userCheckinsJSON, &v); err != nil {
+		t.Fatal(err)
+	}
+
+	venue := v.Response.Checkins.Items[0].export().Venue
+	if venue == nil {
+		t.Fatal("unexpected nil venue")
+	}
+
+	if got, want := venue.Contact.Twitter, "@brooklynbowl"; got != want {
+		t.Fatalf("unexpected venue Twitter: %q != %q", got, want)
+	}
+	if got, want := venue.Contact.URL.String(), "http://www.brooklynbowl.com"; got != want {
+		t.Fatalf("unexpected venue URL: %q != %q", got, want)
+	}
+}
+
+// TestCheckinVenueAccessors verifies that Checkin.HasVenue and
+// Checkin.VenueName report a checkin's venue, if one was added.
+func TestCheckinVenueAccessors(t *testing.T) {
+	var v struct {
+		Response struct {
+			Checkins struct {
+				Items []*rawCheckin `json:"items"`
+			} `json:"checkins"`
+		} `json:"response"`
+	}
+
+	if err := json.Unmarshal(userCheckinsJSON, &v); err != nil {
+		t.Fatal(err)
+	}
+
+	var nv struct {
+		Response struct {
+			Checkin *rawCheckin `json:"checkin"`
+		} `json:"response"`
+	}
+
+	if err := json.Unmarshal(checkinViewJSON, &nv); err != nil {
+		t.Fatal(err)
+	}
+
+	var tests = []struct {
+		description string
+		checkin     *Checkin
+		has         bool
+		name        string
+	}{
+		{
+			description: "with venue",
+			checkin:     v.Response.Checkins.Items[0].export(),
+			has:         true,
+			name:        "Brooklyn Bowl",
+		},
+		{
+			description: "no venue",
+			checkin:     nv.Response.Checkin.export(),
+		},
+		{
+			description: "nil checkin",
+		},
+	}
+
+	for _, tt := range tests {
+		if got := tt.checkin.HasVenue(); got != tt.has {
+			t.Fatalf("unexpected HasVenue for test %q: %v != %v", tt.description, got, tt.has)
+		}
+		if got := tt.checkin.VenueName(); got != tt.name {
+			t.Fatalf("unexpected VenueName for test %q: %q != %q", tt.description, got, tt.name)
+		}
+		if got := tt.checkin != nil && tt.checkin.Venue != nil; got != tt.has {
+			t.Fatalf("unexpected non-nil Venue for test %q: %v != %v", tt.description, got, tt.has)
+		}
+	}
+}
+
+// Test_rawCheckinExportCountMismatch verifies that only the toasts and
+// comments present in a checkin are exported, even if the reported counts
+// do not match.
+func Test_rawCheckinExportCountMismatch(t *testing.T) {
+	var tests = []struct {
+		description string
+		body        string
+		n           int
+	}{
+		{
+			description: "counts lower than items",
+			body:        `{"toasts":{"count":1,"items":[{"like_id":1,"user":{"uid":1}},{"like_id":2,"user":{"uid":1}}]},"comments":{"count":1,"items":[{"comment_id":1,"user":{"uid":1}},{"comment_id":2,"user":{"uid":1}}]}}`,
+			n:           2,
+		},
+		{
+			description: "counts higher than items, with null items",
+			body:        `{"toasts":{"count":25,"items":[{"like_id":1,"user":{"uid":1}},null]},"comments":{"count":25,"items":[{"comment_id":1,"user":{"uid":1}},null]}}`,
+			n:           1,
+		},
+	}
+
+	for _, tt := range tests {
+		var r rawCheckin
+		if err := json.Unmarshal([]byte(tt.body), &r); err != nil {
+			t.Fatalf("unexpected error for test %q: %v", tt.description, err)
+		}
+
+		c := r.export()
+
+		if l := len(c.Toasts); l != tt.n {
+			t.Fatalf("unexpected number of toasts for test %q: %d != %d", tt.description, l, tt.n)
+		}
+		for _, to := range c.Toasts {
+			if to == nil || to.ID == 0 {
+				t.Fatalf("unexpected toast for test %q: %+v", tt.description, to)
+			}
+		}
+
+		if l := len(c.Comments); l != tt.n {
+			t.Fatalf("unexpected number of comments for test %q: %d != %d", tt.description, l, tt.n)
+		}
+		for _, cm := range c.Comments {
+			if cm == nil || cm.ID == 0 {
+				t.Fatalf("unexpected comment for test %q: %+v", tt.description, cm)
+			}
+		}
+	}
+}
+
+// Test_rawCheckinMediaExportMediumImage verifies that the medium image of
+// checkin media is populated using either of its JSON keys.
+func Test_rawCheckinMediaExportMediumImage(t *testing.T) {
+	medium := "https://d1c8v1qci5en44.cloudfront.net/photo/2014_11_28/0a418014e88d2bc841b0f4f688714ce7_320x320.jpg"
+
+	var tests = []struct {
+		description string
+		body        []byte
+	}{
+		{
+			description: "photo_img_md",
+			body:        []byte(`{"photo_id":1,"photo":{"photo_img_md":"` + medium + `"}}`),
+		},
+		{
+			description: "photo_img_med",
+			body:        []byte(`{"photo_id":1,"photo":{"photo_img_med":"` + medium + `"}}`),
+		},
+	}
+
+	for _, tt := range tests {
+		var r rawCheckinMedia
+		if err := json.Unmarshal(tt.body, &r); err != nil {
+			t.Fatal(err)
+		}
+
+		if got := r.export().MediumImage.String(); got != medium {
+			t.Fatalf("unexpected MediumImage for test %q: %q != %q", tt.description, got, medium)
+		}
+	}
+}
+
+// TestDedupCheckins verifies that DedupCheckins removes checkins with
+// duplicate IDs, while preserving the order of the input checkins.
+func TestDedupCheckins(t *testing.T) {
+	tests := []struct {
+		desc     string
+		checkins []*Checkin
+		ids      []int64
+	}{
+		{
+			desc: "empty",
+		},
+		{
+			desc:     "no duplicates",
+			checkins: []*Checkin{{ID: 3}, {ID: 1}, {ID: 2}},
+			ids:      []int64{3, 1, 2},
+		},
+		{
+			desc: "duplicates interleaved",
+			checkins: []*Checkin{
+				{ID: 5}, {ID: 4}, {ID: 5}, nil, {ID: 3}, {ID: 4}, {ID: 2}, {ID: 3},
+			},
+			ids: []int64{5, 4, 3, 2},
+		},
+	}
+
+	for _, tt := range tests {
+		checkins := DedupCheckins(tt.checkins)
+
+		var ids []int64
+		for _, c := range checkins {
+			ids = append(ids, c.ID)
+		}
+
+		if !reflect.DeepEqual(ids, tt.ids) {
+			t.Fatalf("[%s] unexpected checkin IDs: %v != %v", tt.desc, ids, tt.ids)
+		}
+	}
+}
+
+// TestFilterWithMedia verifies that FilterWithMedia only returns checkins
+// which have at least one photo attached.
+func TestFilterWithMedia(t *testing.T) {
+	var r rawCheckin
+	if err := json.Unmarshal(checkinMediaJSON, &r); err != nil {
+		t.Fatal(err)
+	}
+
+	withMedia := r.export()
+	if got, want := len(withMedia.Media), 1; got != want {
+		t.Fatalf("unexpected number of Media: %d != %d", got, want)
+	}
+
+	m := withMedia.Media[0]
+	if got, want := m.ID, int64(24739915); got != want {
+		t.Fatalf("unexpected Media[0].ID: %d != %d", got, want)
+	}
+	for _, tt := range []struct {
+		u    url.URL
+		want string
+	}{
+		{u: m.SmallImage, want: "https://d1c8v1qci5en44.cloudfront.net/photo/2014_11_28/0a418014e88d2bc841b0f4f688714ce7_100x100.jpg"},
+		{u: m.MediumImage, want: "https://d1c8v1qci5en44.cloudfront.net/photo/2014_11_28/0a418014e88d2bc841b0f4f688714ce7_320x320.jpg"},
+		{u: m.LargeImage, want: "https://d1c8v1qci5en44.cloudfront.net/photo/2014_11_28/0a418014e88d2bc841b0f4f688714ce7_640x640.jpg"},
+		{u: m.OriginalImage, want: "https://d1c8v1qci5en44.cloudfront.net/photo/2014_11_28/0a418014e88d2bc841b0f4f688714ce7_raw.jpg"},
+	} {
+		if got := tt.u.String(); got != tt.want {
+			t.Fatalf("unexpected Media URL: %q != %q", got, tt.want)
+		}
+	}
+
+	withoutMedia := &Checkin{ID: 2}
+
+	checkins := FilterWithMedia([]*Checkin{withoutMedia, withMedia, withoutMedia})
+	if got, want := len(checkins), 1; got != want {
+		t.Fatalf("unexpected number of checkins: %d != %d", got, want)
+	}
+	if got, want := checkins[0].ID, withMedia.ID; got != want {
+		t.Fatalf("unexpected checkin ID: %d != %d", got, want)
+	}
+
+	if checkins := FilterWithMedia([]*Checkin{withoutMedia}); len(checkins) != 0 {
+		t.Fatalf("unexpected number of checkins: %d != 0", len(checkins))
+	}
+}
+
+// checkinMediaJSON is canned JSON for a single checkin with a photo attached.
+var checkinMediaJSON = []byte(`{
+  "checkin_id": 133319903,
+  "created_at": "Fri, 28 Nov 2014 22:21:05 +0000",
+  "media": {
+    "count": 1,
+    "items": [
+      {
+        "photo_id": 24739915,
+        "photo": {
+          "photo_img_sm": "https://d1c8v1qci5en44.cloudfront.net/photo/2014_11_28/0a418014e88d2bc841b0f4f688714ce7_100x100.jpg",
+          "photo_img_md": "https://d1c8v1qci5en44.cloudfront.net/photo/2014_11_28/0a418014e88d2bc841b0f4f688714ce7_320x320.jpg",
+          "photo_img_lg": "https://d1c8v1qci5en44.cloudfront.net/photo/2014_11_28/0a418014e88d2bc841b0f4f688714ce7_640x640.jpg",
+          "photo_img_og": "https://d1c8v1qci5en44.cloudfront.net/photo/2014_11_28/0a418014e88d2bc841b0f4f688714ce7_raw.jpg"
+        }
+      }
+    ]
+  }
+}`)
+
 // Canned checkins JSON response, taken from documentation: https://untappd.com/api/docs#useractivityfeed
 // All checkin responses are in this format, and it is used throughout various
 // Checkin method tests.
@@ -209,7 +586,7 @@ var userCheckinsJSON = []byte(`{
             }
           },
           "comments": {
-            "total_count": 0,
+            "total_count": 12,
             "count": 1,
             "items": [
               {
@@ -222,9 +599,9 @@ var userCheckinsJSON = []byte(`{
             ]
           },
           "toasts": {
-            "total_count": 0,
+            "total_count": 3,
             "count": 1,
-            "auth_toast": false,
+            "auth_toast": true,
             "items": [
               {
                 "like_id": 1,
@@ -264,3 +641,109 @@ var userCheckinsJSON = []byte(`{
     }
   }
 }`)
+
+// TestRoundToHalf verifies that RoundToHalf rounds ratings to the nearest 0.5.
+func TestRoundToHalf(t *testing.T) {
+	var tests = []struct {
+		in, out float64
+	}{
+		{in: 0, out: 0},
+		{in: 0.5, out: 0.5},
+		{in: 1.2, out: 1},
+		{in: 3.25, out: 3.5},
+		{in: 3.5, out: 3.5},
+		{in: 3.74, out: 3.5},
+		{in: 3.75, out: 4},
+		{in: 4.9, out: 5},
+		{in: 5, out: 5},
+	}
+
+	for _, tt := range tests {
+		if got := RoundToHalf(tt.in); got != tt.out {
+			t.Fatalf("unexpected RoundToHalf(%v): %v != %v", tt.in, got, tt.out)
+		}
+	}
+}
+
+// TestRatingStars verifies that Rating.Stars returns the expected number of
+// full and half stars for a variety of ratings, including unrated beers.
+func TestRatingStars(t *testing.T) {
+	var tests = []struct {
+		rating     Rating
+		full, half int
+	}{
+		{rating: 0},
+		{rating: -1},
+		{rating: Rating(math.NaN())},
+		{rating: 0.5, half: 1},
+		{rating: 1, full: 1},
+		{rating: 2.25, full: 2, half: 1},
+		{rating: 3.75, full: 4},
+		{rating: 4.5, full: 4, half: 1},
+		{rating: 5, full: 5},
+		{rating: 6, full: 5},
+	}
+
+	for _, tt := range tests {
+		full, half := tt.rating.Stars()
+		if full != tt.full || half != tt.half {
+			t.Fatalf("unexpected stars for rating %v: (%d, %d) != (%d, %d)",
+				tt.rating, full, half, tt.full, tt.half)
+		}
+	}
+}
+
+// TestRatingHistogram verifies that RatingHistogram counts the ratings of
+// rated checkins, including boundary ratings, and ignores unrated checkins.
+func TestRatingHistogram(t *testing.T) {
+	var tests = []struct {
+		description string
+		ratings     []float64
+		h           map[float64]int
+	}{
+		{
+			description: "no checkins",
+			h:           map[float64]int{},
+		},
+		{
+			description: "unrated checkins",
+			ratings:     []float64{0, 0},
+			h:           map[float64]int{},
+		},
+		{
+			description: "boundary ratings",
+			ratings:     []float64{0.5, 5, 5, 0},
+			h: map[float64]int{
+				0.5: 1,
+				5:   2,
+			},
+		},
+		{
+			description: "mixed ratings",
+			ratings:     []float64{3.5, 3.75, 3.5, 4, 0, 3.5},
+			h: map[float64]int{
+				3.5:  3,
+				3.75: 1,
+				4:    1,
+			},
+		},
+		{
+			description: "non-step and out of range ratings",
+			ratings:     []float64{3.3, 3.25, 0.1, 5.5, -1},
+			h: map[float64]int{
+				3.25: 2,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		checkins := []*Checkin{nil}
+		for _, r := range tt.ratings {
+			checkins = append(checkins, &Checkin{UserRating: r})
+		}
+
+		if h := RatingHistogram(checkins); !reflect.DeepEqual(h, tt.h) {
+			t.Fatalf("unexpected histogram for test %q: %v != %v", tt.description, h, tt.h)
+		}
+	}
+}
@@ -0,0 +1,29 @@
+package untappd
+
+import (
+	"net/http"
+	"strconv"
+)
+
+// Checkin queries for information about a single Checkin with the specified
+// ID.  Unlike the checkins returned by activity feeds, the returned Checkin
+// contains all of its comments, toasts, badges, and media.
+//
+// Checkin is useful for displaying a checkin linked from a notification,
+// without needing to search an activity feed for it.
+func (c *Client) Checkin(id int64) (*Checkin, *http.Response, error) {
+	// Temporary struct to unmarshal raw checkin JSON
+	var v struct {
+		Response struct {
+			Checkin rawCheckin `json:"checkin"`
+		} `json:"response"`
+	}
+
+	// Perform request for checkin by ID
+	res, err := c.request("GET", "checkin/view/"+strconv.FormatInt(id, 10), nil, nil, &v)
+	if err != nil {
+		return nil, res, err
+	}
+
+	return v.Response.Checkin.export(), res, nil
+}
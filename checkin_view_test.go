@@ -0,0 +1,193 @@
+package untappd
+
+import (
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+)
+
+// TestClientCheckinBadCheckin verifies that Client.Checkin returns an error
+// when an invalid checkin is queried.
+func TestClientCheckinBadCheckin(t *testing.T) {
+	c, done := checkinViewTestClient(t, func(t *testing.T, w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write(invalidCheckinIDErrJSON)
+	})
+	defer done()
+
+	_, _, err := c.Checkin(1)
+	uErr := assertInvalidCommonErr(t, err)
+
+	if d, want := uErr.Detail, "Invalid checkin ID."; d != want {
+		t.Fatalf("unexpected error detail: %q != %q", d, want)
+	}
+}
+
+// TestClientCheckinOK verifies that Client.Checkin returns a valid checkin,
+// including its comments, toasts, and media.
+func TestClientCheckinOK(t *testing.T) {
+	id := int64(137117722)
+	sID := strconv.FormatInt(id, 10)
+
+	c, done := checkinViewTestClient(t, func(t *testing.T, w http.ResponseWriter, r *http.Request) {
+		path := "/v4/checkin/view/" + sID + "/"
+		if p := r.URL.Path; p != path {
+			t.Fatalf("unexpected URL path: %q != %q", p, path)
+		}
+
+		w.Write(checkinViewJSON)
+	})
+	defer done()
+
+	checkin, _, err := c.Checkin(id)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if checkin.ID != id {
+		t.Fatalf("unexpected checkin ID: %d != %d", checkin.ID, id)
+	}
+	if s, want := checkin.Comment, "When in Rome.."; s != want {
+		t.Fatalf("unexpected checkin Comment: %q != %q", s, want)
+	}
+	if s, want := checkin.Beer.Name, "Brooklyn Bowl Pale Ale"; s != want {
+		t.Fatalf("unexpected checkin Beer.Name: %q != %q", s, want)
+	}
+	if s, want := checkin.User.UserName, "gregavola"; s != want {
+		t.Fatalf("unexpected checkin User.UserName: %q != %q", s, want)
+	}
+
+	if l := len(checkin.Comments); l != 1 {
+		t.Fatalf("unexpected number of comments: %d != %d", l, 1)
+	}
+	if s, want := checkin.Comments[0].Comment, "Cheers!"; s != want {
+		t.Fatalf("unexpected comment: %q != %q", s, want)
+	}
+
+	if l := len(checkin.Toasts); l != 2 {
+		t.Fatalf("unexpected number of toasts: %d != %d", l, 2)
+	}
+	if c, want := checkin.Toasts[0].Created, time.Date(2014, time.December, 6, 2, 5, 0, 0, time.UTC); !c.Equal(want) {
+		t.Fatalf("unexpected toast Created: %v != %v", c, want)
+	}
+	if u := checkin.Toasts[0].User; u == nil || u.UID != 2 {
+		t.Fatalf("unexpected toast User: %+v", u)
+	}
+	if n, want := checkin.ToastCount, 2; n != want {
+		t.Fatalf("unexpected toast count: %d != %d", n, want)
+	}
+
+	if l := len(checkin.Media); l != 1 {
+		t.Fatalf("unexpected number of media: %d != %d", l, 1)
+	}
+}
+
+// checkinViewTestClient builds upon testClient, and adds additional sanity checks
+// for tests which target the checkin view API.
+func checkinViewTestClient(t *testing.T, fn func(t *testing.T, w http.ResponseWriter, r *http.Request)) (*Client, func()) {
+	return testClient(t, func(t *testing.T, w http.ResponseWriter, r *http.Request) {
+		// Always GET request
+		method := "GET"
+		if m := r.Method; m != method {
+			t.Fatalf("unexpected HTTP method: %q != %q", m, method)
+		}
+
+		// Guard against panics
+		if fn != nil {
+			fn(t, w, r)
+		}
+	})
+}
+
+// Canned JSON used in tests
+var invalidCheckinIDErrJSON = []byte(`{"meta":{"code":500,"error_detail":"Invalid checkin ID.","error_type":"invalid_param","response_time":{"time":0,"measure":"seconds"}}}`)
+
+// Canned single checkin JSON response, modeled after documentation:
+// https://untappd.com/api/docs#checkininfo
+var checkinViewJSON = []byte(`{
+  "meta": {
+    "code": 200,
+    "response_time": {
+      "time": 0.1,
+      "measure": "seconds"
+    }
+  },
+  "notifications": [],
+  "response": {
+    "checkin": {
+      "checkin_id": 137117722,
+      "created_at": "Sat, 06 Dec 2014 02:02:32 +0000",
+      "checkin_comment": "When in Rome..",
+      "rating_score": 3.5,
+      "user": {
+        "uid": 1,
+        "user_name": "gregavola"
+      },
+      "beer": {
+        "bid": 3839,
+        "beer_name": "Brooklyn Bowl Pale Ale"
+      },
+      "brewery": {
+        "brewery_id": 259,
+        "brewery_name": "Kelso of Brooklyn"
+      },
+      "venue": [],
+      "comments": {
+        "total_count": 1,
+        "count": 1,
+        "items": [
+          {
+            "comment_id": 1,
+            "checkin_id": 137117722,
+            "comment": "Cheers!",
+            "created_at": "Sat, 06 Dec 2014 02:10:00 +0000",
+            "user": {
+              "uid": 2,
+              "user_name": "jdoe"
+            }
+          }
+        ]
+      },
+      "toasts": {
+        "total_count": 2,
+        "count": 2,
+        "auth_toast": false,
+        "items": [
+          {
+            "like_id": 1,
+            "uid": 2,
+            "created_at": "Sat, 06 Dec 2014 02:05:00 +0000",
+            "user": {
+              "uid": 2,
+              "user_name": "jdoe"
+            }
+          },
+          {
+            "like_id": 2,
+            "uid": 3,
+            "created_at": "Sat, 06 Dec 2014 02:06:00 +0000",
+            "user": {
+              "uid": 3,
+              "user_name": "asmith"
+            }
+          }
+        ]
+      },
+      "media": {
+        "count": 1,
+        "items": [
+          {
+            "photo_id": 24739915,
+            "photo": {
+              "photo_img_sm": "https://untappd.akamaized.net/photo/1_sm.jpg",
+              "photo_img_md": "https://untappd.akamaized.net/photo/1_md.jpg",
+              "photo_img_lg": "https://untappd.akamaized.net/photo/1_lg.jpg",
+              "photo_img_og": "https://untappd.akamaized.net/photo/1_og.jpg"
+            }
+          }
+        ]
+      }
+    }
+  }
+}`)
@@ -11,13 +11,21 @@ package untappd
 
 import (
 	"bytes"
+	"compress/gzip"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
+	"io/ioutil"
+	"log"
+	"math"
 	"net/http"
 	"net/url"
+	"reflect"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 )
 
@@ -32,6 +40,35 @@ const (
 	// untappdUserAgent is the default user agent this package will report to
 	// the Untappd APIv4.
 	untappdUserAgent = "github.com/mdlayher/untappd"
+
+	// defaultConcurrency is the default maximum number of concurrent HTTP
+	// requests performed by methods which issue several requests at once.
+	defaultConcurrency = 4
+
+	// defaultLimit is the default number of results returned by methods
+	// which do not accept a limit parameter.
+	defaultLimit = 25
+
+	// defaultTimeout is the default timeout for HTTP requests performed
+	// by a Client which was not provided a custom http.Client.
+	defaultTimeout = 30 * time.Second
+
+	// defaultMaxResponseBytes is the default maximum size of a response
+	// body which will be read from the Untappd APIv4.
+	defaultMaxResponseBytes = 4 << 20
+
+	// privateUserErrorType is the error type returned by the Untappd APIv4
+	// when a private User's information is queried.
+	privateUserErrorType = "invalid_user_private"
+
+	// invalidAuthErrorType is the error type returned by the Untappd APIv4
+	// when a request's credentials are rejected.
+	invalidAuthErrorType = "invalid_auth"
+
+	// socialNotLinkedErrorType is the error type returned by the Untappd
+	// APIv4 when a checkin requests sharing to a social network which the
+	// authenticated user has not linked to their account.
+	socialNotLinkedErrorType = "invalid_social_account"
 )
 
 var (
@@ -45,20 +82,65 @@ var (
 	// ErrNoClientSecret is returned when an empty Client Secret is passed
 	// to NewClient.
 	ErrNoClientSecret = errors.New("no client secret")
+
+	// ErrPrivateUser is matched by an *Error, using errors.Is, when the
+	// Untappd APIv4 refuses to return information about a User because
+	// their account is private.
+	ErrPrivateUser = errors.New("user account is private")
+
+	// ErrResponseTooLarge is returned when a response body from the Untappd
+	// APIv4 exceeds the maximum size configured using WithMaxResponseBytes.
+	ErrResponseTooLarge = errors.New("response body too large")
 )
 
 // Client is a HTTP client for the Untappd APIv4.  It enables access to various
 // methods of the Untappd APIv4.
+//
+// A Client is safe for concurrent use by multiple goroutines, provided its
+// exported fields are not modified after it is first used.  State updated by
+// the Client itself, such as the rate limit reported by LastRateLimit, is
+// guarded internally.
 type Client struct {
 	UserAgent string
 
-	client *http.Client
-	url    *url.URL
+	// Concurrency is the maximum number of concurrent HTTP requests which
+	// may be performed by methods which issue several requests at once,
+	// such as Beer.InfoMulti.  If zero, defaultConcurrency is used.
+	Concurrency int
+
+	// DefaultLimit is the number of results requested by methods which do
+	// not accept a limit parameter, such as User.Beers.  If zero,
+	// defaultLimit is used.  If DefaultLimit exceeds the maximum number of
+	// results allowed by an endpoint, that maximum is used instead.
+	DefaultLimit int
+
+	client    *http.Client
+	transport http.RoundTripper
+	noCreds   bool
+	url       *url.URL
+	timeout   time.Duration
+	maxBytes  int64
+	strict    bool
+	logger    *log.Logger
 
 	clientID     string
 	clientSecret string
 
-	accessToken string
+	// If configured by WithFallbackToPublic, a client which authenticates
+	// using a client ID and client secret, used when an access token is
+	// rejected
+	publicClient       *http.Client
+	publicClientID     string
+	publicClientSecret string
+
+	checkinKeys checkinKeys
+
+	// mu guards mutable state which is updated as requests complete
+	mu           sync.RWMutex
+	rateLimit    RateLimit
+	hasRateLimit bool
+	meta         Meta
+	hasMeta      bool
 
 	// Methods which require authentication
 	Auth interface {
@@ -67,31 +149,48 @@ type Client struct {
 
 		// https://untappd.com/api/docs#activityfeed
 		Checkins() ([]*Checkin, *http.Response, error)
-		CheckinsMinMaxIDLimit(minID int, maxID int, limit int) ([]*Checkin, *http.Response, error)
+		CheckinsMinMaxIDLimit(minID int64, maxID int64, limit int) ([]*Checkin, *http.Response, error)
+
+		// Removes a checkin made by the authenticated user
+		DeleteCheckin(checkinID int64) (*http.Response, error)
+
+		// https://untappd.com/api/docs#notifications
+		Notifications() (*Notifications, *http.Response, error)
+
+		// https://untappd.com/api/docs#toast
+		Toast(checkinID int64) (*ToastResult, *http.Response, error)
+
+		// https://untappd.com/api/docs#addwish
+		// https://untappd.com/api/docs#removewish
+		WishListToggle(beerID int64) (bool, *http.Response, error)
 	}
 
 	// Methods involving a Beer
 	Beer interface {
 		// https://untappd.com/api/docs#beeractivityfeed
-		Checkins(id int) ([]*Checkin, *http.Response, error)
-		CheckinsMinMaxIDLimit(id int, minID int, maxID int, limit int) ([]*Checkin, *http.Response, error)
+		Checkins(id int64) ([]*Checkin, *http.Response, error)
+		CheckinsMinMaxIDLimit(id int64, minID int64, maxID int64, limit int) ([]*Checkin, *http.Response, error)
+		CheckinsByUser(id int64, username string, minID int64, maxID int64, limit int) ([]*Checkin, *http.Response, error)
 
 		// https://untappd.com/api/docs#beerinfo
-		Info(id int, compact bool) (*Beer, *http.Response, error)
+		Info(id int64, compact bool) (*Beer, *http.Response, error)
+		InfoMulti(ctx context.Context, ids []int64, compact bool) ([]*Beer, error)
 
 		// https://untappd.com/api/docs#beersearch
 		Search(query string) ([]*Beer, *http.Response, error)
 		SearchOffsetLimitSort(query string, offset int, limit int, sort Sort) ([]*Beer, *http.Response, error)
+		SearchForUser(query string, username string) ([]*Beer, *http.Response, error)
 	}
 
 	// Methods involving a Brewery
 	Brewery interface {
 		// https://untappd.com/api/docs#breweryactivityfeed
-		Checkins(id int) ([]*Checkin, *http.Response, error)
-		CheckinsMinMaxIDLimit(id int, minID int, maxID int, limit int) ([]*Checkin, *http.Response, error)
+		Checkins(id int64) ([]*Checkin, *http.Response, error)
+		CheckinsMinMaxIDLimit(id int64, minID int64, maxID int64, limit int) ([]*Checkin, *http.Response, error)
 
 		// https://untappd.com/api/docs#breweryinfo
-		Info(id int, compact bool) (*Brewery, *http.Response, error)
+		Info(id int64, compact bool) (*Brewery, *http.Response, error)
+		InfoBeers(id int64, offset int, limit int) (*Brewery, *http.Response, error)
 
 		// https://untappd.com/api/docs#brewerysearch
 		Search(query string) ([]*Brewery, *http.Response, error)
@@ -102,7 +201,9 @@ type Client struct {
 	Local interface {
 		// https://untappd.com/api/docs#theppublocal
 		Checkins(latitude float64, longitude float64) ([]*Checkin, *http.Response, error)
+		CheckinsInBounds(minLat float64, minLng float64, maxLat float64, maxLng float64, units Distance) ([]*Checkin, *http.Response, error)
 		CheckinsMinMaxIDLimitRadius(r LocalCheckinsRequest) ([]*Checkin, *http.Response, error)
+		CheckinsNearVenue(venueID int64, radius int, units Distance) ([]*Checkin, *http.Response, error)
 	}
 
 	// Methods involving a User
@@ -117,7 +218,9 @@ type Client struct {
 
 		// https://untappd.com/api/docs#useractivityfeed
 		Checkins(username string) ([]*Checkin, *http.Response, error)
-		CheckinsMinMaxIDLimit(username string, minID int, maxID int, limit int) ([]*Checkin, *http.Response, error)
+		CheckinsMinMaxIDLimit(username string, minID int64, maxID int64, limit int) ([]*Checkin, *http.Response, error)
+		CheckinsSince(username string, sinceID int64, limit int) ([]*Checkin, *http.Response, error)
+		CheckinsOffsetLimit(username string, offset int, limit int) ([]*Checkin, *http.Response, error)
 
 		// https://untappd.com/api/docs#userfriends
 		Friends(username string) ([]*User, *http.Response, error)
@@ -126,6 +229,9 @@ type Client struct {
 		// https://untappd.com/api/docs#userinfo
 		Info(username string, compact bool) (*User, *http.Response, error)
 
+		// Combines several requests for information about a User
+		Profile(ctx context.Context, username string) (*UserProfile, error)
+
 		// https://untappd.com/api/docs#userwishlist
 		WishList(username string) ([]*Beer, *http.Response, error)
 		WishListOffsetLimitSort(username string, offset int, limit int, sort Sort) ([]*Beer, *http.Response, error)
@@ -134,11 +240,148 @@ type Client struct {
 	// Methods involving a Venue
 	Venue interface {
 		// https://untappd.com/api/docs#venueactivityfeed
-		Checkins(id int) ([]*Checkin, *http.Response, error)
-		CheckinsMinMaxIDLimit(id int, minID int, maxID int, limit int) ([]*Checkin, *http.Response, error)
+		Checkins(id int64) ([]*Checkin, *http.Response, error)
+		CheckinsMinMaxIDLimit(id int64, minID int64, maxID int64, limit int) ([]*Checkin, *http.Response, error)
 
 		// https://untappd.com/api/docs#venueinfo
-		Info(id int, compact bool) (*Venue, *http.Response, error)
+		Info(id int64, compact bool) (*Venue, *http.Response, error)
+		InfoTopBeers(id int64, compact bool, offset int, limit int) (*Venue, *http.Response, error)
+
+		// Streams all of a Venue's checkins
+		CheckinsEach(ctx context.Context, id int64, fn func(c *Checkin) error) error
+	}
+}
+
+// A ClientOption is an option which can be used to configure a Client using
+// NewClient or NewAuthenticatedClient.
+type ClientOption func(c *Client)
+
+// WithTimeout sets the maximum amount of time a Client will wait for each
+// request to the Untappd APIv4 to complete.  If no WithTimeout option is
+// provided, a default timeout of 30 seconds is used.  A zero or negative
+// duration disables the timeout, so that requests wait indefinitely unless
+// their context is canceled.
+//
+// The timeout only applies to requests which do not already have a context
+// deadline, and only when no custom http.Client was provided to NewClient
+// or NewAuthenticatedClient.  Supplying a custom http.Client opts out of
+// this timeout entirely; configure the timeout on that http.Client instead.
+func WithTimeout(d time.Duration) ClientOption {
+	return func(c *Client) {
+		c.timeout = d
+	}
+}
+
+// WithMaxResponseBytes sets the maximum size of a response body which a
+// Client will read from the Untappd APIv4.  If a response body exceeds n
+// bytes, ErrResponseTooLarge is returned.  If no WithMaxResponseBytes option
+// is provided, a default maximum of 4MB is used.  If n is zero or negative,
+// response bodies of any size are read.
+func WithMaxResponseBytes(n int64) ClientOption {
+	return func(c *Client) {
+		c.maxBytes = n
+	}
+}
+
+// WithUserAgent adds a product token, such as "myapp/1.2", to the User-Agent
+// header reported by a Client to the Untappd APIv4.  The token is prepended to
+// the existing user agent, so that the library is still identified, producing
+// a header such as "myapp/1.2 github.com/mdlayher/untappd".
+//
+// To replace the user agent entirely, set Client.UserAgent instead.
+func WithUserAgent(product string) ClientOption {
+	return func(c *Client) {
+		c.UserAgent = joinNonEmpty(" ", strings.TrimSpace(product), c.UserAgent)
+	}
+}
+
+// WithTransport sets the http.RoundTripper used by a Client to perform
+// requests to the Untappd APIv4, such as one which routes requests through
+// a proxy or signs them.  The Client's credentials are added to each request
+// before it is passed to rt.
+//
+// If a custom http.Client was provided to NewClient or NewAuthenticatedClient,
+// rt is used in place of its Transport.  The custom http.Client itself is
+// not modified.
+func WithTransport(rt http.RoundTripper) ClientOption {
+	return func(c *Client) {
+		c.transport = rt
+	}
+}
+
+// WithNoAutoCredentials disables the addition of a Client's access token, or
+// client ID and client secret, to the query string of each request.  This is
+// useful when requests are routed through a gateway or transport, such as one
+// set by WithTransport, which adds credentials itself.
+//
+// When this option is used, the Client performs no authentication of its own,
+// and the credentials passed to NewClient or NewAuthenticatedClient are never
+// sent.  Those parameters must still be non-empty, but placeholder values may
+// be used.  Ensure that the gateway or transport authenticates each request
+// and that it only sends credentials to the Untappd APIv4, since requests are
+// otherwise unauthenticated.
+func WithNoAutoCredentials() ClientOption {
+	return func(c *Client) {
+		c.noCreds = true
+	}
+}
+
+// WithFallbackToPublic configures a Client created by NewAuthenticatedClient
+// to retry a GET request once, using the specified client ID and client
+// secret instead of its access token, when the Untappd APIv4 rejects the
+// access token with an invalid_auth error.  This keeps read-only
+// applications working with public endpoints after an access token expires.
+//
+// Requests which are retried are performed without the authenticated user's
+// identity, so their results may differ; for example, fields which describe
+// the authenticated user are not populated.  Because the Untappd APIv4 also
+// reports some other errors as invalid_auth, those requests are retried as
+// well, and fail again with the same error.
+//
+// If either clientID or clientSecret is empty, or if WithNoAutoCredentials
+// is used, requests are never retried.
+func WithFallbackToPublic(clientID string, clientSecret string) ClientOption {
+	return func(c *Client) {
+		c.publicClientID = clientID
+		c.publicClientSecret = clientSecret
+	}
+}
+
+// WithLogger configures a Client to log notable events which occur while
+// preparing its requests, such as adjustments made to request parameters
+// which exceed the limits of the Untappd APIv4, using logger.  By default,
+// or if logger is nil, nothing is logged.
+func WithLogger(logger *log.Logger) ClientOption {
+	return func(c *Client) {
+		c.logger = logger
+	}
+}
+
+// logf logs a message using the Client's logger, if one is configured by
+// WithLogger.
+func (c *Client) logf(format string, v ...interface{}) {
+	if c.logger == nil {
+		return
+	}
+
+	c.logger.Printf(format, v...)
+}
+
+// WithStrictDecoding configures a Client to return an error when a response
+// from the Untappd APIv4 contains a field which the Client does not decode,
+// rather than silently ignoring the field.  By default, unknown fields are
+// ignored.
+//
+// Strict decoding is intended for development and debugging, so that
+// changes to the Untappd APIv4 can be spotted quickly; it should not be used
+// in production, because new fields added by the Untappd APIv4 will cause
+// requests to fail.  The meta and notifications members of each response
+// are not checked, unless the value being decoded declares them, nor are
+// fields decoded by custom unmarshalers, such as those which handle values
+// of varying types.
+func WithStrictDecoding() ClientOption {
+	return func(c *Client) {
+		c.strict = true
 	}
 }
 
@@ -147,7 +390,9 @@ type Client struct {
 //
 // To use a Client with the Untappd APIv4, you must register for an API key
 // here: https://untappd.com/api/register.
-func NewClient(clientID string, clientSecret string, client *http.Client) (*Client, error) {
+//
+// Zero or more ClientOptions may be provided to further configure the Client.
+func NewClient(clientID string, clientSecret string, client *http.Client, options ...ClientOption) (*Client, error) {
 	// Disallow empty ID and secret
 	if clientID == "" {
 		return nil, ErrNoClientID
@@ -157,7 +402,7 @@ func NewClient(clientID string, clientSecret string, client *http.Client) (*Clie
 	}
 
 	// Perform common client setup
-	return newClient(clientID, clientSecret, "", client)
+	return newClient(clientID, clientSecret, "", client, options)
 }
 
 // NewAuthenticatedClient creates a properly initialized and authenticated instance
@@ -172,24 +417,21 @@ func NewClient(clientID string, clientSecret string, client *http.Client) (*Clie
 // the OAuth Authentication procedure documented here:
 // https://untappd.com/api/docs#authentication.  Upon successful OAuth Authentication,
 // you will receive an access token which can be used with NewAuthenticatedClient.
-func NewAuthenticatedClient(accessToken string, client *http.Client) (*Client, error) {
+//
+// Zero or more ClientOptions may be provided to further configure the Client.
+func NewAuthenticatedClient(accessToken string, client *http.Client, options ...ClientOption) (*Client, error) {
 	// Disallow empty access token
 	if accessToken == "" {
 		return nil, ErrNoAccessToken
 	}
 
 	// Perform common client setup
-	return newClient("", "", accessToken, client)
+	return newClient("", "", accessToken, client, options)
 }
 
 // newClient handles common setup logic for a Client for NewClient and
 // NewAuthenticatedClient.
-func newClient(clientID string, clientSecret string, accessToken string, client *http.Client) (*Client, error) {
-	// If input client is nil, use http.DefaultClient
-	if client == nil {
-		client = http.DefaultClient
-	}
-
+func newClient(clientID string, clientSecret string, accessToken string, client *http.Client, options []ClientOption) (*Client, error) {
 	// Set up basic client
 	c := &Client{
 		UserAgent: untappdUserAgent,
@@ -204,7 +446,8 @@ func newClient(clientID string, clientSecret string, accessToken string, client
 		clientID:     clientID,
 		clientSecret: clientSecret,
 
-		accessToken: accessToken,
+		timeout:  defaultTimeout,
+		maxBytes: defaultMaxResponseBytes,
 	}
 
 	// Add "services" which allow access to various API methods
@@ -215,9 +458,103 @@ func newClient(clientID string, clientSecret string, accessToken string, client
 	c.Venue = &VenueService{client: c}
 	c.Local = &LocalService{client: c}
 
+	for _, o := range options {
+		o(c)
+	}
+
+	// If input client is nil, use http.DefaultClient, and apply a timeout
+	// to each request, unless it was disabled.  Custom clients are expected
+	// to manage their own timeouts, so no timeout is applied for them.
+	if client == nil {
+		client = http.DefaultClient
+		if c.timeout < 0 {
+			c.timeout = 0
+		}
+	} else {
+		c.timeout = 0
+	}
+
+	// Credentials are applied by wrapping the transport of a copy of the
+	// input client, so that the input client is never modified
+	rt := client.Transport
+	if c.transport != nil {
+		rt = c.transport
+	}
+
+	hc := *client
+	hc.Transport = rt
+	if !c.noCreds {
+		hc.Transport = &AuthTransport{
+			AccessToken:  accessToken,
+			ClientID:     clientID,
+			ClientSecret: clientSecret,
+			Host:         c.url.Host,
+			Transport:    rt,
+		}
+	}
+	c.client = &hc
+
+	// Only clients which use an access token need to fall back to the
+	// client ID and client secret
+	if accessToken != "" && !c.noCreds && c.publicClientID != "" && c.publicClientSecret != "" {
+		pc := *client
+		pc.Transport = &AuthTransport{
+			ClientID:     c.publicClientID,
+			ClientSecret: c.publicClientSecret,
+			Host:         c.url.Host,
+			Transport:    rt,
+		}
+		c.publicClient = &pc
+	}
+
 	return c, nil
 }
 
+// SetAccessToken replaces the access token used to authenticate requests
+// performed by the Client, so that a token can be rotated without creating
+// a new Client.  Requests which are already in progress continue to use the
+// previous token.  If token is empty, the Client's client ID and client
+// secret are used instead.  A Client created by NewAuthenticatedClient has
+// no client ID or client secret, so ErrNoAccessToken is returned if token is
+// empty, and the previous token remains in use.
+//
+// SetAccessToken is safe to call while other goroutines perform requests
+// using the same Client.
+func (c *Client) SetAccessToken(token string) error {
+	if token == "" && (c.clientID == "" || c.clientSecret == "") {
+		return ErrNoAccessToken
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	// If WithNoAutoCredentials was used, no credentials are added to
+	// requests, so there is nothing more to update
+	at, ok := c.client.Transport.(*AuthTransport)
+	if !ok {
+		return nil
+	}
+
+	// Swap in a copy of the HTTP client and its credentials, so requests
+	// which already hold the previous client are unaffected
+	hc := *c.client
+	nat := *at
+	nat.AccessToken = token
+	hc.Transport = &nat
+
+	c.client = &hc
+
+	return nil
+}
+
+// httpClient returns the *http.Client used to perform requests.
+func (c *Client) httpClient() *http.Client {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.client
+}
+
 // Error represents an error returned from the Untappd APIv4.
 type Error struct {
 	Code              int
@@ -239,10 +576,122 @@ func (e Error) Error() string {
 	return fmt.Sprintf("%d [%s]: %s", e.Code, e.Type, details)
 }
 
+// Is reports whether an Error matches target, so that errors.Is can be used
+// to distinguish certain types of Error from one another.  An Error
+// matches ErrPrivateUser when a private User's information was queried, and
+// ErrSocialNotLinked when a checkin was shared to an unlinked social network.
+func (e Error) Is(target error) bool {
+	switch target {
+	case ErrPrivateUser:
+		return e.Type == privateUserErrorType
+	case ErrSocialNotLinked:
+		return e.Type == socialNotLinkedErrorType
+	default:
+		return false
+	}
+}
+
+// MultiError is an error which aggregates the errors from several requests
+// performed at once, such as by User.Profile.  errors.Is and errors.As may
+// be used to match any of its errors.
+type MultiError []error
+
+// Error returns the string representation of a MultiError, containing a
+// summary line followed by each of its errors on a separate line.
+func (m MultiError) Error() string {
+	if len(m) == 1 {
+		return m[0].Error()
+	}
+
+	ss := make([]string, 0, len(m)+1)
+	ss = append(ss, fmt.Sprintf("%d errors occurred:", len(m)))
+	for _, err := range m {
+		ss = append(ss, err.Error())
+	}
+
+	return strings.Join(ss, "\n")
+}
+
+// Unwrap returns the errors contained in a MultiError, so that errors.Is
+// and errors.As can inspect each of them.
+func (m MultiError) Unwrap() []error {
+	return m
+}
+
+// Get performs a GET request against an arbitrary Untappd APIv4 endpoint,
+// such as "beer/info/1", and decodes the entire JSON response into v.
+// Client credentials are applied and API errors are returned as *Error,
+// just as with the methods exposed by the Client's services.
+//
+// Get is intended for use with endpoints which are not yet wrapped by
+// this package.  If v is nil, the response body is not decoded, but may
+// be read from the returned *http.Response.  With WithStrictDecoding, the
+// meta and notifications members are still decoded if v declares them.
+func (c *Client) Get(ctx context.Context, endpoint string, query url.Values, v interface{}) (*http.Response, error) {
+	return c.requestContext(ctx, "GET", strings.Trim(endpoint, "/"), nil, query, v)
+}
+
+// Post is the same as Get, but performs a POST request, sending the
+// contents of body as a form-encoded request body.
+func (c *Client) Post(ctx context.Context, endpoint string, body url.Values, v interface{}) (*http.Response, error) {
+	return c.requestContext(ctx, "POST", strings.Trim(endpoint, "/"), body, nil, v)
+}
+
+// Ping performs a minimal request to the Untappd APIv4, to verify that the
+// API is reachable and that the Client's credentials are accepted.  Ping
+// returns nil on success, an *Error if the API rejects the request, such as
+// when an access token is invalid, or any error which occurred while
+// performing the request.
+func (c *Client) Ping(ctx context.Context) error {
+	// Query for compact information about a well-known beer, which is
+	// available using either set of credentials
+	_, err := c.requestContext(ctx, "GET", "beer/info/1", nil, url.Values{
+		"compact": []string{"true"},
+	}, nil)
+	return err
+}
+
 // request creates a new HTTP request, using the specified HTTP method and API endpoint.
 // Additionally, it accepts POST body parameters, GET query parameters, and an
 // optional struct which can be used to unmarshal result JSON.
 func (c *Client) request(method string, endpoint string, body url.Values, query url.Values, v interface{}) (*http.Response, error) {
+	return c.requestContext(context.Background(), method, endpoint, body, query, v)
+}
+
+// requestContext is the same as request, but the HTTP request is bound
+// to the input context.
+func (c *Client) requestContext(ctx context.Context, method string, endpoint string, body url.Values, query url.Values, v interface{}) (*http.Response, error) {
+	// Apply the Client's timeout, unless the caller already set a deadline
+	if _, ok := ctx.Deadline(); !ok && c.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.timeout)
+		defer cancel()
+	}
+
+	res, err := c.do(ctx, c.httpClient(), method, endpoint, body, query, v)
+	if err != nil && c.shouldFallback(method, err) {
+		// The access token was rejected, so try once more without it
+		return c.do(ctx, c.publicClient, method, endpoint, body, query, v)
+	}
+
+	return res, err
+}
+
+// shouldFallback determines if a request which failed with err should be
+// retried using the Client's client ID and client secret, as configured by
+// WithFallbackToPublic.
+func (c *Client) shouldFallback(method string, err error) bool {
+	if c.publicClient == nil || method != http.MethodGet {
+		return false
+	}
+
+	uErr, ok := err.(*Error)
+	return ok && uErr.Type == invalidAuthErrorType
+}
+
+// do performs a single HTTP request using hc, and is the backing method for
+// requestContext.
+func (c *Client) do(ctx context.Context, hc *http.Client, method string, endpoint string, body url.Values, query url.Values, v interface{}) (*http.Response, error) {
 	// Generate relative URL using API root and endpoint
 	rel, err := url.Parse(fmt.Sprintf("%s/%s/", c.url.Path, endpoint))
 	if err != nil {
@@ -259,16 +708,6 @@ func (c *Client) request(method string, endpoint string, body url.Values, query
 			q.Add(k, vv)
 		}
 	}
-
-	// Always prefer authenticated client access, using an access token.
-	// If no token is found, fall back to unauthenticated client ID and
-	// client secret.
-	if c.accessToken != "" {
-		q.Set("access_token", c.accessToken)
-	} else {
-		q.Set("client_id", c.clientID)
-		q.Set("client_secret", c.clientSecret)
-	}
 	u.RawQuery = q.Encode()
 
 	// Determine if request will contain a POST body
@@ -285,13 +724,15 @@ func (c *Client) request(method string, endpoint string, body url.Values, query
 	}
 
 	// Generate new HTTP request for appropriate URL
-	req, err := http.NewRequest(method, u.String(), buf)
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), buf)
 	if err != nil {
 		return nil, err
 	}
 
-	// Set headers to indicate proper content type
+	// Set headers to indicate proper content type, and that compressed
+	// responses are acceptable
 	req.Header.Add("Accept", jsonContentType)
+	req.Header.Add("Accept-Encoding", "gzip")
 
 	// For POST requests, add proper headers
 	if hasBody {
@@ -303,14 +744,44 @@ func (c *Client) request(method string, endpoint string, body url.Values, query
 	req.Header.Add("User-Agent", c.UserAgent)
 
 	// Invoke request using underlying HTTP client
-	res, err := c.client.Do(req)
+	res, err := hc.Do(req)
 	if err != nil {
 		return nil, err
 	}
 	defer res.Body.Close()
 
-	// Check response for errors
-	if err := checkResponse(res); err != nil {
+	// Record the most recently reported rate limit, even for error responses
+	c.updateRateLimit(res)
+
+	// Decompress response body, if needed
+	if err := decompress(res); err != nil {
+		return res, err
+	}
+
+	// Guard against unexpectedly large response bodies
+	if c.maxBytes > 0 {
+		res.Body = &limitedBody{
+			ReadCloser: res.Body,
+			n:          c.maxBytes,
+		}
+	}
+
+	// Read the entire response body once, so that it can be shared by
+	// both the error and success paths, and so that the body remains
+	// readable by the caller after the request is complete
+	resBody, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return res, err
+	}
+	res.Body = ioutil.NopCloser(bytes.NewReader(resBody))
+
+	// Record the most recently reported response metadata
+	c.updateMeta(res, resBody)
+
+	// Check response for errors, and then rewind the body for the caller
+	err = checkResponse(res)
+	res.Body = ioutil.NopCloser(bytes.NewReader(resBody))
+	if err != nil {
 		return res, err
 	}
 
@@ -320,13 +791,109 @@ func (c *Client) request(method string, endpoint string, body url.Values, query
 	}
 
 	// Decode response body into v, returning response
-	return res, json.NewDecoder(res.Body).Decode(v)
+	if c.strict {
+		return res, decodeStrict(resBody, v)
+	}
+
+	return res, json.NewDecoder(bytes.NewReader(resBody)).Decode(v)
+}
+
+// decodeStrict decodes the JSON response body data into v, returning an error
+// if data contains any fields which are not present in v.  The meta and
+// notifications members of the response are not decoded by most methods, so
+// they are ignored, unless v declares them.
+func decodeStrict(data []byte, v interface{}) error {
+	var body map[string]json.RawMessage
+	if err := json.Unmarshal(data, &body); err != nil {
+		return err
+	}
+	for _, k := range []string{"meta", "notifications"} {
+		if !declaresField(v, k) {
+			delete(body, k)
+		}
+	}
+
+	b, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+
+	d := json.NewDecoder(bytes.NewReader(b))
+	d.DisallowUnknownFields()
+	return d.Decode(v)
+}
+
+// declaresField reports whether the JSON object member name would be decoded
+// into v.  Values which are not structs, such as maps, accept any member.
+func declaresField(v interface{}, name string) bool {
+	t := reflect.TypeOf(v)
+	for t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct {
+		return true
+	}
+
+	return structDeclaresField(t, name)
+}
+
+// structDeclaresField is the backing function for declaresField, which
+// also searches the fields of embedded structs.
+func structDeclaresField(t reflect.Type, name string) bool {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+
+		tag := strings.Split(f.Tag.Get("json"), ",")[0]
+		if tag == "-" {
+			continue
+		}
+
+		// Fields of untagged embedded structs are promoted
+		if f.Anonymous && tag == "" {
+			ft := f.Type
+			if ft.Kind() == reflect.Ptr {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct && structDeclaresField(ft, name) {
+				return true
+			}
+
+			continue
+		}
+
+		if f.PkgPath != "" {
+			// Unexported fields are never decoded
+			continue
+		}
+		if tag == "" {
+			tag = f.Name
+		}
+		if strings.EqualFold(tag, name) {
+			return true
+		}
+	}
+
+	return false
 }
 
 // getCheckins is the backing method for both any request which returns a
 // list of checkins.  It handles performing the necessary HTTP request
 // with the correct parameters, and returns a list of Checkins.
 func (c *Client) getCheckins(endpoint string, q url.Values) ([]*Checkin, *http.Response, error) {
+	return c.getCheckinsContext(context.Background(), endpoint, q)
+}
+
+// getCheckinsContext is the same as getCheckins, but the HTTP request is
+// bound to the input context.
+func (c *Client) getCheckinsContext(ctx context.Context, endpoint string, q url.Values) ([]*Checkin, *http.Response, error) {
+	checkins, _, res, err := c.getCheckinsPage(ctx, endpoint, q)
+	return checkins, res, err
+}
+
+// getCheckinsPage is the same as getCheckinsContext, but also returns the
+// number of items in the response, including any null items which are
+// skipped, so that callers may determine if a page of checkins is full.
+func (c *Client) getCheckinsPage(ctx context.Context, endpoint string, q url.Values) ([]*Checkin, int, *http.Response, error) {
 	// Temporary struct to unmarshal checkin JSON
 	var v struct {
 		Response struct {
@@ -338,18 +905,213 @@ func (c *Client) getCheckins(endpoint string, q url.Values) ([]*Checkin, *http.R
 	}
 
 	// Perform request for user checkins by ID
-	res, err := c.request("GET", endpoint, nil, q, &v)
+	res, err := c.requestContext(ctx, "GET", endpoint, nil, q, &v)
+	if err != nil {
+		return nil, 0, res, err
+	}
+
+	// Build result slice from struct, sized by the number of items actually
+	// returned, since the reported count may not match.  Entries which are
+	// filtered by the API may also appear as null items, and are skipped.
+	checkins := make([]*Checkin, 0, len(v.Response.Checkins.Items))
+	for _, item := range v.Response.Checkins.Items {
+		if item == nil {
+			continue
+		}
+
+		checkins = append(checkins, item.export())
+	}
+
+	return checkins, len(v.Response.Checkins.Items), res, nil
+}
+
+// checkinsQuery builds the query parameters for a request which pages through
+// checkins using checkin ID cursors.  A minimum or maximum ID of zero means
+// no bound, and is omitted from the request.
+func checkinsQuery(minID int64, maxID int64, limit int) url.Values {
+	q := url.Values{
+		"limit": []string{strconv.Itoa(limit)},
+	}
+	if minID != 0 {
+		q.Set("min_id", strconv.FormatInt(minID, 10))
+	}
+	if maxID != 0 {
+		q.Set("max_id", strconv.FormatInt(maxID, 10))
+	}
+
+	return q
+}
+
+// eachCheckin is the callback counterpart to getCheckinsPage.  Rather than
+// building a list of Checkins, it invokes fn for each checkin as it is
+// decoded from the buffered response body.  It returns the number of items
+// in the response, including any null items which are skipped, and the
+// lowest ID of the checkins passed to fn, so that callers may continue
+// paging through checkins.
+//
+// If fn returns an error, decoding stops and that error is returned.
+func (c *Client) eachCheckin(ctx context.Context, endpoint string, q url.Values, fn func(c *Checkin) error) (int, int64, *http.Response, error) {
+	s := &checkinStream{fn: fn}
+
+	// Temporary struct to stream checkin JSON
+	v := struct {
+		Response struct {
+			Checkins struct {
+				Items *checkinStream `json:"items"`
+			} `json:"checkins"`
+		} `json:"response"`
+	}{}
+	v.Response.Checkins.Items = s
+
+	res, err := c.requestContext(ctx, "GET", endpoint, nil, q, &v)
+	if err != nil {
+		return s.items, s.oldest, res, err
+	}
+
+	return s.items, s.oldest, res, nil
+}
+
+// checkinStream implements json.Unmarshaler, so that the items in a list of
+// checkins can be decoded and passed to a callback one at a time.
+type checkinStream struct {
+	fn     func(c *Checkin) error
+	items  int
+	oldest int64
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *checkinStream) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+
+	// As with unmarshalItems, a single item may be returned as a bare object
+	if len(data) > 0 && data[0] == '{' {
+		data = append(append([]byte{'['}, data...), ']')
+	}
+
+	d := json.NewDecoder(bytes.NewReader(data))
+	if t, err := d.Token(); err != nil || t != json.Delim('[') {
+		// No items, or null
+		return err
+	}
+
+	for d.More() {
+		var r *rawCheckin
+		if err := d.Decode(&r); err != nil {
+			return err
+		}
+		s.items++
+
+		// Entries which are filtered by the API may appear as null items
+		if r == nil {
+			continue
+		}
+
+		c := r.export()
+		if s.oldest == 0 || c.ID < s.oldest {
+			s.oldest = c.ID
+		}
+
+		if err := s.fn(c); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// concurrency returns the maximum number of concurrent HTTP requests which
+// may be performed by the Client.
+func (c *Client) concurrency() int {
+	if c.Concurrency <= 0 {
+		return defaultConcurrency
+	}
+
+	return c.Concurrency
+}
+
+// limit returns the number of results which should be requested by methods
+// which do not accept a limit parameter, for an endpoint which allows at most
+// max results.
+func (c *Client) limit(max int) int {
+	limit := c.DefaultLimit
+	if limit <= 0 {
+		limit = defaultLimit
+	}
+	if limit > max {
+		limit = max
+	}
+
+	return limit
+}
+
+// peekBody reads the entire body of an HTTP response, and replaces the body
+// so that it may be read again.  If res or its body is nil, peekBody returns
+// a nil slice and no error.
+func peekBody(res *http.Response) ([]byte, error) {
+	if res == nil || res.Body == nil {
+		return nil, nil
+	}
+
+	body, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+	res.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+	return body, nil
+}
+
+// decompress replaces the body of a gzip-compressed HTTP response with
+// a reader which decompresses it.  If the response is not compressed, or
+// if the transport already decompressed it, the body is left untouched.
+func decompress(res *http.Response) error {
+	if !strings.EqualFold(res.Header.Get("Content-Encoding"), "gzip") {
+		return nil
+	}
+
+	zr, err := gzip.NewReader(res.Body)
 	if err != nil {
-		return nil, res, err
+		return err
+	}
+
+	// The original body is closed by the caller, so closing the gzip
+	// reader is sufficient here
+	res.Body = zr
+	res.Header.Del("Content-Encoding")
+	res.Header.Del("Content-Length")
+	res.ContentLength = -1
+	res.Uncompressed = true
+
+	return nil
+}
+
+// limitedBody is an io.ReadCloser which returns ErrResponseTooLarge if more
+// than n bytes are read from its underlying io.ReadCloser.
+type limitedBody struct {
+	io.ReadCloser
+	n int64
+}
+
+// Read implements io.Reader.
+func (b *limitedBody) Read(p []byte) (int, error) {
+	if b.n <= 0 {
+		// Limit reached: determine if the body has any more data
+		var buf [1]byte
+		n, err := b.ReadCloser.Read(buf[:])
+		if n > 0 {
+			return 0, ErrResponseTooLarge
+		}
+
+		return 0, err
 	}
 
-	// Build result slice from struct
-	checkins := make([]*Checkin, v.Response.Checkins.Count)
-	for i := range v.Response.Checkins.Items {
-		checkins[i] = v.Response.Checkins.Items[i].export()
+	if int64(len(p)) > b.n {
+		p = p[:b.n]
 	}
 
-	return checkins, res, nil
+	n, err := b.ReadCloser.Read(p)
+	b.n -= int64(n)
+	return n, err
 }
 
 // checkResponse checks for a non-200 HTTP status code, and returns any errors
@@ -393,8 +1155,36 @@ func checkResponse(res *http.Response) error {
 	}
 }
 
-// formatFloat converts a float64 to a string in a common way, to
-// reduce inconsistencies with repeated calls to strconv.FormatFloat.
-func formatFloat(f float64) string {
-	return strconv.FormatFloat(f, 'f', -1, 64)
+// formatCoord converts a latitude or longitude to a string with 6 decimal
+// places, which is accurate to roughly 0.1 meters.
+func formatCoord(f float64) string {
+	return strconv.FormatFloat(f, 'f', 6, 64)
+}
+
+// formatRating converts a rating to a string.  Ratings are given in 0.25
+// increments, so the rating is rounded to 2 decimal places, and trailing
+// zeros are omitted.
+func formatRating(f float64) string {
+	return strconv.FormatFloat(math.Round(f*100)/100, 'f', -1, 64)
+}
+
+// joinNonEmpty joins the non-empty strings in ss using sep.
+func joinNonEmpty(sep string, ss ...string) string {
+	out := make([]string, 0, len(ss))
+	for _, s := range ss {
+		if s != "" {
+			out = append(out, s)
+		}
+	}
+
+	return strings.Join(out, sep)
+}
+
+// withDetail appends a parenthesized detail to s, unless detail is empty.
+func withDetail(s string, detail string) string {
+	if detail == "" {
+		return s
+	}
+
+	return s + " (" + detail + ")"
 }
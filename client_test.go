@@ -2,6 +2,10 @@ package untappd
 
 import (
 	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
 	"io"
 	"io/ioutil"
 	"net/http"
@@ -52,6 +56,102 @@ func TestNewAuthenticatedClient(t *testing.T) {
 	}
 }
 
+// TestClientTimeout verifies that a Client applies the appropriate request
+// timeout depending on its options and whether a custom http.Client is used.
+func TestClientTimeout(t *testing.T) {
+	var tests = []struct {
+		description string
+		client      *http.Client
+		options     []ClientOption
+		timeout     time.Duration
+	}{
+		{
+			description: "default",
+			timeout:     defaultTimeout,
+		},
+		{
+			description: "WithTimeout",
+			options:     []ClientOption{WithTimeout(5 * time.Second)},
+			timeout:     5 * time.Second,
+		},
+		{
+			description: "WithTimeout zero",
+			options:     []ClientOption{WithTimeout(0)},
+		},
+		{
+			description: "WithTimeout negative",
+			options:     []ClientOption{WithTimeout(-1)},
+		},
+		{
+			description: "custom client",
+			client:      &http.Client{},
+			options:     []ClientOption{WithTimeout(5 * time.Second)},
+		},
+	}
+
+	for _, tt := range tests {
+		c, err := NewClient("foo", "bar", tt.client, tt.options...)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if c.timeout != tt.timeout {
+			t.Fatalf("unexpected timeout for test %q: %v != %v", tt.description, c.timeout, tt.timeout)
+		}
+	}
+}
+
+// TestClient_requestTimeout verifies that a request which exceeds the
+// Client's timeout is canceled.
+func TestClient_requestTimeout(t *testing.T) {
+	c, done := testClient(t, func(t *testing.T, w http.ResponseWriter, r *http.Request) {
+		// Block until the client gives up on the request
+		select {
+		case <-r.Context().Done():
+		case <-time.After(5 * time.Second):
+		}
+	})
+	defer done()
+
+	WithTimeout(50 * time.Millisecond)(c)
+
+	start := time.Now()
+	_, err := c.request("GET", "foo", nil, nil, nil)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("unexpected error: %v != %v", err, context.DeadlineExceeded)
+	}
+
+	if d := time.Since(start); d > 2*time.Second {
+		t.Fatalf("request took too long to time out: %v", d)
+	}
+}
+
+// TestClient_requestMaxResponseBytes verifies that a response body which
+// exceeds the Client's maximum response size returns ErrResponseTooLarge.
+func TestClient_requestMaxResponseBytes(t *testing.T) {
+	c, done := testClient(t, func(t *testing.T, w http.ResponseWriter, r *http.Request) {
+		w.Write(userCheckinsJSON)
+	})
+	defer done()
+
+	if c.maxBytes != defaultMaxResponseBytes {
+		t.Fatalf("unexpected default maximum response size: %d != %d", c.maxBytes, defaultMaxResponseBytes)
+	}
+
+	// Body fits exactly within the limit
+	WithMaxResponseBytes(int64(len(userCheckinsJSON)))(c)
+	if _, _, err := c.User.Checkins("gregavola"); err != nil {
+		t.Fatal(err)
+	}
+
+	// Body exceeds the limit
+	WithMaxResponseBytes(128)(c)
+	_, _, err := c.User.Checkins("gregavola")
+	if !errors.Is(err, ErrResponseTooLarge) {
+		t.Fatalf("unexpected error: %v != %v", err, ErrResponseTooLarge)
+	}
+}
+
 // TestErrorError tests for consistent output from the Error.Error method.
 func TestErrorError(t *testing.T) {
 	var tests = []struct {
@@ -102,6 +202,68 @@ func TestErrorError(t *testing.T) {
 	}
 }
 
+// TestClientPingOK verifies that Client.Ping returns no error when the API
+// accepts the Client's credentials.
+func TestClientPingOK(t *testing.T) {
+	c, done := testClient(t, func(t *testing.T, w http.ResponseWriter, r *http.Request) {
+		path := "/v4/beer/info/1/"
+		if p := r.URL.Path; p != path {
+			t.Fatalf("unexpected URL path: %q != %q", p, path)
+		}
+
+		assertParameters(t, r, url.Values{
+			"compact": []string{"true"},
+		})
+
+		w.Write([]byte("{}"))
+	})
+	defer done()
+
+	if err := c.Ping(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// TestClientPingUnauthorized verifies that Client.Ping returns an API error
+// when the API rejects the Client's credentials.
+func TestClientPingUnauthorized(t *testing.T) {
+	c, done := testClient(t, func(t *testing.T, w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write(apiErrJSON)
+	})
+	defer done()
+
+	err := c.Ping(context.Background())
+	uErr, ok := err.(*Error)
+	if !ok {
+		t.Fatalf("unexpected error type: %T", err)
+	}
+
+	eType := "invalid_auth"
+	if e := uErr.Type; e != eType {
+		t.Fatalf("unexpected error type: %q != %q", e, eType)
+	}
+}
+
+// TestClientPingTransportError verifies that Client.Ping returns an error
+// when the request cannot be performed.
+func TestClientPingTransportError(t *testing.T) {
+	errTransport := errors.New("transport failure")
+
+	c, err := NewClient("foo", "bar", &http.Client{
+		Transport: roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+			return nil, errTransport
+		}),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := c.Ping(context.Background()); !errors.Is(err, errTransport) {
+		t.Fatalf("unexpected error: %v != %v", err, errTransport)
+	}
+}
+
 // TestClient_requestContainsAPIKeys verifies that both client_id and client_secret
 // are always present in API requests.
 func TestClient_requestContainsAPIKeys(t *testing.T) {
@@ -140,12 +302,560 @@ func TestClient_requestPrefersAccessToken(t *testing.T) {
 	})
 	defer done()
 
-	c.accessToken = "foo"
+	if err := c.SetAccessToken("foo"); err != nil {
+		t.Fatal(err)
+	}
 	if _, err := c.request(method, "foo", nil, nil, nil); err != nil {
 		t.Fatal(err)
 	}
 }
 
+// TestClientSetAccessToken verifies that Client.SetAccessToken rotates the
+// access token used by subsequent requests.
+func TestClientSetAccessToken(t *testing.T) {
+	var token string
+	c, done := testClient(t, func(t *testing.T, w http.ResponseWriter, r *http.Request) {
+		token = r.URL.Query().Get("access_token")
+		w.Write([]byte("{}"))
+	})
+	defer done()
+
+	for _, want := range []string{"foo", "bar"} {
+		if err := c.SetAccessToken(want); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := c.request("GET", "foo", nil, nil, nil); err != nil {
+			t.Fatal(err)
+		}
+
+		if token != want {
+			t.Fatalf("unexpected access token: %q != %q", token, want)
+		}
+	}
+}
+
+// TestClientSetAccessTokenEmpty verifies that Client.SetAccessToken only
+// accepts an empty token when the Client has a client ID and client secret
+// to fall back to.
+func TestClientSetAccessTokenEmpty(t *testing.T) {
+	c, err := NewAuthenticatedClient("foo", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := c.SetAccessToken(""); err != ErrNoAccessToken {
+		t.Fatalf("unexpected error: %v != %v", err, ErrNoAccessToken)
+	}
+
+	c, err = NewClient("foo", "bar", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := c.SetAccessToken(""); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// TestClientWithTransport verifies that a Client uses the http.RoundTripper
+// set by WithTransport, and that the transport sees the Client's credentials.
+func TestClientWithTransport(t *testing.T) {
+	var calls int
+	rt := roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+		calls++
+
+		assertParameters(t, r, url.Values{
+			"access_token": []string{"foo"},
+			"compact":      []string{"true"},
+		})
+
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{"Content-Type": []string{jsonContentType}},
+			Body:       ioutil.NopCloser(bytes.NewReader([]byte("{}"))),
+			Request:    r,
+		}, nil
+	})
+
+	hc := &http.Client{}
+	c, err := NewAuthenticatedClient("foo", hc, WithTransport(rt))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := c.Ping(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	if calls != 1 {
+		t.Fatalf("unexpected number of transport calls: %d != %d", calls, 1)
+	}
+	if hc.Transport != nil {
+		t.Fatal("input http.Client should not have been modified")
+	}
+}
+
+// TestClientWithNoAutoCredentials verifies that a Client configured using
+// WithNoAutoCredentials adds no credentials to its requests, even after its
+// access token is changed.
+func TestClientWithNoAutoCredentials(t *testing.T) {
+	var calls int
+	rt := roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+		calls++
+
+		q := r.URL.Query()
+		for _, k := range []string{"access_token", "client_id", "client_secret"} {
+			if _, ok := q[k]; ok {
+				t.Fatalf("unexpected credential query parameter: %q", k)
+			}
+		}
+
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{"Content-Type": []string{jsonContentType}},
+			Body:       ioutil.NopCloser(bytes.NewReader([]byte("{}"))),
+			Request:    r,
+		}, nil
+	})
+
+	c, err := NewClient("foo", "bar", nil, WithTransport(rt), WithNoAutoCredentials())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := c.Ping(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := c.SetAccessToken("baz"); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Ping(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	if calls != 2 {
+		t.Fatalf("unexpected number of transport calls: %d != %d", calls, 2)
+	}
+}
+
+// TestClientWithFallbackToPublic verifies that a Client configured using
+// WithFallbackToPublic retries a GET request once using its client ID and
+// client secret when its access token is rejected.
+func TestClientWithFallbackToPublic(t *testing.T) {
+	var calls int
+	rt := roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+		calls++
+
+		code, body := http.StatusOK, []byte("{}")
+		switch calls {
+		case 1:
+			assertParameters(t, r, url.Values{
+				"access_token": []string{"foo"},
+			})
+			code, body = http.StatusInternalServerError, invalidUserErrJSON
+		case 2:
+			assertParameters(t, r, url.Values{
+				"client_id":     []string{"bar"},
+				"client_secret": []string{"baz"},
+			})
+			if _, ok := r.URL.Query()["access_token"]; ok {
+				t.Fatal("unexpected access token in fallback request")
+			}
+		}
+
+		return &http.Response{
+			StatusCode: code,
+			Header:     http.Header{"Content-Type": []string{jsonContentType}},
+			Body:       ioutil.NopCloser(bytes.NewReader(body)),
+			Request:    r,
+		}, nil
+	})
+
+	c, err := NewAuthenticatedClient("foo", nil, WithTransport(rt), WithFallbackToPublic("bar", "baz"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := c.request("GET", "foo", nil, nil, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	if calls != 2 {
+		t.Fatalf("unexpected number of transport calls: %d != %d", calls, 2)
+	}
+}
+
+// TestClientWithFallbackToPublicNoCredentials verifies that a Client does not
+// retry a request when its access token is rejected, if it has no client ID
+// and client secret to fall back to, or if the request is not a GET.
+func TestClientWithFallbackToPublicNoCredentials(t *testing.T) {
+	var tests = []struct {
+		description string
+		method      string
+		options     []ClientOption
+	}{
+		{
+			description: "no fallback",
+			method:      "GET",
+		},
+		{
+			description: "no client ID",
+			method:      "GET",
+			options:     []ClientOption{WithFallbackToPublic("", "baz")},
+		},
+		{
+			description: "no client secret",
+			method:      "GET",
+			options:     []ClientOption{WithFallbackToPublic("bar", "")},
+		},
+		{
+			description: "POST request",
+			method:      "POST",
+			options:     []ClientOption{WithFallbackToPublic("bar", "baz")},
+		},
+	}
+
+	for _, tt := range tests {
+		var calls int
+		rt := roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+			calls++
+
+			return &http.Response{
+				StatusCode: http.StatusInternalServerError,
+				Header:     http.Header{"Content-Type": []string{jsonContentType}},
+				Body:       ioutil.NopCloser(bytes.NewReader(invalidUserErrJSON)),
+				Request:    r,
+			}, nil
+		})
+
+		c, err := NewAuthenticatedClient("foo", nil, append(tt.options, WithTransport(rt))...)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		_, err = c.request(tt.method, "foo", nil, nil, nil)
+		uErr, ok := err.(*Error)
+		if !ok {
+			t.Fatalf("unexpected error type for test %q: %T", tt.description, err)
+		}
+		if got, want := uErr.Type, invalidAuthErrorType; got != want {
+			t.Fatalf("unexpected error type for test %q: %q != %q", tt.description, got, want)
+		}
+
+		if calls != 1 {
+			t.Fatalf("unexpected number of transport calls for test %q: %d != %d", tt.description, calls, 1)
+		}
+	}
+}
+
+// TestClientWithStrictDecoding verifies that a Client configured using
+// WithStrictDecoding returns an error for unknown fields in a response, and
+// that a Client ignores them by default.
+func TestClientWithStrictDecoding(t *testing.T) {
+	var tests = []struct {
+		description string
+		body        string
+		options     []ClientOption
+		ok          bool
+	}{
+		{
+			description: "lenient, extra field",
+			body:        `{"meta":{"code":200},"response":{"foo":"bar","baz":1}}`,
+			ok:          true,
+		},
+		{
+			description: "strict, known fields",
+			body:        `{"meta":{"code":200},"notifications":[],"response":{"foo":"bar"}}`,
+			options:     []ClientOption{WithStrictDecoding()},
+			ok:          true,
+		},
+		{
+			description: "strict, extra field",
+			body:        `{"meta":{"code":200},"response":{"foo":"bar","baz":1}}`,
+			options:     []ClientOption{WithStrictDecoding()},
+		},
+	}
+
+	for _, tt := range tests {
+		c, done := testClient(t, func(t *testing.T, w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(tt.body))
+		})
+		for _, o := range tt.options {
+			o(c)
+		}
+
+		var v struct {
+			Response struct {
+				Foo string `json:"foo"`
+			} `json:"response"`
+		}
+
+		_, err := c.request("GET", "foo", nil, nil, &v)
+		done()
+
+		if tt.ok && err != nil {
+			t.Fatalf("unexpected error for test %q: %v", tt.description, err)
+		}
+		if !tt.ok && err == nil {
+			t.Fatalf("expected an error for test %q", tt.description)
+		}
+		if tt.ok && v.Response.Foo != "bar" {
+			t.Fatalf("unexpected foo for test %q: %q != %q", tt.description, v.Response.Foo, "bar")
+		}
+	}
+}
+
+// TestClientGetStrictDecodingMeta verifies that a Client configured using
+// WithStrictDecoding decodes the meta and notifications members of a
+// response into a value which declares them, and ignores them otherwise.
+func TestClientGetStrictDecodingMeta(t *testing.T) {
+	c, done := testClient(t, func(t *testing.T, w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"meta":{"code":200},"notifications":{"count":1},"response":{"foo":"bar"}}`))
+	})
+	defer done()
+
+	WithStrictDecoding()(c)
+
+	var v struct {
+		Meta struct {
+			Code int `json:"code"`
+		} `json:"meta"`
+		Notifications map[string]int
+		Response      struct {
+			Foo string `json:"foo"`
+		} `json:"response"`
+	}
+
+	if _, err := c.Get(context.Background(), "foo", nil, &v); err != nil {
+		t.Fatal(err)
+	}
+
+	if got, want := v.Meta.Code, 200; got != want {
+		t.Fatalf("unexpected meta code: %d != %d", got, want)
+	}
+	if got, want := v.Notifications["count"], 1; got != want {
+		t.Fatalf("unexpected notifications count: %d != %d", got, want)
+	}
+	if got, want := v.Response.Foo, "bar"; got != want {
+		t.Fatalf("unexpected foo: %q != %q", got, want)
+	}
+}
+
+// Test_declaresField verifies that declaresField reports whether a JSON
+// object member would be decoded into a value.
+func Test_declaresField(t *testing.T) {
+	type embedded struct {
+		Meta int `json:"meta"`
+	}
+
+	var tests = []struct {
+		description string
+		v           interface{}
+		ok          bool
+	}{
+		{
+			description: "tagged field",
+			v: &struct {
+				M int `json:"meta,omitempty"`
+			}{},
+			ok: true,
+		},
+		{
+			description: "untagged field",
+			v:           &struct{ Meta int }{},
+			ok:          true,
+		},
+		{
+			description: "embedded struct",
+			v:           &struct{ embedded }{},
+			ok:          true,
+		},
+		{
+			description: "map",
+			v:           &map[string]interface{}{},
+			ok:          true,
+		},
+		{
+			description: "ignored field",
+			v: &struct {
+				Meta int `json:"-"`
+			}{},
+		},
+		{
+			description: "no field",
+			v: &struct {
+				Response int `json:"response"`
+			}{},
+		},
+	}
+
+	for _, tt := range tests {
+		if got := declaresField(tt.v, "meta"); got != tt.ok {
+			t.Fatalf("unexpected result for test %q: %v != %v", tt.description, got, tt.ok)
+		}
+	}
+}
+
+// TestClientListMethodsEmptyResponse verifies that every method which returns
+// a list returns a non-nil, empty slice when the API returns an empty
+// response, so the slice is always safe to use and is marshaled as a JSON
+// array.
+func TestClientListMethodsEmptyResponse(t *testing.T) {
+	c, done := testClient(t, func(t *testing.T, w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("{}"))
+	})
+	defer done()
+
+	var tests = []struct {
+		description string
+		fn          func() (interface{}, error)
+	}{
+		{
+			description: "Auth.Checkins",
+			fn: func() (interface{}, error) {
+				v, _, err := c.Auth.Checkins()
+				return v, err
+			},
+		},
+		{
+			description: "Beer.Checkins",
+			fn: func() (interface{}, error) {
+				v, _, err := c.Beer.Checkins(1)
+				return v, err
+			},
+		},
+		{
+			description: "Beer.CheckinsByUser",
+			fn: func() (interface{}, error) {
+				v, _, err := c.Beer.CheckinsByUser(1, "foo", 0, 0, 25)
+				return v, err
+			},
+		},
+		{
+			description: "Beer.Search",
+			fn: func() (interface{}, error) {
+				v, _, err := c.Beer.Search("foo")
+				return v, err
+			},
+		},
+		{
+			description: "Beer.SearchForUser",
+			fn: func() (interface{}, error) {
+				v, _, err := c.Beer.SearchForUser("foo", "bar")
+				return v, err
+			},
+		},
+		{
+			description: "Brewery.Checkins",
+			fn: func() (interface{}, error) {
+				v, _, err := c.Brewery.Checkins(1)
+				return v, err
+			},
+		},
+		{
+			description: "Brewery.Search",
+			fn: func() (interface{}, error) {
+				v, _, err := c.Brewery.Search("foo")
+				return v, err
+			},
+		},
+		{
+			description: "Local.Checkins",
+			fn: func() (interface{}, error) {
+				v, _, err := c.Local.Checkins(1, 1)
+				return v, err
+			},
+		},
+		{
+			description: "Local.CheckinsInBounds",
+			fn: func() (interface{}, error) {
+				v, _, err := c.Local.CheckinsInBounds(1, 1, 1.1, 1.1, DistanceMiles)
+				return v, err
+			},
+		},
+		{
+			description: "Local.CheckinsNearVenue",
+			fn: func() (interface{}, error) {
+				v, _, err := c.Local.CheckinsNearVenue(1, 1, DistanceMiles)
+				return v, err
+			},
+		},
+		{
+			description: "User.Badges",
+			fn: func() (interface{}, error) {
+				v, _, err := c.User.Badges("foo")
+				return v, err
+			},
+		},
+		{
+			description: "User.Beers",
+			fn: func() (interface{}, error) {
+				v, _, err := c.User.Beers("foo")
+				return v, err
+			},
+		},
+		{
+			description: "User.Checkins",
+			fn: func() (interface{}, error) {
+				v, _, err := c.User.Checkins("foo")
+				return v, err
+			},
+		},
+		{
+			description: "User.CheckinsSince",
+			fn: func() (interface{}, error) {
+				v, _, err := c.User.CheckinsSince("foo", 1, 25)
+				return v, err
+			},
+		},
+		{
+			description: "User.CheckinsOffsetLimit",
+			fn: func() (interface{}, error) {
+				v, _, err := c.User.CheckinsOffsetLimit("foo", 0, 25)
+				return v, err
+			},
+		},
+		{
+			description: "User.Friends",
+			fn: func() (interface{}, error) {
+				v, _, err := c.User.Friends("foo")
+				return v, err
+			},
+		},
+		{
+			description: "User.WishList",
+			fn: func() (interface{}, error) {
+				v, _, err := c.User.WishList("foo")
+				return v, err
+			},
+		},
+		{
+			description: "Venue.Checkins",
+			fn: func() (interface{}, error) {
+				v, _, err := c.Venue.Checkins(1)
+				return v, err
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		v, err := tt.fn()
+		if err != nil {
+			t.Fatalf("unexpected error for test %q: %v", tt.description, err)
+		}
+
+		b, err := json.Marshal(v)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if got, want := string(b), "[]"; got != want {
+			t.Fatalf("unexpected JSON for test %q: %s != %s", tt.description, got, want)
+		}
+	}
+}
+
 // TestClient_requestContainsRequestBody verifies that all request body items
 // are present in API requests, when HTTP method is POST
 func TestClient_requestContainsRequestBody(t *testing.T) {
@@ -234,6 +944,27 @@ func TestClient_requestContainsHeaders(t *testing.T) {
 	}
 }
 
+// TestClient_requestWithUserAgent verifies that WithUserAgent adds a product
+// token to the User-Agent header, while retaining the library's user agent.
+func TestClient_requestWithUserAgent(t *testing.T) {
+	product := "myapp/1.2"
+	want := product + " " + untappdUserAgent
+
+	c, done := testClient(t, func(t *testing.T, w http.ResponseWriter, r *http.Request) {
+		if s := r.Header.Get("User-Agent"); s != want {
+			t.Fatalf("unexpected User-Agent header: %q != %q", s, want)
+		}
+	})
+	defer done()
+
+	WithUserAgent(product)(c)
+	WithUserAgent("")(c)
+
+	if _, err := c.request("GET", "foo", nil, nil, nil); err != nil {
+		t.Fatal(err)
+	}
+}
+
 // TestClient_requestContainsBody verifies that a response body can be
 // unmarshaled from JSON following an API request.
 func TestClient_requestContainsBody(t *testing.T) {
@@ -264,6 +995,216 @@ func TestClient_requestContainsBody(t *testing.T) {
 	}
 }
 
+// TestClient_requestBufferedBody verifies that a response body can be
+// decoded following the content type check, and that the body remains
+// readable from the returned *http.Response on both success and error.
+func TestClient_requestBufferedBody(t *testing.T) {
+	code := http.StatusOK
+	body := []byte(`{"meta":{"code":200},"response":{"name":"foo"}}`)
+
+	c, done := testClient(t, func(t *testing.T, w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(code)
+		w.Write(body)
+	})
+	defer done()
+
+	var v struct {
+		Response struct {
+			Name string `json:"name"`
+		} `json:"response"`
+	}
+
+	res, err := c.request("GET", "foo", nil, nil, &v)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if n := v.Response.Name; n != "foo" {
+		t.Fatalf("unexpected name: %q != %q", n, "foo")
+	}
+	assertBody(t, res, body)
+
+	code = http.StatusInternalServerError
+	body = apiErrJSON
+
+	res, err = c.request("GET", "foo", nil, nil, &v)
+	if _, ok := err.(*Error); !ok {
+		t.Fatalf("unexpected error type: %T", err)
+	}
+	assertBody(t, res, body)
+}
+
+// assertBody asserts that the body of an HTTP response matches an
+// expected body.
+func assertBody(t *testing.T, res *http.Response, expected []byte) {
+	b, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Equal(b, expected) {
+		t.Fatalf("unexpected response body:\n- want: %s\n-  got: %s", expected, b)
+	}
+}
+
+// TestClient_requestGzip verifies that a gzip-compressed response body is
+// transparently decompressed following an API request.
+func TestClient_requestGzip(t *testing.T) {
+	c, done := testClient(t, func(t *testing.T, w http.ResponseWriter, r *http.Request) {
+		if s := r.Header.Get("Accept-Encoding"); s != "gzip" {
+			t.Fatalf("unexpected Accept-Encoding header: %q != %q", s, "gzip")
+		}
+
+		w.Header().Set("Content-Encoding", "gzip")
+
+		zw := gzip.NewWriter(w)
+		if _, err := zw.Write(userCheckinsJSON); err != nil {
+			t.Fatal(err)
+		}
+		if err := zw.Close(); err != nil {
+			t.Fatal(err)
+		}
+	})
+	defer done()
+
+	checkins, _, err := c.User.Checkins("gregavola")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if l := len(checkins); l != 1 {
+		t.Fatalf("unexpected number of checkins: %d != %d", l, 1)
+	}
+	assertExpectedCheckins(t, checkins)
+}
+
+// TestMultiErrorError verifies that a MultiError is formatted as a summary
+// of each of its errors.
+func TestMultiErrorError(t *testing.T) {
+	var tests = []struct {
+		description string
+		err         MultiError
+		s           string
+	}{
+		{
+			description: "one error",
+			err:         MultiError{errors.New("foo")},
+			s:           "foo",
+		},
+		{
+			description: "several errors",
+			err: MultiError{
+				errors.New("foo"),
+				&Error{Code: 500, Type: "invalid_param", Detail: "bar"},
+			},
+			s: "2 errors occurred:\nfoo\n500 [invalid_param]: bar",
+		},
+	}
+
+	for _, tt := range tests {
+		if s := tt.err.Error(); s != tt.s {
+			t.Fatalf("unexpected string for test %q:\n- want: %q\n-  got: %q", tt.description, tt.s, s)
+		}
+	}
+}
+
+// TestMultiErrorIsAs verifies that errors.Is and errors.As match the errors
+// contained in a MultiError.
+func TestMultiErrorIsAs(t *testing.T) {
+	uErr := &Error{Type: privateUserErrorType}
+
+	var err error = MultiError{
+		ErrInvalidLimit,
+		uErr,
+	}
+
+	if !errors.Is(err, ErrInvalidLimit) {
+		t.Fatal("MultiError should match ErrInvalidLimit")
+	}
+	if !errors.Is(err, ErrPrivateUser) {
+		t.Fatal("MultiError should match ErrPrivateUser")
+	}
+	if errors.Is(err, ErrInvalidSort) {
+		t.Fatal("MultiError should not match ErrInvalidSort")
+	}
+
+	var target *Error
+	if !errors.As(err, &target) {
+		t.Fatal("MultiError should contain an *Error")
+	}
+	if target != uErr {
+		t.Fatalf("unexpected *Error: %v != %v", target, uErr)
+	}
+}
+
+// TestClientGet verifies that Client.Get can be used to request an arbitrary
+// API endpoint, and decode its response body.
+func TestClientGet(t *testing.T) {
+	c, done := testClient(t, func(t *testing.T, w http.ResponseWriter, r *http.Request) {
+		if m := r.Method; m != "GET" {
+			t.Fatalf("unexpected method: %q != %q", m, "GET")
+		}
+
+		path := "/v4/made/up/endpoint/"
+		if p := r.URL.Path; p != path {
+			t.Fatalf("unexpected URL path: %q != %q", p, path)
+		}
+
+		assertParameters(t, r, url.Values{
+			"foo": []string{"bar"},
+		})
+
+		w.Write([]byte(`{"meta":{"code":200},"response":{"name":"foo","count":2}}`))
+	})
+	defer done()
+
+	var v struct {
+		Response struct {
+			Name  string `json:"name"`
+			Count int    `json:"count"`
+		} `json:"response"`
+	}
+
+	_, err := c.Get(context.Background(), "/made/up/endpoint", url.Values{
+		"foo": []string{"bar"},
+	}, &v)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if n := v.Response.Name; n != "foo" {
+		t.Fatalf("unexpected name: %q != %q", n, "foo")
+	}
+	if c := v.Response.Count; c != 2 {
+		t.Fatalf("unexpected count: %d != %d", c, 2)
+	}
+}
+
+// TestClientPost verifies that Client.Post sends a request body to an
+// arbitrary API endpoint, and returns API errors.
+func TestClientPost(t *testing.T) {
+	c, done := testClient(t, func(t *testing.T, w http.ResponseWriter, r *http.Request) {
+		if m := r.Method; m != "POST" {
+			t.Fatalf("unexpected method: %q != %q", m, "POST")
+		}
+
+		if got, want := r.PostFormValue("foo"), "bar"; got != want {
+			t.Fatalf("unexpected request body parameter %q: %v != %v", "foo", got, want)
+		}
+
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write(apiErrJSON)
+	})
+	defer done()
+
+	_, err := c.Post(context.Background(), "made/up/endpoint", url.Values{
+		"foo": []string{"bar"},
+	}, nil)
+	if _, ok := err.(*Error); !ok {
+		t.Fatalf("unexpected error type: %T", err)
+	}
+}
+
 // Test_checkResponseWrongContentType verifies that checkResponse returns an error
 // when the Content-Type header does not indicate application/json.
 func Test_checkResponseWrongContentType(t *testing.T) {
@@ -312,6 +1253,32 @@ func Test_checkResponseErrorOK(t *testing.T) {
 	})
 }
 
+// Test_checkResponsePrivateUser verifies that checkResponse returns an error
+// which matches ErrPrivateUser when a private user is queried.
+func Test_checkResponsePrivateUser(t *testing.T) {
+	withHTTPResponse(t, http.StatusInternalServerError, jsonContentType, privateUserErrJSON, func(t *testing.T, res *http.Response) {
+		err := checkResponse(res)
+		if !errors.Is(err, ErrPrivateUser) {
+			t.Fatalf("unexpected error: %v != %v", err, ErrPrivateUser)
+		}
+
+		// Callers relying on the concrete type must be unaffected
+		if _, ok := err.(*Error); !ok {
+			t.Fatalf("unexpected error type: %T", err)
+		}
+	})
+}
+
+// Test_checkResponseNotPrivateUser verifies that checkResponse returns an
+// error which does not match ErrPrivateUser for other API errors.
+func Test_checkResponseNotPrivateUser(t *testing.T) {
+	withHTTPResponse(t, http.StatusInternalServerError, jsonContentType, invalidUserErrJSON, func(t *testing.T, res *http.Response) {
+		if err := checkResponse(res); errors.Is(err, ErrPrivateUser) {
+			t.Fatalf("unexpected match for error: %v", err)
+		}
+	})
+}
+
 // Test_checkResponseEOF verifies that checkResponse returns no error when HTTP
 // status is OK, but response body is empty.
 func Test_checkResponseOKNoBody(t *testing.T) {
@@ -332,34 +1299,53 @@ func Test_checkResponseOKWithBody(t *testing.T) {
 	})
 }
 
-// Test_formatFloat verifies that formatFloat produces consistent
-// strings from float64 values.
-func Test_formatFloat(t *testing.T) {
+// Test_formatCoordRating verifies that formatCoord and formatRating produce
+// consistent strings from float64 values, with precision appropriate to
+// coordinates and ratings respectively.
+func Test_formatCoordRating(t *testing.T) {
 	var tests = []struct {
-		f float64
-		s string
+		f      float64
+		coord  string
+		rating string
 	}{
 		{
-			f: 0.0,
-			s: "0",
+			f:      0.0,
+			coord:  "0.000000",
+			rating: "0",
+		},
+		{
+			f:      1.5,
+			coord:  "1.500000",
+			rating: "1.5",
 		},
 		{
-			f: 1.5,
-			s: "1.5",
+			f:      3.75,
+			coord:  "3.750000",
+			rating: "3.75",
 		},
 		{
-			f: 2.2345,
-			s: "2.2345",
+			f:      2.2345,
+			coord:  "2.234500",
+			rating: "2.23",
 		},
 		{
-			f: 03.456789,
-			s: "3.456789",
+			f:      40.71278123,
+			coord:  "40.712781",
+			rating: "40.71",
+		},
+		{
+			f:      -73.9859414,
+			coord:  "-73.985941",
+			rating: "-73.99",
 		},
 	}
 
 	for i, tt := range tests {
-		if s := formatFloat(tt.f); s != tt.s {
-			t.Fatalf("%02d: unexpected string for %f: %s != %s", i, tt.f, s, tt.s)
+		if s := formatCoord(tt.f); s != tt.coord {
+			t.Fatalf("%02d: unexpected coordinate string for %f: %s != %s", i, tt.f, s, tt.coord)
+		}
+		if s := formatRating(tt.f); s != tt.rating {
+			t.Fatalf("%02d: unexpected rating string for %f: %s != %s", i, tt.f, s, tt.rating)
 		}
 	}
 }
@@ -390,23 +1376,29 @@ func testClient(t *testing.T, fn func(t *testing.T, w http.ResponseWriter, r *ht
 		}
 	}))
 
-	client, err := NewClient("foo", "bar", nil)
+	u, err := url.Parse(srv.URL + "/v4")
 	if err != nil {
 		t.Fatal(err)
 	}
 
-	u, err := url.Parse(srv.URL + "/v4")
+	client, err := NewClient("foo", "bar", nil, withURL(u))
 	if err != nil {
 		t.Fatal(err)
 	}
 
-	client.url = u
-
 	return client, func() {
 		srv.Close()
 	}
 }
 
+// withURL sets the API root used by a Client, so that its credentials are
+// applied to requests to a test server.
+func withURL(u *url.URL) ClientOption {
+	return func(c *Client) {
+		c.url = u
+	}
+}
+
 // assertParameters asserts that query parameters from an HTTP request
 // match an expected set of query parameter values.
 func assertParameters(t *testing.T, r *http.Request, expected url.Values) {
@@ -570,6 +1562,9 @@ var apiErrJSON = []byte(`{
 // invalidUserErrJSON is canned JSON used to test for invalid user handling
 var invalidUserErrJSON = []byte(`{"meta":{"code":500,"error_detail":"There is no user with that username.","error_type":"invalid_auth","response_time":{"time":0,"measure":"seconds"}}}`)
 
+// privateUserErrJSON is canned JSON used to test for private user handling
+var privateUserErrJSON = []byte(`{"meta":{"code":500,"error_detail":"This user has chosen to make their account private.","error_type":"invalid_user_private","response_time":{"time":0,"measure":"seconds"}}}`)
+
 // invalidBeerErrJSON is canned JSON used to test for invalid beer handling
 var invalidBeerErrJSON = []byte(`{"meta":{"code":500,"error_detail":"This Beer ID is invalid.","error_type":"invalid_param","response_time":{"time":0,"measure":"seconds"}}}`)
 
@@ -587,3 +1582,7 @@ var invalidQueryErrJSON = []byte(`{"meta":{"code":500,"error_detail":"Your missi
 
 // invalidCheckinErrJSON is canned JSON used to test for an invalid checkin attempt
 var invalidCheckinErrJSON = []byte(`{"meta":{"code":500,"error_detail":"The bid field is required.","error_type":"invalid_param","developer_friendly":"","response_time":{"time":0.036,"measure":"seconds"}},"response":[]}`)
+
+// socialNotLinkedErrJSON is canned JSON used to test for unlinked social
+// network handling when checking in
+var socialNotLinkedErrJSON = []byte(`{"meta":{"code":500,"error_detail":"Your Twitter account is not linked to Untappd.","error_type":"invalid_social_account","developer_friendly":"","response_time":{"time":0.021,"measure":"seconds"}},"response":[]}`)
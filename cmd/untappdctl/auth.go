@@ -1,12 +1,13 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"log"
 	"net"
 	"net/http"
 	"strconv"
-	"strings"
 	"time"
 
 	"github.com/codegangsta/cli"
@@ -41,37 +42,38 @@ func authCheckinCommand() *cli.Command {
 			},
 			&cli.StringFlag{
 				Name:  "comment",
-				Usage: "optional comment for this checkin",
+				Usage: fmt.Sprintf("optional comment, up to %d characters, for this checkin", untappd.MaxCheckinCommentLength),
 			},
 		},
 
 		Action: func(ctx *cli.Context) error {
 			// Check for valid integer ID
-			id, err := strconv.Atoi(mustStringArg(ctx, "beer ID"))
+			id, err := strconv.ParseInt(mustStringArg(ctx, "beer ID"), 10, 64)
 			checkAtoiError(err)
 
-			// Use system's timezone and offset for request,
-			// dividing to get a single digit offset
-			// Thanks: https://github.com/cmar/untappd/blob/master/lib/untappd/checkin.rb#L50
-			timezone, offset := time.Now().Zone()
-			offset = offset / 60 / 60
+			r := untappd.CheckinRequest{
+				BeerID:  id,
+				Comment: ctx.String("comment"),
+				Rating:  ctx.Float64("rating"),
+			}
+
+			// Use system's timezone and offset for request
+			r.FillTimeZone(time.Now())
 
 			// Attempt to perform checkin
 			c := untappdClient(ctx)
-			checkin, res, err := c.Auth.Checkin(untappd.CheckinRequest{
-				BeerID:    id,
-				GMTOffset: offset,
-				TimeZone:  timezone,
-				Comment:   ctx.String("comment"),
-				Rating:    ctx.Float64("rating"),
-			})
+			checkin, res, err := c.Auth.Checkin(r)
 			printRateLimit(res)
+			if errors.Is(err, untappd.ErrCommentTooLong) {
+				log.Fatalf("comment is too long: must be at most %d characters", untappd.MaxCheckinCommentLength)
+			}
 			if err != nil {
 				log.Fatal(err)
 			}
 
-			// Print out checkin in human-readable format
-			printCheckins([]*untappd.Checkin{checkin})
+			// Print out checkin in human-readable or JSON format
+			checkins := []*untappd.Checkin{checkin}
+			printResult(ctx, checkins, func() { printCheckins(checkins) })
 			return nil
 		},
 	}
@@ -94,8 +96,8 @@ func authCheckinsCommand(limitFlag, minIDFlag, maxIDFlag *cli.IntFlag) *cli.Comm
 			// "untappdctl beer checkins mdlayher"
 			c := untappdClient(ctx)
 			checkins, res, err := c.Auth.CheckinsMinMaxIDLimit(
-				ctx.Int("min_id"),
-				ctx.Int("max_id"),
+				int64(ctx.Int("min_id")),
+				int64(ctx.Int("max_id")),
 				ctx.Int("limit"),
 			)
 			printRateLimit(res)
@@ -103,8 +105,8 @@ func authCheckinsCommand(limitFlag, minIDFlag, maxIDFlag *cli.IntFlag) *cli.Comm
 				log.Fatal(err)
 			}
 
-			// Print out checkins in human-readable format
-			printCheckins(checkins)
+			// Print out checkins in human-readable or JSON format
+			printResult(ctx, checkins, func() { printCheckins(checkins) })
 			return nil
 		},
 	}
@@ -117,6 +119,12 @@ func authLoginCommand() *cli.Command {
 		Name:    "login",
 		Aliases: []string{"l"},
 		Usage:   "authenticate using OAuth to Untappd APIv4",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:  "save",
+				Usage: "optional file path where the access token is saved for use with --token_file",
+			},
+		},
 
 		Action: func(ctx *cli.Context) error {
 			// 8338 looks kinda like "BEER", right?
@@ -125,60 +133,58 @@ func authLoginCommand() *cli.Command {
 			// Set up redirect URL, which will use our HTTP server
 			redirectURL := fmt.Sprintf("http://localhost%s", host)
 
-			// Start listening for TCP connections
-			l, err := net.Listen("tcp", host)
-			if err != nil {
-				log.Fatal(err)
-			}
-
-			// Wait for a single token to arrive, then cancel listener
-			doneC := make(chan struct{})
-
-			// Handle response token by providing to to both HTTP response
-			// and terminal output
-			tokenFn := func(token string, w http.ResponseWriter, r *http.Request) {
-				// Print token in terminal and to HTTP response body
-				log.Println("token:", token)
-				if _, err := w.Write([]byte(token)); err != nil {
-					http.Error(w, err.Error(), http.StatusInternalServerError)
-					return
-				}
-
-				// Close HTTP listener to prevent further requests
-				_ = l.Close()
-				close(doneC)
-			}
-
 			// Set up http.Handler which allows easy OAuth authentication
-			// with Untappd APIv4
-			h, clientURL, err := untappd.NewAuthHandler(
+			// with Untappd APIv4, completing after a single token exchange
+			h, clientURL, err := untappd.NewOneShotAuthHandler(
 				ctx.String("client_id"),
 				ctx.String("client_secret"),
 				redirectURL,
-				tokenFn,
 				nil,
 			)
 			if err != nil {
 				log.Fatal(err)
 			}
 
+			// Start listening for TCP connections
+			l, err := net.Listen("tcp", host)
+			if err != nil {
+				log.Fatal(err)
+			}
+
 			// Start HTTP server in background, using our custom authentication handler
+			srv := &http.Server{
+				Handler: h,
+			}
 			go func() {
-				if err := (&http.Server{
-					Handler: h,
-				}).Serve(l); err != nil {
-					// Ignore this error on shutdown
-					if !strings.Contains(err.Error(), "use of closed network connection") {
-						log.Println(err)
-					}
+				if err := srv.Serve(l); err != nil && err != http.ErrServerClosed {
+					log.Println(err)
 				}
 			}()
 
 			// Provide link for user to open to start authentication flow
 			log.Println(clientURL.String())
 
-			// Block until one authentication completes
-			<-doneC
+			// Block until one authentication completes, then shut down the
+			// HTTP server once the response has been sent
+			result := <-h.Result()
+			if err := srv.Shutdown(context.Background()); err != nil {
+				log.Fatal(err)
+			}
+			if result.Err != nil {
+				log.Fatal(result.Err)
+			}
+
+			// Optionally persist the token so later commands can use it
+			if path := ctx.String("save"); path != "" {
+				if err := writeTokenFile(path, result.Token); err != nil {
+					log.Fatal(err)
+				}
+
+				log.Println("token saved:", path)
+				return nil
+			}
+
+			log.Println("token:", result.Token)
 			return nil
 		},
 	}
@@ -38,10 +38,10 @@ func beerCheckinsCommand(limitFlag, minIDFlag, maxIDFlag *cli.IntFlag) *cli.Comm
 
 		Action: func(ctx *cli.Context) error {
 			// Check for valid integer ID
-			id, err := strconv.Atoi(mustStringArg(ctx, "beer ID"))
+			id, err := strconv.ParseInt(mustStringArg(ctx, "beer ID"), 10, 64)
 			checkAtoiError(err)
 
-			minID, maxID, limit := ctx.Int("min_id"), ctx.Int("max_id"), ctx.Int("limit")
+			minID, maxID, limit := int64(ctx.Int("min_id")), int64(ctx.Int("max_id")), ctx.Int("limit")
 
 			// Query for beer's checkins by beername, e.g.
 			// "untappdctl beer checkins mdlayher"
@@ -57,8 +57,8 @@ func beerCheckinsCommand(limitFlag, minIDFlag, maxIDFlag *cli.IntFlag) *cli.Comm
 				log.Fatal(err)
 			}
 
-			// Print out checkins in human-readable format
-			printCheckins(checkins)
+			// Print out checkins in human-readable or JSON format
+			printResult(ctx, checkins, func() { printCheckins(checkins) })
 			return nil
 		},
 	}
@@ -74,7 +74,7 @@ func beerInfoCommand() *cli.Command {
 
 		Action: func(ctx *cli.Context) error {
 			// Check for valid integer ID
-			id, err := strconv.Atoi(mustStringArg(ctx, "beer ID"))
+			id, err := strconv.ParseInt(mustStringArg(ctx, "beer ID"), 10, 64)
 			checkAtoiError(err)
 
 			// Query for beer by ID, e.g. "untappdctl beer info 1"
@@ -85,8 +85,9 @@ func beerInfoCommand() *cli.Command {
 				log.Fatal(err)
 			}
 
-			// Print out beer in human-readable format
-			printBeers([]*untappd.Beer{beer})
+			// Print out beer in human-readable or JSON format
+			beers := []*untappd.Beer{beer}
+			printResult(ctx, beers, func() { printBeers(beers) })
 			return nil
 		},
 	}
@@ -121,8 +122,8 @@ func beerSearchCommand(offsetFlag, limitFlag *cli.IntFlag, sortFlag *cli.StringF
 				log.Fatal(err)
 			}
 
-			// Print out beers in human-readable format
-			printBeers(beers)
+			// Print out beers in human-readable or JSON format
+			printResult(ctx, beers, func() { printBeers(beers) })
 			return nil
 		},
 	}
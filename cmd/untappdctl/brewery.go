@@ -38,10 +38,10 @@ func breweryCheckinsCommand(limitFlag, minIDFlag, maxIDFlag *cli.IntFlag) *cli.C
 
 		Action: func(ctx *cli.Context) error {
 			// Check for valid integer ID
-			id, err := strconv.Atoi(mustStringArg(ctx, "brewery ID"))
+			id, err := strconv.ParseInt(mustStringArg(ctx, "brewery ID"), 10, 64)
 			checkAtoiError(err)
 
-			minID, maxID, limit := ctx.Int("min_id"), ctx.Int("max_id"), ctx.Int("limit")
+			minID, maxID, limit := int64(ctx.Int("min_id")), int64(ctx.Int("max_id")), ctx.Int("limit")
 
 			// Query for brewery's checkins by brewery ID, e.g.
 			// "untappdctl brewery checkins 1"
@@ -57,8 +57,8 @@ func breweryCheckinsCommand(limitFlag, minIDFlag, maxIDFlag *cli.IntFlag) *cli.C
 				log.Fatal(err)
 			}
 
-			// Print out checkins in human-readable format
-			printCheckins(checkins)
+			// Print out checkins in human-readable or JSON format
+			printResult(ctx, checkins, func() { printCheckins(checkins) })
 			return nil
 		},
 	}
@@ -74,7 +74,7 @@ func breweryInfoCommand() *cli.Command {
 
 		Action: func(ctx *cli.Context) error {
 			// Check for valid integer ID
-			id, err := strconv.Atoi(mustStringArg(ctx, "brewery ID"))
+			id, err := strconv.ParseInt(mustStringArg(ctx, "brewery ID"), 10, 64)
 			checkAtoiError(err)
 
 			// Query for brewery by ID, e.g. "untappdctl brewery info 1"
@@ -85,8 +85,9 @@ func breweryInfoCommand() *cli.Command {
 				log.Fatal(err)
 			}
 
-			// Print out brewery in human-readable format
-			printBreweries([]*untappd.Brewery{brewery})
+			// Print out brewery in human-readable or JSON format
+			breweries := []*untappd.Brewery{brewery}
+			printResult(ctx, breweries, func() { printBreweries(breweries) })
 			return nil
 		},
 	}
@@ -120,8 +121,8 @@ func brewerySearchCommand(offsetFlag, limitFlag *cli.IntFlag) *cli.Command {
 				log.Fatal(err)
 			}
 
-			// Print out breweries in human-readable format
-			printBreweries(breweries)
+			// Print out breweries in human-readable or JSON format
+			printResult(ctx, breweries, func() { printBreweries(breweries) })
 			return nil
 		},
 	}
@@ -12,13 +12,13 @@ import (
 
 // localCommand allows access to untappd.Client.Local methods, such as local
 // checkins by latitude and longitude.
-func localCommand(limitFlag, minIDFlag, maxIDFlag *cli.IntFlag) *cli.Command {
+func localCommand(offsetFlag, limitFlag, minIDFlag, maxIDFlag *cli.IntFlag) *cli.Command {
 	return &cli.Command{
 		Name:    "local",
 		Aliases: []string{"l"},
 		Usage:   "query for local area checkins, by latitude and longitude",
 		Subcommands: []*cli.Command{
-			localCheckinsCommand(limitFlag, minIDFlag, maxIDFlag),
+			localCheckinsCommand(offsetFlag, limitFlag, minIDFlag, maxIDFlag),
 		},
 	}
 }
@@ -26,12 +26,13 @@ func localCommand(limitFlag, minIDFlag, maxIDFlag *cli.IntFlag) *cli.Command {
 // localCheckinsCommand allows access to the untappd.Client.Local.Checkins method, which
 // can query for information about recent checkins for a local area, by latitude, longitude,
 // and several other parameters.
-func localCheckinsCommand(limitFlag, minIDFlag, maxIDFlag *cli.IntFlag) *cli.Command {
+func localCheckinsCommand(offsetFlag, limitFlag, minIDFlag, maxIDFlag *cli.IntFlag) *cli.Command {
 	return &cli.Command{
 		Name:    "checkins",
 		Aliases: []string{"c"},
 		Usage:   "query for recent checkins for a local area, by latitude and longitude",
 		Flags: []cli.Flag{
+			offsetFlag,
 			limitFlag,
 			minIDFlag,
 			maxIDFlag,
@@ -43,7 +44,7 @@ func localCheckinsCommand(limitFlag, minIDFlag, maxIDFlag *cli.IntFlag) *cli.Com
 			&cli.StringFlag{
 				Name:  "unit",
 				Value: string(untappd.DistanceMiles),
-				Usage: fmt.Sprintf("units for radius, either %q or %q", untappd.DistanceMiles, untappd.DistanceKilometers),
+				Usage: fmt.Sprintf("units for radius (options: %s)", untappd.Distances()),
 			},
 		},
 
@@ -63,8 +64,15 @@ func localCheckinsCommand(limitFlag, minIDFlag, maxIDFlag *cli.IntFlag) *cli.Com
 
 			// Validate units
 			unit := untappd.Distance(ctx.String("unit"))
-			if unit != untappd.DistanceMiles && unit != untappd.DistanceKilometers {
-				log.Fatalf("unit must be %q or %q", untappd.DistanceMiles, untappd.DistanceKilometers)
+			var found bool
+			for _, d := range untappd.Distances() {
+				if unit == d {
+					found = true
+					break
+				}
+			}
+			if !found {
+				log.Fatalf("invalid unit %q (options: %s)", unit, untappd.Distances())
 			}
 
 			// Query for local's checkins by local area with latitude,longitude
@@ -74,8 +82,9 @@ func localCheckinsCommand(limitFlag, minIDFlag, maxIDFlag *cli.IntFlag) *cli.Com
 			checkins, res, err := c.Local.CheckinsMinMaxIDLimitRadius(untappd.LocalCheckinsRequest{
 				Latitude:  lat,
 				Longitude: lng,
-				MinID:     ctx.Int("min_id"),
-				MaxID:     ctx.Int("max_id"),
+				MinID:     int64(ctx.Int("min_id")),
+				MaxID:     int64(ctx.Int("max_id")),
+				Offset:    ctx.Int("offset"),
 				Limit:     ctx.Int("limit"),
 				Radius:    ctx.Int("radius"),
 				Units:     unit,
@@ -85,8 +94,8 @@ func localCheckinsCommand(limitFlag, minIDFlag, maxIDFlag *cli.IntFlag) *cli.Com
 				log.Fatal(err)
 			}
 
-			// Print out checkins in human-readable format
-			printCheckins(checkins)
+			// Print out checkins in human-readable or JSON format
+			printResult(ctx, checkins, func() { printCheckins(checkins) })
 			return nil
 		},
 	}
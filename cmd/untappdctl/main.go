@@ -3,10 +3,10 @@ package main
 import (
 	"fmt"
 	"log"
-	"math"
 	"net/http"
 	"os"
 	"strconv"
+	"strings"
 
 	"github.com/codegangsta/cli"
 	"github.com/mdlayher/untappd"
@@ -30,8 +30,8 @@ func main() {
 		},
 	}
 
-	// Add global flags for Untappd API client ID, client secret, and
-	// authenticated access token
+	// Add global flags for Untappd API client ID, client secret,
+	// authenticated access token, and output format
 	app.Flags = []cli.Flag{
 		&cli.StringFlag{
 			Name:    "client_id",
@@ -48,6 +48,15 @@ func main() {
 			Usage:   "authenticated access token for Untappd APIv4",
 			EnvVars: []string{"UNTAPPD_TOKEN"},
 		},
+		&cli.StringFlag{
+			Name:    "token_file",
+			Usage:   "file containing an access token saved by 'auth login --save'",
+			EnvVars: []string{"UNTAPPD_TOKEN_FILE"},
+		},
+		&cli.BoolFlag{
+			Name:  "json",
+			Usage: "print results as indented JSON instead of tables",
+		},
 	}
 
 	// Frequently used flags for paging and sorting results, with their
@@ -65,7 +74,7 @@ func main() {
 	sortFlag := &cli.StringFlag{
 		Name:  "sort",
 		Value: string(untappd.SortDate),
-		Usage: fmt.Sprintf("sort type for API query results (options: %s)", untappd.Sorts()),
+		Usage: fmt.Sprintf("sort type for API query results (options: %s)", sortOptions()),
 	}
 
 	// Flags used to specify minimum and maximum checkin IDs
@@ -76,8 +85,8 @@ func main() {
 	}
 	maxIDFlag := &cli.IntFlag{
 		Name:  "max_id",
-		Value: math.MaxInt32,
-		Usage: "maximum checkin ID for API query results",
+		Value: 0,
+		Usage: "maximum checkin ID for API query results, or 0 for no maximum",
 	}
 
 	// Add commands mirroring available untappd.Client services
@@ -85,7 +94,7 @@ func main() {
 		authCommand(limitFlag, minIDFlag, maxIDFlag),
 		beerCommand(offsetFlag, limitFlag, sortFlag, minIDFlag, maxIDFlag),
 		breweryCommand(offsetFlag, limitFlag, minIDFlag, maxIDFlag),
-		localCommand(limitFlag, minIDFlag, maxIDFlag),
+		localCommand(offsetFlag, limitFlag, minIDFlag, maxIDFlag),
 		userCommand(offsetFlag, limitFlag, sortFlag, minIDFlag, maxIDFlag),
 		venueCommand(limitFlag, minIDFlag, maxIDFlag),
 	}
@@ -99,13 +108,23 @@ func main() {
 }
 
 // untappdClient creates an initialized *untappd.Client using either the
-// access token, or client ID and secret from global CLI context.
+// access token, token file, or client ID and secret from global CLI context.
 func untappdClient(ctx *cli.Context) *untappd.Client {
 	var c *untappd.Client
 	var err error
 
-	// Always prefer authenticated access token, if available
+	// Always prefer authenticated access token, if available, falling
+	// back to a token file saved by a previous login
 	token := ctx.String("access_token")
+	if token == "" {
+		if path := ctx.String("token_file"); path != "" {
+			token, err = readTokenFile(path)
+			if err != nil {
+				log.Fatal(err)
+			}
+		}
+	}
+
 	if token != "" {
 		c, err = untappd.NewAuthenticatedClient(token, nil)
 	} else {
@@ -123,8 +142,14 @@ func untappdClient(ctx *cli.Context) *untappd.Client {
 }
 
 // printRateLimit is a helper method which displays the remaining rate limit
-// header for each HTTP request.
+// header for each HTTP request.  Requests which fail before a response is
+// received, such as those rejected by validation, have no rate limit to
+// display.
 func printRateLimit(res *http.Response) {
+	if res == nil {
+		return
+	}
+
 	const header = "X-Ratelimit-Remaining"
 	if v := res.Header.Get(header); v != "" {
 		log.Printf("%s: %s", header, v)
@@ -162,10 +187,21 @@ func offsetLimitSort(ctx *cli.Context) (int, int, untappd.Sort) {
 	}
 
 	// Die on invalid sort, and show options
-	log.Fatalf("invalid sort type %q (options: %s)", sort, untappd.Sorts())
+	log.Fatalf("invalid sort type %q (options: %s)", sort, sortOptions())
 	return offset, limit, untappd.Sort("")
 }
 
+// sortOptions returns a list of the available sort types and their
+// descriptions, for display in help and error messages.
+func sortOptions() string {
+	opts := make([]string, 0, len(untappd.Sorts()))
+	for _, s := range untappd.Sorts() {
+		opts = append(opts, fmt.Sprintf("%s: %s", s, untappd.SortDescription(s)))
+	}
+
+	return strings.Join(opts, ", ")
+}
+
 // checkAtoiError reduces error-checking code duplication for functions
 // which require valid integer IDs.
 func checkAtoiError(err error) {
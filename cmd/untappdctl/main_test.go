@@ -0,0 +1,74 @@
+package main
+
+import (
+	"bytes"
+	"log"
+	"net/http"
+	"os"
+	"testing"
+
+	"github.com/mdlayher/untappd"
+)
+
+// Test_printRateLimit verifies that printRateLimit displays the remaining
+// rate limit of a response, and does nothing when no response was received.
+func Test_printRateLimit(t *testing.T) {
+	var tests = []struct {
+		description string
+		res         *http.Response
+		out         string
+	}{
+		{
+			description: "no response",
+		},
+		{
+			description: "no header",
+			res:         &http.Response{Header: http.Header{}},
+		},
+		{
+			description: "header",
+			res: &http.Response{Header: http.Header{
+				"X-Ratelimit-Remaining": []string{"99"},
+			}},
+			out: "X-Ratelimit-Remaining: 99\n",
+		},
+	}
+
+	defer log.SetOutput(os.Stderr)
+	defer log.SetFlags(log.Flags())
+	log.SetFlags(0)
+
+	for _, tt := range tests {
+		buf := bytes.NewBuffer(nil)
+		log.SetOutput(buf)
+
+		printRateLimit(tt.res)
+
+		if got := buf.String(); got != tt.out {
+			t.Fatalf("unexpected output for test %q: %q != %q", tt.description, got, tt.out)
+		}
+	}
+}
+
+// Test_printRateLimitValidationError verifies that printRateLimit can be used
+// with the nil response returned by a request which fails validation.
+func Test_printRateLimitValidationError(t *testing.T) {
+	c, err := untappd.NewAuthenticatedClient("foo", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	comment := string(bytes.Repeat([]byte("a"), untappd.MaxCheckinCommentLength+1))
+	_, res, err := c.Auth.Checkin(untappd.CheckinRequest{
+		BeerID:  1,
+		Comment: comment,
+	})
+	if err != untappd.ErrCommentTooLong {
+		t.Fatalf("unexpected error: %v != %v", err, untappd.ErrCommentTooLong)
+	}
+
+	defer log.SetOutput(os.Stderr)
+	log.SetOutput(bytes.NewBuffer(nil))
+
+	printRateLimit(res)
+}
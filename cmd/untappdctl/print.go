@@ -1,40 +1,89 @@
 package main
 
 import (
+	"encoding/json"
 	"fmt"
+	"io"
 	"log"
 	"os"
+	"strings"
 	"text/tabwriter"
 
+	"github.com/codegangsta/cli"
 	"github.com/mdlayher/untappd"
 )
 
+// stdout is the destination for all printed results.  It is a variable
+// so that output can be captured in tests.
+var stdout io.Writer = os.Stdout
+
+// printResult prints v to stdout as indented JSON if the global --json flag
+// is set.  Otherwise, fn is invoked to print v in a human-friendly format.
+func printResult(ctx *cli.Context, v interface{}, fn func()) {
+	if !ctx.Bool("json") {
+		fn()
+		return
+	}
+
+	if err := writeJSON(stdout, v); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// writeJSON writes v to w as indented JSON.
+func writeJSON(w io.Writer, v interface{}) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
 // printBadges turns a slice of *untappd.Badge structs into a human-friendly
-// output format, and prints it to stdout.
-func printBadges(badges []*untappd.Badge) {
+// output format, and prints it to stdout.  The hints parameter allows each
+// badge's hint to be printed, which is the only text available for badges
+// which have not yet been earned.
+func printBadges(badges []*untappd.Badge, hints bool) {
 	tw := tabWriter()
 
+	header := "ID\tName\tEarned\tCheckinID"
+	if hints {
+		header += "\tHint"
+	}
+
 	// Print field header
-	fmt.Fprintln(tw, "ID\tName\tEarned\tCheckinID")
+	fmt.Fprintln(tw, header)
 
 	// Function to be invoked for each badge and badge level
 	printFn := func(b *untappd.Badge) {
 		y, m, d := b.Earned.Date()
 
-		fmt.Fprintf(tw, "%d\t%s\t%s\t%d\n",
+		fmt.Fprintf(tw, "%d\t%s\t%s\t%d",
 			b.ID,
 			b.Name,
 			fmt.Sprintf("%04d-%02d-%02d", y, m, d),
 			b.CheckinID,
 		)
+
+		if hints {
+			fmt.Fprintf(tw, "\t%s", b.Hint)
+		}
+
+		fmt.Fprintln(tw)
 	}
 
 	// Print out each badge
 	for _, b := range badges {
+		if b == nil {
+			continue
+		}
+
 		printFn(b)
 
 		// Print out each badge level
 		for _, bb := range b.Levels {
+			if bb == nil {
+				continue
+			}
+
 			printFn(bb)
 		}
 	}
@@ -53,12 +102,17 @@ func printBeers(beers []*untappd.Beer) {
 	// Print field header
 	fmt.Fprintln(tw, "ID\tName\tBrewery\tStyle\tABV\tIBU")
 
-	// Print out each beer
+	// Print out each beer, skipping any which are missing, and leaving
+	// the brewery cell empty if no brewery is present
 	for _, b := range beers {
+		if b == nil {
+			continue
+		}
+
 		fmt.Fprintf(tw, "%d\t%s\t%s\t%s\t%0.1f\t%03d\n",
 			b.ID,
 			b.Name,
-			b.Brewery.Name,
+			breweryName(b.Brewery),
 			b.Style,
 			b.ABV,
 			b.IBU,
@@ -77,26 +131,19 @@ func printBreweries(breweries []*untappd.Brewery) {
 	tw := tabWriter()
 
 	// Print field header
-	fmt.Fprintln(tw, "ID\tName\tLocation")
+	fmt.Fprintln(tw, "ID\tName\tType\tCountry")
 
 	// Print out each brewery
 	for _, b := range breweries {
-		// Use country as default location
-		l := b.Country
-
-		// Add extended information if available
-		if b.Location.City != "" && b.Location.State != "" {
-			l = fmt.Sprintf("%s, %s, %s",
-				b.Location.City,
-				b.Location.State,
-				b.Country,
-			)
+		if b == nil {
+			continue
 		}
 
-		fmt.Fprintf(tw, "%d\t%s\t%s\n",
+		fmt.Fprintf(tw, "%d\t%s\t%s\t%s\n",
 			b.ID,
 			b.Name,
-			l,
+			b.Type,
+			b.Country,
 		)
 	}
 
@@ -112,18 +159,23 @@ func printCheckins(checkins []*untappd.Checkin) {
 	tw := tabWriter()
 
 	// Print field header
-	fmt.Fprintln(tw, "ID\tName\tBrewery\tRating\tBadges\tToasts\tComments\tComment")
+	fmt.Fprintln(tw, "ID\tUser\tBeer\tBrewery\tVenue\tRating\tCreated\tComment")
 
-	// Print out each checkin
+	// Print out each checkin; the user, beer, brewery, and venue
+	// may not be present, so leave their cells empty if needed
 	for _, c := range checkins {
-		fmt.Fprintf(tw, "%d\t%s\t%s\t%0.2f\t%d\t%d\t%d\t%s\n",
+		if c == nil {
+			continue
+		}
+
+		fmt.Fprintf(tw, "%d\t%s\t%s\t%s\t%s\t%0.2f\t%s\t%s\n",
 			c.ID,
-			c.Beer.Name,
-			c.Brewery.Name,
+			userName(c.User),
+			beerName(c.Beer),
+			breweryName(c.Brewery),
+			venueName(c.Venue),
 			c.UserRating,
-			len(c.Badges),
-			len(c.Toasts),
-			len(c.Comments),
+			c.Created.Format("2006-01-02 15:04"),
 			c.Comment,
 		)
 	}
@@ -150,6 +202,10 @@ func printUsers(users []*untappd.User, info bool) {
 
 	// Print out each user
 	for _, u := range users {
+		if u == nil {
+			continue
+		}
+
 		fmt.Fprintf(tw, "%d\t%s\t%s %s",
 			u.UID,
 			u.UserName,
@@ -179,20 +235,19 @@ func printVenues(venues []*untappd.Venue) {
 	tw := tabWriter()
 
 	// Print field header
-	fmt.Fprintln(tw, "ID\tName\tCategory\tPublic\tLocation")
+	fmt.Fprintln(tw, "ID\tName\tLocation\tCategory")
 
-	// Print out each checkin
+	// Print out each venue
 	for _, v := range venues {
-		fmt.Fprintf(tw, "%d\t%s\t%s\t%t\t%s\n",
+		if v == nil {
+			continue
+		}
+
+		fmt.Fprintf(tw, "%d\t%s\t%s\t%s\n",
 			v.ID,
 			v.Name,
+			joinNonEmpty(", ", v.Location.City, v.Location.State),
 			v.Category,
-			v.Public,
-			fmt.Sprintf("%s, %s, %s",
-				v.Location.City,
-				v.Location.State,
-				v.Location.Country,
-			),
 		)
 	}
 
@@ -202,8 +257,56 @@ func printVenues(venues []*untappd.Venue) {
 	}
 }
 
+// userName returns the username of u, or an empty string if u is nil.
+func userName(u *untappd.User) string {
+	if u == nil {
+		return ""
+	}
+
+	return u.UserName
+}
+
+// beerName returns the name of b, or an empty string if b is nil.
+func beerName(b *untappd.Beer) string {
+	if b == nil {
+		return ""
+	}
+
+	return b.Name
+}
+
+// breweryName returns the name of b, or an empty string if b is nil.
+func breweryName(b *untappd.Brewery) string {
+	if b == nil {
+		return ""
+	}
+
+	return b.Name
+}
+
+// venueName returns the name of v, or an empty string if v is nil.
+func venueName(v *untappd.Venue) string {
+	if v == nil {
+		return ""
+	}
+
+	return v.Name
+}
+
+// joinNonEmpty joins each non-empty string in ss using sep.
+func joinNonEmpty(sep string, ss ...string) string {
+	var out []string
+	for _, s := range ss {
+		if s != "" {
+			out = append(out, s)
+		}
+	}
+
+	return strings.Join(out, sep)
+}
+
 // tabWriter returns a *tabwriter.Writer appropriately configured
 // for tabular output.
 func tabWriter() *tabwriter.Writer {
-	return tabwriter.NewWriter(os.Stdout, 0, 8, 2, '\t', 0)
+	return tabwriter.NewWriter(stdout, 0, 8, 2, '\t', 0)
 }
@@ -0,0 +1,225 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/mdlayher/untappd"
+)
+
+// Test_writeJSON verifies that writeJSON produces indented JSON which can be
+// decoded back into the original result structs.
+func Test_writeJSON(t *testing.T) {
+	label, err := url.Parse("https://untappd.akamaized.net/site/beer_logos/beer-1.jpeg")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	beers := []*untappd.Beer{{
+		ID:    1,
+		Name:  "Black Note Stout",
+		Label: *label,
+		Brewery: &untappd.Brewery{
+			Name: "Bell's Brewery, Inc.",
+		},
+	}}
+
+	buf := bytes.NewBuffer(nil)
+	if err := writeJSON(buf, beers); err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.HasPrefix(buf.Bytes(), []byte("[\n  {\n")) {
+		t.Fatalf("output is not indented JSON: %s", buf.String())
+	}
+
+	var out []*untappd.Beer
+	if err := json.Unmarshal(buf.Bytes(), &out); err != nil {
+		t.Fatal(err)
+	}
+
+	if l := len(out); l != 1 {
+		t.Fatalf("unexpected number of beers: %d != %d", l, 1)
+	}
+	if n := out[0].Name; n != beers[0].Name {
+		t.Fatalf("unexpected beer Name: %q != %q", n, beers[0].Name)
+	}
+	if n := out[0].Brewery.Name; n != beers[0].Brewery.Name {
+		t.Fatalf("unexpected beer Brewery.Name: %q != %q", n, beers[0].Brewery.Name)
+	}
+	if u := out[0].Label.String(); u != label.String() {
+		t.Fatalf("unexpected beer Label: %q != %q", u, label.String())
+	}
+}
+
+// Test_printCheckinsNilFields verifies that printCheckins does not panic when
+// a checkin is missing its user, beer, brewery, or venue.
+func Test_printCheckinsNilFields(t *testing.T) {
+	out := capture(func() {
+		printCheckins([]*untappd.Checkin{{
+			ID:      1,
+			Comment: "hello",
+		}})
+	})
+
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	if l := len(lines); l != 2 {
+		t.Fatalf("unexpected number of lines: %d != %d\n%s", l, 2, out)
+	}
+	if !strings.HasPrefix(lines[1], "1") || !strings.HasSuffix(lines[1], "hello") {
+		t.Fatalf("unexpected checkin row: %q", lines[1])
+	}
+}
+
+// Test_printBreweriesVenues verifies the columns printed by printBreweries
+// and printVenues.
+func Test_printBreweriesVenues(t *testing.T) {
+	out := capture(func() {
+		printBreweries([]*untappd.Brewery{{
+			ID:      1,
+			Name:    "Bell's Brewery, Inc.",
+			Type:    "Regional Brewery",
+			Country: "United States",
+		}})
+	})
+	for _, s := range []string{"Bell's Brewery, Inc.", "Regional Brewery", "United States"} {
+		if !strings.Contains(out, s) {
+			t.Fatalf("brewery output missing %q:\n%s", s, out)
+		}
+	}
+
+	var v untappd.Venue
+	v.ID = 1
+	v.Name = "Brooklyn Bowl"
+	v.Category = "Bowling Alley"
+	v.Location.City = "Brooklyn"
+
+	out = capture(func() {
+		printVenues([]*untappd.Venue{&v})
+	})
+	for _, s := range []string{"Brooklyn Bowl", "Brooklyn\t", "Bowling Alley"} {
+		if !strings.Contains(out, s) {
+			t.Fatalf("venue output missing %q:\n%s", s, out)
+		}
+	}
+}
+
+// Test_printBeersNilBrewery verifies that printBeers leaves the brewery
+// column empty when a beer has no brewery.
+func Test_printBeersNilBrewery(t *testing.T) {
+	out := capture(func() {
+		printBeers([]*untappd.Beer{{
+			ID:    1,
+			Name:  "Homebrew",
+			Style: "Stout",
+		}})
+	})
+
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	if l := len(lines); l != 2 {
+		t.Fatalf("unexpected number of lines: %d != %d\n%s", l, 2, out)
+	}
+
+	// Tabs are also used for padding, so compare the non-empty cells;
+	// the brewery cell is empty
+	want := []string{"1", "Homebrew", "Stout", "0.0", "000"}
+	if got := strings.Fields(lines[1]); !reflect.DeepEqual(got, want) {
+		t.Fatalf("unexpected beer row: %q != %q", got, want)
+	}
+}
+
+// Test_printBadgesHints verifies that printBadges only prints a hint column
+// when hints are requested.
+func Test_printBadgesHints(t *testing.T) {
+	badges := []*untappd.Badge{{
+		ID:          1,
+		Name:        "Taste the Music",
+		Description: "You've been to a concert venue.",
+		Hint:        "Check in at a concert venue.",
+	}}
+
+	for _, hints := range []bool{false, true} {
+		out := capture(func() {
+			printBadges(badges, hints)
+		})
+
+		if got := strings.Contains(out, "Hint"); got != hints {
+			t.Fatalf("unexpected presence of hint header with hints %v: %v\n%s", hints, got, out)
+		}
+		if got := strings.Contains(out, badges[0].Hint); got != hints {
+			t.Fatalf("unexpected presence of hint with hints %v: %v\n%s", hints, got, out)
+		}
+		if strings.Contains(out, badges[0].Description) {
+			t.Fatalf("unexpected description in output:\n%s", out)
+		}
+	}
+}
+
+// Test_printNilEntries verifies that each print helper skips nil entries
+// rather than panicking, printing only its header.
+func Test_printNilEntries(t *testing.T) {
+	var tests = []struct {
+		description string
+		fn          func()
+		lines       int
+	}{
+		{
+			// The non-nil badge is printed, but its nil level is not
+			description: "badges",
+			fn: func() {
+				printBadges([]*untappd.Badge{nil, {Levels: []*untappd.Badge{nil}}}, true)
+			},
+			lines: 2,
+		},
+		{
+			description: "beers",
+			fn:          func() { printBeers([]*untappd.Beer{nil}) },
+			lines:       1,
+		},
+		{
+			description: "breweries",
+			fn:          func() { printBreweries([]*untappd.Brewery{nil}) },
+			lines:       1,
+		},
+		{
+			description: "checkins",
+			fn:          func() { printCheckins([]*untappd.Checkin{nil}) },
+			lines:       1,
+		},
+		{
+			description: "users",
+			fn:          func() { printUsers([]*untappd.User{nil}, true) },
+			lines:       1,
+		},
+		{
+			description: "venues",
+			fn:          func() { printVenues([]*untappd.Venue{nil}) },
+			lines:       1,
+		},
+	}
+
+	for _, tt := range tests {
+		out := capture(tt.fn)
+
+		lines := strings.Split(strings.TrimSpace(out), "\n")
+		if l := len(lines); l != tt.lines {
+			t.Fatalf("unexpected number of lines for test %q: %d != %d\n%s", tt.description, l, tt.lines, out)
+		}
+	}
+}
+
+// capture invokes fn and returns everything it printed to stdout.
+func capture(fn func()) string {
+	buf := bytes.NewBuffer(nil)
+
+	old := stdout
+	stdout = buf
+	defer func() { stdout = old }()
+
+	fn()
+	return buf.String()
+}
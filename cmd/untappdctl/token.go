@@ -0,0 +1,60 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+)
+
+// tokenFile is the JSON structure used to persist an access token obtained
+// by the OAuth authentication flow, so it can be reused by later commands.
+type tokenFile struct {
+	AccessToken string `json:"access_token"`
+}
+
+// writeTokenFile writes an access token to the file at path, readable and
+// writable only by the current user.
+func writeTokenFile(path string, token string) error {
+	b, err := json.MarshalIndent(tokenFile{AccessToken: token}, "", "\t")
+	if err != nil {
+		return err
+	}
+
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return err
+	}
+
+	// Tighten permissions on any pre-existing file, since OpenFile only
+	// applies the mode when the file is created
+	if err := f.Chmod(0600); err != nil {
+		_ = f.Close()
+		return err
+	}
+
+	if _, err := f.Write(append(b, '\n')); err != nil {
+		_ = f.Close()
+		return err
+	}
+
+	return f.Close()
+}
+
+// readTokenFile reads an access token from the file at path, as written
+// by writeTokenFile.
+func readTokenFile(path string) (string, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+
+	var tf tokenFile
+	if err := json.Unmarshal(b, &tf); err != nil {
+		return "", err
+	}
+	if tf.AccessToken == "" {
+		return "", errors.New("no access token in token file: " + path)
+	}
+
+	return tf.AccessToken, nil
+}
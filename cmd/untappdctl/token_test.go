@@ -0,0 +1,70 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// Test_readTokenFile verifies that an access token written by writeTokenFile
+// is read back by readTokenFile, and that the file is private to its owner.
+func Test_readTokenFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "token.json")
+
+	const token = "foo"
+	if err := writeTokenFile(path, token); err != nil {
+		t.Fatal(err)
+	}
+
+	fi, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := fi.Mode().Perm(), os.FileMode(0600); got != want {
+		t.Fatalf("unexpected token file permissions: %v != %v", got, want)
+	}
+
+	got, err := readTokenFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != token {
+		t.Fatalf("unexpected token: %q != %q", got, token)
+	}
+}
+
+// Test_readTokenFileErrors verifies that readTokenFile returns an error for
+// missing, malformed, or empty token files.
+func Test_readTokenFileErrors(t *testing.T) {
+	dir := t.TempDir()
+
+	var tests = []struct {
+		description string
+		body        string
+	}{
+		{
+			description: "missing",
+		},
+		{
+			description: "malformed",
+			body:        "{",
+		},
+		{
+			description: "empty token",
+			body:        `{"access_token":""}`,
+		},
+	}
+
+	for _, tt := range tests {
+		path := filepath.Join(dir, tt.description)
+		if tt.body != "" {
+			if err := os.WriteFile(path, []byte(tt.body), 0600); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		if _, err := readTokenFile(path); err == nil {
+			t.Fatalf("expected an error for test %q", tt.description)
+		}
+	}
+}
@@ -35,6 +35,10 @@ func userBadgesCommand(offsetFlag, limitFlag *cli.IntFlag) *cli.Command {
 		Flags: []cli.Flag{
 			offsetFlag,
 			limitFlag,
+			&cli.BoolFlag{
+				Name:  "hints",
+				Usage: "print a hint describing how each badge is earned",
+			},
 		},
 
 		Action: func(ctx *cli.Context) error {
@@ -53,8 +57,8 @@ func userBadgesCommand(offsetFlag, limitFlag *cli.IntFlag) *cli.Command {
 				log.Fatal(err)
 			}
 
-			// Print out badges in human-readable format
-			printBadges(badges)
+			// Print out badges in human-readable or JSON format
+			printResult(ctx, badges, func() { printBadges(badges, ctx.Bool("hints")) })
 			return nil
 		},
 	}
@@ -90,8 +94,8 @@ func userBeersCommand(offsetFlag, limitFlag *cli.IntFlag, sortFlag *cli.StringFl
 				log.Fatal(err)
 			}
 
-			// Print out beers in human-readable format
-			printBeers(beers)
+			// Print out beers in human-readable or JSON format
+			printResult(ctx, beers, func() { printBeers(beers) })
 			return nil
 		},
 	}
@@ -111,7 +115,7 @@ func userCheckinsCommand(limitFlag, minIDFlag, maxIDFlag *cli.IntFlag) *cli.Comm
 		},
 
 		Action: func(ctx *cli.Context) error {
-			minID, maxID, limit := ctx.Int("min_id"), ctx.Int("max_id"), ctx.Int("limit")
+			minID, maxID, limit := int64(ctx.Int("min_id")), int64(ctx.Int("max_id")), ctx.Int("limit")
 
 			// Query for user's checkins by username, e.g.
 			// "untappdctl user checkins mdlayher"
@@ -127,8 +131,8 @@ func userCheckinsCommand(limitFlag, minIDFlag, maxIDFlag *cli.IntFlag) *cli.Comm
 				log.Fatal(err)
 			}
 
-			// Print out checkins in human-readable format
-			printCheckins(checkins)
+			// Print out checkins in human-readable or JSON format
+			printResult(ctx, checkins, func() { printCheckins(checkins) })
 			return nil
 		},
 	}
@@ -162,8 +166,8 @@ func userFriendsCommand(offsetFlag, limitFlag *cli.IntFlag) *cli.Command {
 				log.Fatal(err)
 			}
 
-			// Print out users in human-readable format
-			printUsers(friends, false)
+			// Print out users in human-readable or JSON format
+			printResult(ctx, friends, func() { printUsers(friends, false) })
 			return nil
 		},
 	}
@@ -186,8 +190,9 @@ func userInfoCommand() *cli.Command {
 				log.Fatal(err)
 			}
 
-			// Print out user in human-readable format
-			printUsers([]*untappd.User{user}, true)
+			// Print out user in human-readable or JSON format
+			users := []*untappd.User{user}
+			printResult(ctx, users, func() { printUsers(users, true) })
 			return nil
 		},
 	}
@@ -223,8 +228,8 @@ func userWishListCommand(offsetFlag, limitFlag *cli.IntFlag, sortFlag *cli.Strin
 				log.Fatal(err)
 			}
 
-			// Print out beers in human-readable format
-			printBeers(beers)
+			// Print out beers in human-readable or JSON format
+			printResult(ctx, beers, func() { printBeers(beers) })
 			return nil
 		},
 	}
@@ -37,10 +37,10 @@ func venueCheckinsCommand(limitFlag, minIDFlag, maxIDFlag *cli.IntFlag) *cli.Com
 
 		Action: func(ctx *cli.Context) error {
 			// Check for valid integer ID
-			id, err := strconv.Atoi(mustStringArg(ctx, "venue ID"))
+			id, err := strconv.ParseInt(mustStringArg(ctx, "venue ID"), 10, 64)
 			checkAtoiError(err)
 
-			minID, maxID, limit := ctx.Int("min_id"), ctx.Int("max_id"), ctx.Int("limit")
+			minID, maxID, limit := int64(ctx.Int("min_id")), int64(ctx.Int("max_id")), ctx.Int("limit")
 
 			// Query for venue's checkins by venue ID, e.g.
 			// "untappdctl venue checkins 1"
@@ -56,8 +56,8 @@ func venueCheckinsCommand(limitFlag, minIDFlag, maxIDFlag *cli.IntFlag) *cli.Com
 				log.Fatal(err)
 			}
 
-			// Print out checkins in human-readable format
-			printCheckins(checkins)
+			// Print out checkins in human-readable or JSON format
+			printResult(ctx, checkins, func() { printCheckins(checkins) })
 			return nil
 		},
 	}
@@ -73,7 +73,7 @@ func venueInfoCommand() *cli.Command {
 
 		Action: func(ctx *cli.Context) error {
 			// Check for valid integer ID
-			id, err := strconv.Atoi(mustStringArg(ctx, "venue ID"))
+			id, err := strconv.ParseInt(mustStringArg(ctx, "venue ID"), 10, 64)
 			checkAtoiError(err)
 
 			// Query for venue by ID, e.g. "untappdctl venue info 1"
@@ -84,8 +84,9 @@ func venueInfoCommand() *cli.Command {
 				log.Fatal(err)
 			}
 
-			// Print out venue in human-readable format
-			printVenues([]*untappd.Venue{venue})
+			// Print out venue in human-readable or JSON format
+			venues := []*untappd.Venue{venue}
+			printResult(ctx, venues, func() { printVenues(venues) })
 			return nil
 		},
 	}
@@ -9,24 +9,24 @@ import (
 // the comment.
 type Comment struct {
 	// Metadata from Untappd.
-	ID        int
-	CheckinID int
+	ID        int64 `json:"id"`
+	CheckinID int64 `json:"checkin_id"`
 
 	// The actual comment about a Checkin.
-	Comment string
+	Comment string `json:"comment"`
 
 	// Time when this comment was submitted to Untappd.
-	Created time.Time
+	Created time.Time `json:"created"`
 
 	// The user who submitted the Comment.
-	User *User
+	User *User `json:"user"`
 }
 
 // rawComment is the raw JSON representation of an Untappd toast.  Its data is
 // unmarshaled from JSON and then exported to a Comment struct.
 type rawComment struct {
-	ID        int          `json:"comment_id"`
-	CheckinID int          `json:"checkin_id"`
+	ID        int64        `json:"comment_id"`
+	CheckinID int64        `json:"checkin_id"`
 	Comment   string       `json:"comment"`
 	Created   responseTime `json:"created_at"`
 	User      *rawUser     `json:"user"`
@@ -0,0 +1,126 @@
+package untappd
+
+import (
+	"context"
+	"time"
+)
+
+// feedPollerLimit is the number of checkins requested by a FeedPoller on
+// each poll: the maximum allowed by the activity feed.
+const feedPollerLimit = MaxUserCheckinsLimit
+
+// FeedPoller periodically polls an authenticated user's friend activity
+// feed, as returned by Client.Auth.CheckinsMinMaxIDLimit, and invokes a
+// callback for each checkin which has not been seen before.
+//
+// A FeedPoller keeps track of the highest checkin ID it has seen, so that
+// each poll only requests checkins which are newer than the previous poll.
+type FeedPoller struct {
+	auth     *AuthService
+	interval time.Duration
+	fn       func(c *Checkin)
+	errFn    func(err error)
+
+	sinceID int64
+}
+
+// NewFeedPoller creates a FeedPoller which polls the activity feed of the
+// authenticated user of client, once every interval.
+//
+// fn is invoked once for each new checkin, in the order in which checkins
+// were created.  The first poll delivers the checkins currently present
+// in the feed.
+//
+// If errFn is not nil, it is invoked with any errors which occur while
+// polling.  Errors do not stop the FeedPoller; polling resumes at the next
+// interval.
+func NewFeedPoller(client *Client, interval time.Duration, fn func(c *Checkin), errFn func(err error)) *FeedPoller {
+	return &FeedPoller{
+		auth:     &AuthService{client: client},
+		interval: interval,
+		fn:       fn,
+		errFn:    errFn,
+	}
+}
+
+// Run begins polling immediately, and continues polling until ctx is
+// canceled.  Canceling ctx also aborts any request which is in progress.
+// Run always returns the error from ctx.
+func (p *FeedPoller) Run(ctx context.Context) error {
+	t := time.NewTicker(p.interval)
+	defer t.Stop()
+
+	for {
+		p.poll(ctx)
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-t.C:
+		}
+	}
+}
+
+// poll requests all checkins newer than the highest checkin ID seen so far,
+// and invokes p.fn for each new checkin.
+//
+// If more than one page of checkins arrived since the previous poll, poll
+// pages backwards through the feed until it reaches the checkins which were
+// already seen, so that no checkins are skipped.  The first poll only
+// requests a single page.
+func (p *FeedPoller) poll(ctx context.Context) {
+	var (
+		checkins []*Checkin
+		maxID    int64
+	)
+
+	for {
+		page, items, _, err := p.auth.checkinsPage(ctx, p.sinceID, maxID, feedPollerLimit)
+		if err != nil {
+			// Errors caused by stopping the poller are not reported
+			if p.errFn != nil && ctx.Err() == nil {
+				p.errFn(err)
+			}
+			return
+		}
+
+		// The minimum ID parameter is inclusive, so skip any checkins
+		// which were already delivered by a previous poll
+		var (
+			oldest int64
+			seen   bool
+		)
+		for _, c := range page {
+			if oldest == 0 || c.ID < oldest {
+				oldest = c.ID
+			}
+			if c.ID <= p.sinceID {
+				seen = true
+				continue
+			}
+
+			checkins = append(checkins, c)
+		}
+
+		// Stop once the previously seen checkins are reached, no more
+		// checkins remain, or the cursor would not move
+		if p.sinceID == 0 || seen || items < feedPollerLimit || oldest <= 1 || (maxID != 0 && oldest > maxID) {
+			break
+		}
+
+		// Continue from the checkin before the oldest in this page
+		maxID = oldest - 1
+	}
+
+	// Checkins are returned newest first, so iterate in reverse to deliver
+	// them in the order in which they were created
+	checkins = DedupCheckins(checkins)
+	for i := len(checkins) - 1; i >= 0; i-- {
+		c := checkins[i]
+		p.fn(c)
+
+		if c.ID > p.sinceID {
+			p.sinceID = c.ID
+		}
+	}
+}
@@ -0,0 +1,209 @@
+package untappd
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// TestFeedPollerOK verifies that a FeedPoller requests checkins newer than
+// the highest ID it has seen, and only delivers each checkin once.
+func TestFeedPollerOK(t *testing.T) {
+	var (
+		mu    sync.Mutex
+		polls int
+	)
+
+	c, done := testClient(t, func(t *testing.T, w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		polls++
+
+		switch polls {
+		case 1:
+			if got, want := r.URL.Query().Get("min_id"), ""; got != want {
+				t.Errorf("unexpected min_id for first poll: %q != %q", got, want)
+			}
+
+			w.Write([]byte(`{"response":{"checkins":{"count":2,"items":[{"checkin_id":2},{"checkin_id":1}]}}}`))
+		case 2:
+			if got, want := r.URL.Query().Get("min_id"), "2"; got != want {
+				t.Errorf("unexpected min_id for second poll: %q != %q", got, want)
+			}
+
+			// Checkin 2 is returned again, and must not be delivered twice
+			w.Write([]byte(`{"response":{"checkins":{"count":2,"items":[{"checkin_id":3},{"checkin_id":2}]}}}`))
+		default:
+			w.Write([]byte("{}"))
+		}
+	})
+	defer done()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var ids []int64
+	p := NewFeedPoller(c, 10*time.Millisecond, func(c *Checkin) {
+		ids = append(ids, c.ID)
+		if len(ids) == 3 {
+			cancel()
+		}
+	}, func(err error) {
+		t.Errorf("unexpected poll error: %v", err)
+	})
+
+	errC := make(chan error, 1)
+	go func() {
+		errC <- p.Run(ctx)
+	}()
+
+	select {
+	case err := <-errC:
+		if err != context.Canceled {
+			t.Fatalf("unexpected error: %v != %v", err, context.Canceled)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("poller did not stop")
+	}
+
+	if want := []int64{1, 2, 3}; !reflect.DeepEqual(ids, want) {
+		t.Fatalf("unexpected checkin IDs: %v != %v", ids, want)
+	}
+}
+
+// TestFeedPollerPagesBackwards verifies that a FeedPoller pages backwards
+// through the feed when more than one page of checkins arrives between
+// polls, so that no checkins are skipped.
+func TestFeedPollerPagesBackwards(t *testing.T) {
+	const newest = 102
+
+	var (
+		mu    sync.Mutex
+		polls int
+	)
+
+	c, done := testClient(t, func(t *testing.T, w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		polls++
+
+		// Only checkins 1 and 2 exist during the first poll
+		if polls == 1 {
+			w.Write([]byte(`{"response":{"checkins":{"count":2,"items":[{"checkin_id":2},{"checkin_id":1}]}}}`))
+			return
+		}
+
+		q := r.URL.Query()
+		minID, err := strconv.ParseInt(q.Get("min_id"), 10, 64)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+
+		// No max_id is sent for the first page of each poll
+		maxID := int64(newest)
+		if s := q.Get("max_id"); s != "" {
+			maxID, err = strconv.ParseInt(s, 10, 64)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+		}
+		limit, err := strconv.Atoi(q.Get("limit"))
+		if err != nil {
+			t.Error(err)
+			return
+		}
+
+		var items []string
+		for id := int64(math.Min(float64(maxID), newest)); id >= minID && len(items) < limit; id-- {
+			items = append(items, fmt.Sprintf(`{"checkin_id":%d}`, id))
+		}
+
+		fmt.Fprintf(w, `{"response":{"checkins":{"count":%d,"items":[%s]}}}`,
+			len(items), strings.Join(items, ","))
+	})
+	defer done()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var ids []int64
+	p := NewFeedPoller(c, 10*time.Millisecond, func(c *Checkin) {
+		ids = append(ids, c.ID)
+		if len(ids) == newest {
+			cancel()
+		}
+	}, func(err error) {
+		t.Errorf("unexpected poll error: %v", err)
+	})
+
+	errC := make(chan error, 1)
+	go func() {
+		errC <- p.Run(ctx)
+	}()
+
+	select {
+	case <-errC:
+	case <-time.After(5 * time.Second):
+		t.Fatal("poller did not stop")
+	}
+
+	for i, id := range ids {
+		if want := int64(i + 1); id != want {
+			t.Fatalf("unexpected checkin ID at index %d: %d != %d", i, id, want)
+		}
+	}
+	if l := len(ids); l != newest {
+		t.Fatalf("unexpected number of checkins: %d != %d", l, newest)
+	}
+
+	// One request for the first poll, and three to page back to checkin 2
+	mu.Lock()
+	defer mu.Unlock()
+	if polls != 4 {
+		t.Fatalf("unexpected number of requests: %d != %d", polls, 4)
+	}
+}
+
+// TestFeedPollerCancelRequest verifies that canceling the context passed to
+// FeedPoller.Run aborts a request which is in progress.
+func TestFeedPollerCancelRequest(t *testing.T) {
+	c, done := testClient(t, func(t *testing.T, w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(5 * time.Second):
+		}
+	})
+	defer done()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	p := NewFeedPoller(c, time.Hour, func(c *Checkin) {
+		t.Errorf("unexpected checkin: %+v", c)
+	}, func(err error) {
+		t.Errorf("unexpected poll error: %v", err)
+	})
+
+	errC := make(chan error, 1)
+	go func() {
+		errC <- p.Run(ctx)
+	}()
+
+	select {
+	case err := <-errC:
+		if err != context.DeadlineExceeded {
+			t.Fatalf("unexpected error: %v != %v", err, context.DeadlineExceeded)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("poller did not abort its request")
+	}
+}
@@ -0,0 +1,67 @@
+package untappd
+
+import "sync"
+
+// foursquareCategories maps Foursquare venue category IDs to their names.
+// It is seeded with categories commonly used by venues on Untappd, and may
+// be extended using RegisterFoursquareCategory.
+var foursquareCategories = struct {
+	mu    sync.RWMutex
+	names map[string]string
+}{
+	names: map[string]string{
+		// Top-level categories
+		"4d4b7104d754a06370d81259": "Arts & Entertainment",
+		"4d4b7105d754a06374d81259": "Food",
+		"4d4b7105d754a06376d81259": "Nightlife Spot",
+
+		// Bars and breweries
+		"4bf58dd8d48988d116941735": "Bar",
+		"56aa371ce4b08b9a8d57356c": "Beer Bar",
+		"4bf58dd8d48988d117941735": "Beer Garden",
+		"50327c8591d4c4b30a586d5d": "Brewery",
+		"4bf58dd8d48988d118941735": "Dive Bar",
+		"4bf58dd8d48988d11b941735": "Pub",
+		"4bf58dd8d48988d11d941735": "Sports Bar",
+		"4bf58dd8d48988d123941735": "Wine Bar",
+
+		// Restaurants
+		"4bf58dd8d48988d155941735": "Gastropub",
+		"4bf58dd8d48988d1ca941735": "Pizza Place",
+		"4bf58dd8d48988d1c4941735": "Restaurant",
+
+		// Shops
+		"5370f356bcbc57f1066c94c2": "Beer Store",
+		"4bf58dd8d48988d186941735": "Liquor Store",
+
+		// Entertainment
+		"4bf58dd8d48988d1e4931735": "Bowling Alley",
+		"4bf58dd8d48988d1e5931735": "Music Venue",
+	},
+}
+
+// FoursquareCategoryName returns the name of the Foursquare venue category
+// with the specified ID, such as "Bar" for "4bf58dd8d48988d116941735".
+//
+// Only a partial table of categories commonly used by venues on Untappd is
+// included.  If the category is unknown, ok is false.  Additional categories
+// may be added using RegisterFoursquareCategory.
+func FoursquareCategoryName(id string) (name string, ok bool) {
+	foursquareCategories.mu.RLock()
+	defer foursquareCategories.mu.RUnlock()
+
+	name, ok = foursquareCategories.names[id]
+	return name, ok
+}
+
+// RegisterFoursquareCategory adds or replaces the name of the Foursquare
+// venue category with the specified ID, for use by FoursquareCategoryName.
+//
+// RegisterFoursquareCategory is safe for concurrent use, but is typically
+// called during program initialization.
+func RegisterFoursquareCategory(id string, name string) {
+	foursquareCategories.mu.Lock()
+	defer foursquareCategories.mu.Unlock()
+
+	foursquareCategories.names[id] = name
+}
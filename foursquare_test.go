@@ -0,0 +1,56 @@
+package untappd
+
+import "testing"
+
+// TestFoursquareCategoryName verifies that FoursquareCategoryName returns the
+// names of known Foursquare categories, and reports unknown categories.
+func TestFoursquareCategoryName(t *testing.T) {
+	var tests = []struct {
+		id   string
+		name string
+		ok   bool
+	}{
+		{
+			id:   "4bf58dd8d48988d116941735",
+			name: "Bar",
+			ok:   true,
+		},
+		{
+			id:   "50327c8591d4c4b30a586d5d",
+			name: "Brewery",
+			ok:   true,
+		},
+		{
+			id: "foo",
+		},
+	}
+
+	for _, tt := range tests {
+		name, ok := FoursquareCategoryName(tt.id)
+		if ok != tt.ok {
+			t.Fatalf("unexpected ok for ID %q: %v != %v", tt.id, ok, tt.ok)
+		}
+		if name != tt.name {
+			t.Fatalf("unexpected name for ID %q: %q != %q", tt.id, name, tt.name)
+		}
+	}
+}
+
+// TestRegisterFoursquareCategory verifies that RegisterFoursquareCategory
+// adds categories for use by FoursquareCategoryName.
+func TestRegisterFoursquareCategory(t *testing.T) {
+	const id = "untappd-test-category"
+	if _, ok := FoursquareCategoryName(id); ok {
+		t.Fatalf("category %q should not be registered", id)
+	}
+
+	RegisterFoursquareCategory(id, "Test Category")
+
+	name, ok := FoursquareCategoryName(id)
+	if !ok {
+		t.Fatalf("category %q should be registered", id)
+	}
+	if want := "Test Category"; name != want {
+		t.Fatalf("unexpected name: %q != %q", name, want)
+	}
+}
@@ -0,0 +1,52 @@
+package untappd
+
+import "errors"
+
+// ErrInvalidLimit is returned when a limit which is negative, or which
+// exceeds the maximum number of results allowed by an Untappd APIv4 endpoint,
+// is passed to a method which accepts a limit.
+var ErrInvalidLimit = errors.New("invalid limit")
+
+// Constants that define the maximum number of results which may be returned
+// by one call to various Untappd APIv4 endpoints.  Methods which accept a
+// limit return ErrInvalidLimit for a limit which exceeds the appropriate
+// maximum, without performing a request.
+const (
+	// MaxCheckinsLimit is the maximum number of checkins which may be
+	// returned by one call to a Beer, Brewery, Venue, or Local checkins feed.
+	MaxCheckinsLimit = 25
+
+	// MaxUserCheckinsLimit is the maximum number of checkins which may be
+	// returned by one call to a User or authenticated user's checkins feed.
+	MaxUserCheckinsLimit = 50
+
+	// MaxBeersLimit is the maximum number of beers which may be returned
+	// by one call to a beer search, or to a User's beers or wish list.
+	MaxBeersLimit = 50
+
+	// MaxBreweriesLimit is the maximum number of breweries which may be
+	// returned by one call to a brewery search.
+	MaxBreweriesLimit = 50
+
+	// MaxBadgesLimit is the maximum number of badges which may be returned
+	// by one call to a User's badges.
+	MaxBadgesLimit = 50
+
+	// MaxFriendsLimit is the maximum number of friends which may be returned
+	// by one call to a User's friends.
+	MaxFriendsLimit = 25
+)
+
+// checkLimit returns ErrInvalidLimit if limit is negative or exceeds max.
+// A limit of zero is permitted, and indicates the API's default limit.
+//
+// Limits are checked by the client, because the Untappd APIv4 silently
+// clamps or rejects limits outside of the range it allows, which would
+// otherwise make paging through results unreliable.
+func checkLimit(limit int, max int) error {
+	if limit < 0 || limit > max {
+		return ErrInvalidLimit
+	}
+
+	return nil
+}
@@ -0,0 +1,48 @@
+package untappd
+
+import "testing"
+
+// Test_checkLimit verifies that checkLimit accepts limits within the range
+// allowed by an endpoint, and rejects all others.
+func Test_checkLimit(t *testing.T) {
+	tests := []struct {
+		desc  string
+		limit int
+		max   int
+		err   error
+	}{
+		{
+			desc:  "zero uses API default",
+			limit: 0,
+			max:   MaxCheckinsLimit,
+		},
+		{
+			desc:  "in range",
+			limit: 10,
+			max:   MaxCheckinsLimit,
+		},
+		{
+			desc:  "at maximum",
+			limit: MaxBeersLimit,
+			max:   MaxBeersLimit,
+		},
+		{
+			desc:  "over maximum",
+			limit: MaxCheckinsLimit + 1,
+			max:   MaxCheckinsLimit,
+			err:   ErrInvalidLimit,
+		},
+		{
+			desc:  "negative",
+			limit: -1,
+			max:   MaxBeersLimit,
+			err:   ErrInvalidLimit,
+		},
+	}
+
+	for _, tt := range tests {
+		if err := checkLimit(tt.limit, tt.max); err != tt.err {
+			t.Fatalf("[%s] unexpected error: %v != %v", tt.desc, err, tt.err)
+		}
+	}
+}
@@ -1,5 +1,17 @@
 package untappd
 
+import "errors"
+
+// ErrInvalidDistance is returned when a Distance which is not returned by
+// Distances is passed to a method which accepts a Distance parameter.
+// Unknown units are rejected without performing a request, because the
+// Untappd APIv4 would otherwise silently interpret the radius incorrectly.
+var ErrInvalidDistance = errors.New("invalid distance")
+
+// ErrInvalidBounds is returned when the minimum latitude or longitude of a
+// bounding box exceeds its maximum.
+var ErrInvalidBounds = errors.New("invalid bounding box")
+
 // Distance is a distance unit accepted by the Untappd APIv4.
 // A set of Distance constants are provided for ease of use.
 type Distance string
@@ -12,6 +24,87 @@ const (
 	DistanceKilometers Distance = "km"
 )
 
+// Constants that define the maximum radius accepted by the Untappd APIv4
+// when querying for local checkins, for each Distance unit.  Larger radii
+// are clamped to these values by LocalService methods.
+const (
+	// MaxLocalRadiusMiles is the maximum radius in miles.
+	MaxLocalRadiusMiles = 25
+
+	// MaxLocalRadiusKilometers is the maximum radius in kilometers.
+	MaxLocalRadiusKilometers = 40
+)
+
+// Distances returns a slice of all available Distance constants.
+func Distances() []Distance {
+	return []Distance{
+		DistanceMiles,
+		DistanceKilometers,
+	}
+}
+
+// kilometersPerMile is the number of kilometers in one international mile.
+const kilometersPerMile = 1.609344
+
+// MilesToKm converts a distance in miles to kilometers.
+func MilesToKm(miles float64) float64 {
+	return miles * kilometersPerMile
+}
+
+// KmToMiles converts a distance in kilometers to miles.
+func KmToMiles(km float64) float64 {
+	return km / kilometersPerMile
+}
+
+// Convert converts value, a distance in units of d, to units of to.  As with
+// LocalCheckinsRequest, an empty Distance is treated as miles.  If d and to
+// are the same unit, or either is not one of the values returned by Distances,
+// value is returned unchanged.
+func (d Distance) Convert(value float64, to Distance) float64 {
+	from := d.orMiles()
+	to = to.orMiles()
+	if from == to || !from.valid() || !to.valid() {
+		return value
+	}
+
+	if from == DistanceMiles {
+		return MilesToKm(value)
+	}
+
+	return KmToMiles(value)
+}
+
+// orMiles returns d, or DistanceMiles if d is empty.
+func (d Distance) orMiles() Distance {
+	if d == "" {
+		return DistanceMiles
+	}
+
+	return d
+}
+
+// maxRadius returns the maximum radius accepted by the Untappd APIv4 for
+// local checkins, in units of d.  An empty Distance is treated as miles.
+func (d Distance) maxRadius() int {
+	if d.orMiles() == DistanceKilometers {
+		return MaxLocalRadiusKilometers
+	}
+
+	return MaxLocalRadiusMiles
+}
+
+// valid determines if d is one of the Distance constants returned by
+// Distances.
+func (d Distance) valid() bool {
+	for _, dd := range Distances() {
+		if d == dd {
+			return true
+		}
+	}
+
+	return false
+}
+
 // LocalService is a "service" which allows access to API methods involving checkins
 // in a localized area.
 type LocalService struct {
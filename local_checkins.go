@@ -1,6 +1,7 @@
 package untappd
 
 import (
+	"math"
 	"net/http"
 	"net/url"
 	"strconv"
@@ -17,14 +18,17 @@ type LocalCheckinsRequest struct {
 	// Optional parameters
 
 	// Minimum and maximum checkin IDs to query
-	MinID int
-	MaxID int
+	MinID int64
+	MaxID int64
 
-	// Maximum number of results to return
-	Limit int
+	// Number of results to skip, and maximum number of results to return
+	Offset int
+	Limit  int
 
 	// Distance radius from latitude/longitude pair, and units
-	// for the radius
+	// for the radius.  If Units is empty, the radius is in miles.  A
+	// radius greater than MaxLocalRadiusMiles or MaxLocalRadiusKilometers,
+	// for its units, is clamped to that maximum.
 	Radius int
 	Units  Distance
 }
@@ -32,8 +36,8 @@ type LocalCheckinsRequest struct {
 // Checkins queries for information about checkins in a local area, specified
 // by latitude and longitude.
 //
-// This method returns up to 25 of a local area's most recent checkins within
-// a distance of 25 miles.
+// This method returns up to Client.DefaultLimit of a local area's most recent
+// checkins within a distance of 25 miles.
 // For more granular control, and to page through the checkins list using ID
 // parameters, use CheckinsMinMaxIDLimitRadius instead.
 func (l *LocalService) Checkins(latitude float64, longitude float64) ([]*Checkin, *http.Response, error) {
@@ -41,9 +45,9 @@ func (l *LocalService) Checkins(latitude float64, longitude float64) ([]*Checkin
 		Latitude:  latitude,
 		Longitude: longitude,
 
-		Limit: 25,
+		Limit: l.client.limit(MaxCheckinsLimit),
 
-		Radius: 25,
+		Radius: MaxLocalRadiusMiles,
 		Units:  DistanceMiles,
 	})
 }
@@ -54,27 +58,51 @@ func (l *LocalService) Checkins(latitude float64, longitude float64) ([]*Checkin
 // local area where recent checkins will be queried.
 //
 // 25 checkins is the maximum number of checkins which may be returned by
-// one call.
+// one call.  If r.Limit exceeds MaxCheckinsLimit, ErrInvalidLimit is returned.
+//
+// If r.Units is not empty and is not one of the values returned by Distances,
+// ErrInvalidDistance is returned and no request is performed.  If r.Radius
+// exceeds the maximum radius for r.Units, the maximum is used instead, and
+// the adjustment is logged using the logger configured by WithLogger.
 func (l *LocalService) CheckinsMinMaxIDLimitRadius(r LocalCheckinsRequest) ([]*Checkin, *http.Response, error) {
+	if r.Units != "" && !r.Units.valid() {
+		return nil, nil, ErrInvalidDistance
+	}
+
+	if err := checkLimit(r.Limit, MaxCheckinsLimit); err != nil {
+		return nil, nil, err
+	}
+
 	// Add required parameters
 	q := url.Values{
-		"lat": []string{formatFloat(r.Latitude)},
-		"lng": []string{formatFloat(r.Longitude)},
+		"lat": []string{formatCoord(r.Latitude)},
+		"lng": []string{formatCoord(r.Longitude)},
 	}
 
 	// Add optional parameters, if not empty
 	if r.MinID != 0 {
-		q.Set("min_id", strconv.Itoa(r.MinID))
+		q.Set("min_id", strconv.FormatInt(r.MinID, 10))
 	}
 	if r.MaxID != 0 {
-		q.Set("max_id", strconv.Itoa(r.MaxID))
+		q.Set("max_id", strconv.FormatInt(r.MaxID, 10))
 	}
 
+	if r.Offset != 0 {
+		q.Set("offset", strconv.Itoa(r.Offset))
+	}
 	if r.Limit != 0 {
 		q.Set("limit", strconv.Itoa(r.Limit))
 	}
 
 	if r.Radius != 0 {
+		// Clamp the radius client-side, rather than allowing the API to
+		// silently do so
+		if max := r.Units.maxRadius(); r.Radius > max {
+			l.client.logf("untappd: clamping local checkins radius %d%s to maximum %d%s",
+				r.Radius, r.Units.orMiles(), max, r.Units.orMiles())
+			r.Radius = max
+		}
+
 		q.Set("radius", strconv.Itoa(r.Radius))
 	}
 	if r.Units != "" {
@@ -83,3 +111,114 @@ func (l *LocalService) CheckinsMinMaxIDLimitRadius(r LocalCheckinsRequest) ([]*C
 
 	return l.client.getCheckins("thepub/local", q)
 }
+
+// CheckinsNearVenue queries for information about checkins in a local area
+// surrounding the Venue with the specified ID.  The Venue's coordinates are
+// first resolved using Venue.Info, and are then used to query for local
+// checkins within radius units of the Venue.
+//
+// This method returns up to Client.DefaultLimit of the area's most recent
+// checkins.  If units is empty, the radius is in miles.  If units is not empty
+// and is not one of the values returned by Distances, ErrInvalidDistance is
+// returned and no request is performed.
+//
+// If the Venue cannot be queried, the *http.Response from the Venue.Info
+// request is returned along with the error.
+func (l *LocalService) CheckinsNearVenue(venueID int64, radius int, units Distance) ([]*Checkin, *http.Response, error) {
+	// Check units before querying the venue, so no requests are wasted
+	if units != "" && !units.valid() {
+		return nil, nil, ErrInvalidDistance
+	}
+
+	// Only basic venue information is needed to determine its location
+	v, res, err := l.client.Venue.Info(venueID, true)
+	if err != nil {
+		return nil, res, err
+	}
+
+	return l.CheckinsMinMaxIDLimitRadius(LocalCheckinsRequest{
+		Latitude:  v.Location.Latitude,
+		Longitude: v.Location.Longitude,
+
+		Limit: l.client.limit(MaxCheckinsLimit),
+
+		Radius: radius,
+		Units:  units,
+	})
+}
+
+// CheckinsInBounds queries for information about checkins in a local area,
+// specified by a bounding box such as a map viewport, rather than by a
+// central point and radius.
+//
+// The Untappd APIv4 only accepts a central point and radius, so the query is
+// approximated using the center of the bounding box, and the distance from
+// its center to a corner, rounded up to a whole number of units and clamped
+// to MaxLocalRadiusMiles or MaxLocalRadiusKilometers.  As a result, checkins
+// near the corners of a large bounding box may be omitted, and checkins
+// outside of a small bounding box may be included.  The center is computed
+// as the midpoint of each coordinate, so boxes which span the antimeridian
+// are not supported.
+//
+// This method returns up to Client.DefaultLimit of the area's most recent
+// checkins.  If units is empty, the radius is in miles.  If units is not empty
+// and is not one of the values returned by Distances, ErrInvalidDistance is
+// returned.  If the minimum latitude or longitude exceeds its maximum,
+// ErrInvalidBounds is returned.  In either case, no request is performed.
+func (l *LocalService) CheckinsInBounds(minLat float64, minLng float64, maxLat float64, maxLng float64, units Distance) ([]*Checkin, *http.Response, error) {
+	if units == "" {
+		units = DistanceMiles
+	}
+	if !units.valid() {
+		return nil, nil, ErrInvalidDistance
+	}
+
+	if minLat > maxLat || minLng > maxLng {
+		return nil, nil, ErrInvalidBounds
+	}
+
+	lat, lng, radius := boundsCenterRadius(minLat, minLng, maxLat, maxLng, units)
+
+	return l.CheckinsMinMaxIDLimitRadius(LocalCheckinsRequest{
+		Latitude:  lat,
+		Longitude: lng,
+
+		Limit: l.client.limit(MaxCheckinsLimit),
+
+		Radius: radius,
+		Units:  units,
+	})
+}
+
+// boundsCenterRadius computes the center of a bounding box, and the radius
+// of a circle with that center which encloses the bounding box, in units.
+// The radius is at least 1, and no more than the maximum accepted by the
+// Untappd APIv4.
+func boundsCenterRadius(minLat float64, minLng float64, maxLat float64, maxLng float64, units Distance) (float64, float64, int) {
+	// Mean radius of the Earth, in miles and kilometers
+	earthRadius, max := 3958.8, units.maxRadius()
+	if units == DistanceKilometers {
+		earthRadius = 6371.0
+	}
+
+	lat := (minLat + maxLat) / 2
+	lng := (minLng + maxLng) / 2
+
+	// Haversine distance from the center to the northeast corner
+	rad := func(deg float64) float64 { return deg * math.Pi / 180 }
+	dLat := rad(maxLat - lat)
+	dLng := rad(maxLng - lng)
+	h := math.Pow(math.Sin(dLat/2), 2) +
+		math.Cos(rad(lat))*math.Cos(rad(maxLat))*math.Pow(math.Sin(dLng/2), 2)
+	d := 2 * earthRadius * math.Asin(math.Sqrt(h))
+
+	radius := int(math.Ceil(d))
+	switch {
+	case radius < 1:
+		radius = 1
+	case radius > max:
+		radius = max
+	}
+
+	return lat, lng, radius
+}
@@ -1,6 +1,8 @@
 package untappd
 
 import (
+	"bytes"
+	"log"
 	"math"
 	"net/http"
 	"net/url"
@@ -12,8 +14,8 @@ import (
 // TestClientLocalCheckinsOK verifies that Client.Local.Checkins always sets the
 // appropriate default values.
 func TestClientLocalCheckinsOK(t *testing.T) {
-	lat := "0"
-	lng := "0"
+	lat := "0.000000"
+	lng := "0.000000"
 	limit := "25"
 	radius := "25"
 	distance := DistanceMiles
@@ -58,16 +60,16 @@ func TestClientLocalCheckinsMinMaxIDLimitRadiusBadLocal(t *testing.T) {
 // returns a valid checkins list, when used with correct parameters.
 func TestClientLocalCheckinsMinMaxIDLimitRadiusOffsetLimitOK(t *testing.T) {
 	var lat = 1.00
-	sLat := formatFloat(lat)
+	sLat := "1.000000"
 
 	var lng = -1.00
-	sLng := formatFloat(lng)
+	sLng := "-1.000000"
 
-	var minID = 1
-	sMinID := strconv.Itoa(minID)
+	var minID int64 = 1
+	sMinID := strconv.FormatInt(minID, 10)
 
-	var maxID = math.MaxInt32
-	sMaxID := strconv.Itoa(maxID)
+	var maxID int64 = math.MaxInt32
+	sMaxID := strconv.FormatInt(maxID, 10)
 
 	var limit = 25
 	sLimit := strconv.Itoa(limit)
@@ -114,6 +116,327 @@ func TestClientLocalCheckinsMinMaxIDLimitRadiusOffsetLimitOK(t *testing.T) {
 	assertExpectedCheckins(t, checkins)
 }
 
+// TestClientLocalCheckinsMinMaxIDLimitRadiusOffset verifies that
+// Client.Local.CheckinsMinMaxIDLimitRadius only sends an offset parameter
+// when a non-zero offset is set.
+func TestClientLocalCheckinsMinMaxIDLimitRadiusOffset(t *testing.T) {
+	for _, offset := range []int{0, 25} {
+		c, done := localCheckinsTestClient(t, func(t *testing.T, w http.ResponseWriter, r *http.Request) {
+			_, ok := r.URL.Query()["offset"]
+			if got, want := ok, offset != 0; got != want {
+				t.Fatalf("unexpected presence of offset parameter for offset %d: %v != %v", offset, got, want)
+			}
+			if offset != 0 {
+				assertParameters(t, r, url.Values{
+					"offset": []string{strconv.Itoa(offset)},
+				})
+			}
+
+			w.Write([]byte("{}"))
+		})
+
+		_, _, err := c.Local.CheckinsMinMaxIDLimitRadius(LocalCheckinsRequest{
+			Latitude:  1.0,
+			Longitude: -1.0,
+			Offset:    offset,
+		})
+		done()
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+// TestClientLocalCheckinsMinMaxIDLimitRadiusUnits verifies that
+// Client.Local.CheckinsMinMaxIDLimitRadius accepts valid or empty units,
+// and rejects invalid units without performing a request.
+func TestClientLocalCheckinsMinMaxIDLimitRadiusUnits(t *testing.T) {
+	var tests = []struct {
+		description string
+		units       Distance
+		err         error
+	}{
+		{
+			description: "kilometers",
+			units:       DistanceKilometers,
+		},
+		{
+			description: "empty",
+		},
+		{
+			description: "invalid",
+			units:       Distance("mi"),
+			err:         ErrInvalidDistance,
+		},
+	}
+
+	for _, tt := range tests {
+		c, done := localCheckinsTestClient(t, func(t *testing.T, w http.ResponseWriter, r *http.Request) {
+			if tt.err != nil {
+				t.Fatalf("request should not have been performed for test %q", tt.description)
+			}
+
+			if got, want := r.URL.Query().Get("dist_pref"), string(tt.units); got != want {
+				t.Fatalf("unexpected dist_pref for test %q: %q != %q", tt.description, got, want)
+			}
+
+			w.Write([]byte("{}"))
+		})
+
+		_, _, err := c.Local.CheckinsMinMaxIDLimitRadius(LocalCheckinsRequest{
+			Latitude:  1.0,
+			Longitude: -1.0,
+			Radius:    10,
+			Units:     tt.units,
+		})
+		done()
+		if err != tt.err {
+			t.Fatalf("unexpected error for test %q: %v != %v", tt.description, err, tt.err)
+		}
+	}
+}
+
+// TestClientLocalCheckinsMinMaxIDLimitRadiusClamp verifies that
+// Client.Local.CheckinsMinMaxIDLimitRadius clamps a radius which exceeds the
+// maximum for its units, and logs the adjustment.
+func TestClientLocalCheckinsMinMaxIDLimitRadiusClamp(t *testing.T) {
+	var tests = []struct {
+		description string
+		radius      int
+		units       Distance
+		param       string
+		clamped     bool
+	}{
+		{
+			description: "miles, over maximum",
+			radius:      100,
+			units:       DistanceMiles,
+			param:       "25",
+			clamped:     true,
+		},
+		{
+			description: "empty, over maximum",
+			radius:      26,
+			param:       "25",
+			clamped:     true,
+		},
+		{
+			description: "kilometers, over maximum",
+			radius:      100,
+			units:       DistanceKilometers,
+			param:       "40",
+			clamped:     true,
+		},
+		{
+			description: "miles, maximum",
+			radius:      MaxLocalRadiusMiles,
+			units:       DistanceMiles,
+			param:       "25",
+		},
+		{
+			description: "kilometers, within maximum",
+			radius:      30,
+			units:       DistanceKilometers,
+			param:       "30",
+		},
+	}
+
+	for _, tt := range tests {
+		c, done := localCheckinsTestClient(t, func(t *testing.T, w http.ResponseWriter, r *http.Request) {
+			if got := r.URL.Query().Get("radius"); got != tt.param {
+				t.Fatalf("unexpected radius for test %q: %q != %q", tt.description, got, tt.param)
+			}
+
+			w.Write([]byte("{}"))
+		})
+
+		buf := bytes.NewBuffer(nil)
+		WithLogger(log.New(buf, "", 0))(c)
+
+		_, _, err := c.Local.CheckinsMinMaxIDLimitRadius(LocalCheckinsRequest{
+			Latitude:  1.0,
+			Longitude: -1.0,
+			Radius:    tt.radius,
+			Units:     tt.units,
+		})
+		done()
+		if err != nil {
+			t.Fatalf("unexpected error for test %q: %v", tt.description, err)
+		}
+
+		if logged := buf.Len() > 0; logged != tt.clamped {
+			t.Fatalf("unexpected logging for test %q: %v != %v (%q)", tt.description, logged, tt.clamped, buf.String())
+		}
+	}
+}
+
+// TestClientLocalCheckinsNearVenueOK verifies that Client.Local.CheckinsNearVenue
+// resolves a venue's coordinates and uses them to query local checkins.
+func TestClientLocalCheckinsNearVenueOK(t *testing.T) {
+	var id int64 = 1021
+	sID := strconv.FormatInt(id, 10)
+
+	var calls int
+	c, done := testClient(t, func(t *testing.T, w http.ResponseWriter, r *http.Request) {
+		calls++
+
+		switch p := r.URL.Path; p {
+		case "/v4/venue/info/" + sID + "/":
+			assertParameters(t, r, url.Values{
+				"compact": []string{"true"},
+			})
+
+			w.Write(venueLocationJSON)
+		case "/v4/thepub/local/":
+			assertParameters(t, r, url.Values{
+				"lat":       []string{"42.291200"},
+				"lng":       []string{"-85.587200"},
+				"limit":     []string{"25"},
+				"radius":    []string{"5"},
+				"dist_pref": []string{string(DistanceKilometers)},
+			})
+
+			w.Write(userCheckinsJSON)
+		default:
+			t.Fatalf("unexpected HTTP path: %q", p)
+		}
+	})
+	defer done()
+
+	checkins, _, err := c.Local.CheckinsNearVenue(id, 5, DistanceKilometers)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if calls != 2 {
+		t.Fatalf("unexpected number of requests: %d != %d", calls, 2)
+	}
+
+	assertExpectedCheckins(t, checkins)
+}
+
+// TestClientLocalCheckinsNearVenueBadVenue verifies that
+// Client.Local.CheckinsNearVenue returns an error and does not query local
+// checkins when the venue cannot be queried.
+func TestClientLocalCheckinsNearVenueBadVenue(t *testing.T) {
+	c, done := testClient(t, func(t *testing.T, w http.ResponseWriter, r *http.Request) {
+		if p, prefix := r.URL.Path, "/v4/venue/info/"; !strings.HasPrefix(p, prefix) {
+			t.Fatalf("unexpected HTTP path prefix: %q != %q", p, prefix)
+		}
+
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write(invalidVenueErrJSON)
+	})
+	defer done()
+
+	_, _, err := c.Local.CheckinsNearVenue(1, 5, DistanceMiles)
+	assertInvalidVenueErr(t, err)
+}
+
+// TestClientLocalCheckinsNearVenueBadUnits verifies that
+// Client.Local.CheckinsNearVenue rejects invalid units without performing
+// any requests.
+func TestClientLocalCheckinsNearVenueBadUnits(t *testing.T) {
+	c, done := testClient(t, func(t *testing.T, w http.ResponseWriter, r *http.Request) {
+		t.Fatal("request should not have been performed")
+	})
+	defer done()
+
+	if _, _, err := c.Local.CheckinsNearVenue(1, 5, Distance("mi")); err != ErrInvalidDistance {
+		t.Fatalf("unexpected error: %v != %v", err, ErrInvalidDistance)
+	}
+}
+
+// TestClientLocalCheckinsInBoundsOK verifies that Client.Local.CheckinsInBounds
+// queries local checkins using the center of a bounding box, and a radius
+// which encloses the box.
+func TestClientLocalCheckinsInBoundsOK(t *testing.T) {
+	var tests = []struct {
+		description string
+		bounds      [4]float64
+		units       Distance
+		lat, lng    string
+		radius      string
+		distPref    Distance
+	}{
+		{
+			description: "miles by default",
+			bounds:      [4]float64{40.0, -75.0, 40.2, -74.8},
+			lat:         "40.100000",
+			lng:         "-74.900000",
+			radius:      "9",
+			distPref:    DistanceMiles,
+		},
+		{
+			description: "kilometers",
+			bounds:      [4]float64{40.0, -75.0, 40.2, -74.8},
+			units:       DistanceKilometers,
+			lat:         "40.100000",
+			lng:         "-74.900000",
+			radius:      "14",
+			distPref:    DistanceKilometers,
+		},
+		{
+			description: "clamped to maximum radius",
+			bounds:      [4]float64{40.0, -75.0, 41.0, -74.0},
+			units:       DistanceMiles,
+			lat:         "40.500000",
+			lng:         "-74.500000",
+			radius:      strconv.Itoa(MaxLocalRadiusMiles),
+			distPref:    DistanceMiles,
+		},
+		{
+			description: "single point",
+			bounds:      [4]float64{40.0, -75.0, 40.0, -75.0},
+			units:       DistanceKilometers,
+			lat:         "40.000000",
+			lng:         "-75.000000",
+			radius:      "1",
+			distPref:    DistanceKilometers,
+		},
+	}
+
+	for _, tt := range tests {
+		c, done := localCheckinsTestClient(t, func(t *testing.T, w http.ResponseWriter, r *http.Request) {
+			assertParameters(t, r, url.Values{
+				"lat":       []string{tt.lat},
+				"lng":       []string{tt.lng},
+				"limit":     []string{"25"},
+				"radius":    []string{tt.radius},
+				"dist_pref": []string{string(tt.distPref)},
+			})
+
+			w.Write(userCheckinsJSON)
+		})
+
+		b := tt.bounds
+		_, _, err := c.Local.CheckinsInBounds(b[0], b[1], b[2], b[3], tt.units)
+		done()
+		if err != nil {
+			t.Fatalf("unexpected error for test %q: %v", tt.description, err)
+		}
+	}
+}
+
+// TestClientLocalCheckinsInBoundsBad verifies that Client.Local.CheckinsInBounds
+// rejects invalid units and bounding boxes without performing any requests.
+func TestClientLocalCheckinsInBoundsBad(t *testing.T) {
+	c, done := testClient(t, func(t *testing.T, w http.ResponseWriter, r *http.Request) {
+		t.Fatal("request should not have been performed")
+	})
+	defer done()
+
+	if _, _, err := c.Local.CheckinsInBounds(40, -75, 41, -74, Distance("mi")); err != ErrInvalidDistance {
+		t.Fatalf("unexpected error: %v != %v", err, ErrInvalidDistance)
+	}
+	if _, _, err := c.Local.CheckinsInBounds(41, -75, 40, -74, DistanceMiles); err != ErrInvalidBounds {
+		t.Fatalf("unexpected error: %v != %v", err, ErrInvalidBounds)
+	}
+	if _, _, err := c.Local.CheckinsInBounds(40, -74, 41, -75, DistanceMiles); err != ErrInvalidBounds {
+		t.Fatalf("unexpected error: %v != %v", err, ErrInvalidBounds)
+	}
+}
+
 // localCheckinTestClient builds upon testClient, and adds additional sanity checks
 // for tests which target the local checkin API.
 func localCheckinsTestClient(t *testing.T, fn func(t *testing.T, w http.ResponseWriter, r *http.Request)) (*Client, func()) {
@@ -136,3 +459,6 @@ func localCheckinsTestClient(t *testing.T, fn func(t *testing.T, w http.Response
 		}
 	})
 }
+
+// Canned compact venue JSON, containing only the venue's location
+var venueLocationJSON = []byte(`{"meta":{"code":200},"notifications":{},"response":{"venue":{"venue_id":1021,"venue_name":"Bell's Eccentric Cafe & General Store","location":{"venue_city":"Kalamazoo","lat":42.2912,"lng":-85.5872}}}}`)
@@ -0,0 +1,82 @@
+package untappd
+
+import (
+	"math"
+	"testing"
+)
+
+// TestDistanceConvert verifies that Distance.Convert converts between units,
+// and leaves values unchanged for the same or unknown units.
+func TestDistanceConvert(t *testing.T) {
+	var tests = []struct {
+		description string
+		from, to    Distance
+		in, out     float64
+	}{
+		{
+			description: "miles to kilometers",
+			from:        DistanceMiles,
+			to:          DistanceKilometers,
+			in:          25,
+			out:         40.2336,
+		},
+		{
+			description: "kilometers to miles",
+			from:        DistanceKilometers,
+			to:          DistanceMiles,
+			in:          40.2336,
+			out:         25,
+		},
+		{
+			description: "empty is miles",
+			to:          DistanceKilometers,
+			in:          1,
+			out:         1.609344,
+		},
+		{
+			description: "miles to miles",
+			from:        DistanceMiles,
+			to:          DistanceMiles,
+			in:          10,
+			out:         10,
+		},
+		{
+			description: "kilometers to kilometers",
+			from:        DistanceKilometers,
+			to:          DistanceKilometers,
+			in:          10,
+			out:         10,
+		},
+		{
+			description: "unknown unit",
+			from:        Distance("mi"),
+			to:          DistanceKilometers,
+			in:          10,
+			out:         10,
+		},
+	}
+
+	for _, tt := range tests {
+		if got := tt.from.Convert(tt.in, tt.to); math.Abs(got-tt.out) > 1e-9 {
+			t.Fatalf("unexpected result for test %q: %v != %v", tt.description, got, tt.out)
+		}
+	}
+}
+
+// TestDistanceRoundTrip verifies that converting a distance to another unit
+// and back produces the original distance.
+func TestDistanceRoundTrip(t *testing.T) {
+	for _, v := range []float64{0, 0.5, 1, 25, 40, 1000} {
+		if got := KmToMiles(MilesToKm(v)); math.Abs(got-v) > 1e-9 {
+			t.Fatalf("unexpected miles round trip: %v != %v", got, v)
+		}
+		if got := MilesToKm(KmToMiles(v)); math.Abs(got-v) > 1e-9 {
+			t.Fatalf("unexpected kilometers round trip: %v != %v", got, v)
+		}
+
+		got := DistanceKilometers.Convert(DistanceMiles.Convert(v, DistanceKilometers), DistanceMiles)
+		if math.Abs(got-v) > 1e-9 {
+			t.Fatalf("unexpected Convert round trip: %v != %v", got, v)
+		}
+	}
+}
@@ -0,0 +1,107 @@
+package untappd
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Meta contains metadata reported by the Untappd APIv4 alongside each
+// response, such as the time taken by the API to produce a response.
+type Meta struct {
+	// The status code reported by the API.
+	Code int `json:"code"`
+
+	// The time taken by the API to produce the response, and to initialize
+	// before handling the request.
+	ResponseTime time.Duration `json:"response_time"`
+	InitTime     time.Duration `json:"init_time"`
+}
+
+// rawMeta is the raw JSON representation of a response's meta block.  Its
+// data is unmarshaled from JSON and then exported to a Meta struct.
+type rawMeta struct {
+	Code         int              `json:"code"`
+	ResponseTime responseDuration `json:"response_time"`
+	InitTime     responseDuration `json:"init_time"`
+}
+
+// export creates an exported Meta from a rawMeta struct.
+func (r *rawMeta) export() Meta {
+	return Meta{
+		Code:         r.Code,
+		ResponseTime: time.Duration(r.ResponseTime),
+		InitTime:     time.Duration(r.InitTime),
+	}
+}
+
+// LastMeta returns the metadata reported by the Untappd APIv4 in its most
+// recent response to this Client, which can be used to monitor API latency.
+// If no response has contained metadata yet, ok is false.
+//
+// LastMeta is safe to call while other goroutines perform requests using
+// the same Client.
+func (c *Client) LastMeta() (m Meta, ok bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.meta, c.hasMeta
+}
+
+// updateMeta stores the meta block from a JSON response body, if any, as
+// the Client's most recent metadata.  Bodies which cannot be decoded are
+// ignored, since they are reported elsewhere by the request.
+func (c *Client) updateMeta(res *http.Response, body []byte) {
+	if !strings.HasPrefix(res.Header.Get("Content-Type"), jsonContentType) {
+		return
+	}
+
+	m, ok := decodeMeta(body)
+	if !ok {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.meta = m.export()
+	c.hasMeta = true
+}
+
+// decodeMeta decodes only the meta block from a JSON response body.  The API
+// sends the meta block first, so decoding stops as soon as it is found, rather
+// than decoding the entire body a second time.
+func decodeMeta(body []byte) (*rawMeta, bool) {
+	d := json.NewDecoder(bytes.NewReader(body))
+	if t, err := d.Token(); err != nil || t != json.Delim('{') {
+		return nil, false
+	}
+
+	for d.More() {
+		t, err := d.Token()
+		if err != nil {
+			return nil, false
+		}
+
+		// Skip over any members which precede the meta block
+		if key, ok := t.(string); !ok || key != "meta" {
+			var skip json.RawMessage
+			if err := d.Decode(&skip); err != nil {
+				return nil, false
+			}
+
+			continue
+		}
+
+		var m *rawMeta
+		if err := d.Decode(&m); err != nil || m == nil {
+			return nil, false
+		}
+
+		return m, true
+	}
+
+	return nil, false
+}
@@ -0,0 +1,114 @@
+package untappd
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+// TestClientLastMeta verifies that Client.LastMeta reports the metadata from
+// the most recent successful response.
+func TestClientLastMeta(t *testing.T) {
+	c, done := testClient(t, func(t *testing.T, w http.ResponseWriter, r *http.Request) {
+		w.Write(userCheckinsJSON)
+	})
+	defer done()
+
+	if _, ok := c.LastMeta(); ok {
+		t.Fatal("expected no metadata before any requests")
+	}
+
+	if _, _, err := c.User.Checkins("gregavola"); err != nil {
+		t.Fatal(err)
+	}
+
+	m, ok := c.LastMeta()
+	if !ok {
+		t.Fatal("expected metadata after a request")
+	}
+
+	want := Meta{
+		Code:         200,
+		ResponseTime: 841 * time.Millisecond,
+		InitTime:     1 * time.Millisecond,
+	}
+	if m != want {
+		t.Fatalf("unexpected Meta: %+v != %+v", m, want)
+	}
+}
+
+// TestClientLastMetaError verifies that Client.LastMeta reports the metadata
+// from an error response.
+func TestClientLastMetaError(t *testing.T) {
+	c, done := testClient(t, func(t *testing.T, w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write(invalidUserErrJSON)
+	})
+	defer done()
+
+	if _, _, err := c.User.Info("foo", false); err == nil {
+		t.Fatal("expected an error, but none occurred")
+	}
+
+	m, ok := c.LastMeta()
+	if !ok {
+		t.Fatal("expected metadata after a request")
+	}
+	if got, want := m.Code, 500; got != want {
+		t.Fatalf("unexpected Code: %d != %d", got, want)
+	}
+}
+
+// TestDecodeMeta verifies that decodeMeta finds the meta block anywhere in
+// the top level of a response, and stops decoding once it has been found.
+func TestDecodeMeta(t *testing.T) {
+	var tests = []struct {
+		description string
+		body        string
+		code        int
+		ok          bool
+	}{
+		{
+			description: "meta first, followed by malformed data",
+			body:        `{"meta":{"code":200},"response":{`,
+			code:        200,
+			ok:          true,
+		},
+		{
+			description: "meta after response",
+			body:        `{"response":{"items":[1,2]},"meta":{"code":500}}`,
+			code:        500,
+			ok:          true,
+		},
+		{
+			description: "no meta",
+			body:        `{"response":{}}`,
+		},
+		{
+			description: "null meta",
+			body:        `{"meta":null}`,
+		},
+		{
+			description: "not an object",
+			body:        `[{"meta":{"code":200}}]`,
+		},
+		{
+			description: "malformed",
+			body:        `{"response":`,
+		},
+	}
+
+	for _, tt := range tests {
+		m, ok := decodeMeta([]byte(tt.body))
+		if got, want := ok, tt.ok; got != want {
+			t.Fatalf("unexpected ok for test %q: %v != %v", tt.description, got, want)
+		}
+		if !ok {
+			continue
+		}
+
+		if got, want := m.Code, tt.code; got != want {
+			t.Fatalf("unexpected code for test %q: %d != %d", tt.description, got, want)
+		}
+	}
+}
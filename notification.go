@@ -0,0 +1,127 @@
+package untappd
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+)
+
+// Notifications represents an authenticated Untappd user's notifications,
+// and contains the number of unread notifications of each type, as well as
+// the user's recent notifications.
+type Notifications struct {
+	// Number of unread notifications of each type.
+	Unread NotificationCounts `json:"unread"`
+
+	// Recent notifications, such as toasts and comments on the user's
+	// checkins, or friend requests.
+	Items []*Notification `json:"items"`
+}
+
+// NotificationCounts contains the number of unread notifications of each
+// type for an authenticated Untappd user.
+type NotificationCounts struct {
+	Comments int `json:"comments"`
+	Toasts   int `json:"toasts"`
+	Friends  int `json:"friends"`
+	Messages int `json:"messages"`
+	News     int `json:"news"`
+}
+
+// Notification represents a single Untappd notification, and contains
+// metadata regarding the notification, and the User who triggered it.
+type Notification struct {
+	// Metadata from Untappd.
+	ID   int64  `json:"id"`
+	Type string `json:"type"`
+
+	// If applicable, the checkin this notification refers to, such as
+	// a checkin which was toasted or commented on.
+	CheckinID int64 `json:"checkin_id"`
+
+	// Time when this notification was created.
+	Created time.Time `json:"created"`
+
+	// The user who triggered this notification.
+	User *User `json:"user"`
+}
+
+// ResponseNotifications returns the Notifications summary from the
+// *http.Response returned by any method which queries the Untappd APIv4.
+// Most responses include the number of unread notifications for the
+// authenticated user, which may be used to update a notification count
+// without calling Auth.Notifications.  If the response contains no
+// notifications block, ResponseNotifications returns nil and no error.
+//
+// The response body is left intact, so ResponseNotifications may be called
+// more than once for the same response.
+func ResponseNotifications(res *http.Response) (*Notifications, error) {
+	body, err := peekBody(res)
+	if err != nil || body == nil {
+		return nil, err
+	}
+
+	var v struct {
+		Notifications *responseNotifications `json:"notifications"`
+	}
+
+	if err := json.Unmarshal(body, &v); err != nil {
+		return nil, err
+	}
+
+	if v.Notifications == nil {
+		return nil, nil
+	}
+
+	rn := rawNotifications(*v.Notifications)
+	return rn.export(), nil
+}
+
+// rawNotifications is the raw JSON representation of Untappd notifications.
+// Its data is unmarshaled from JSON and then exported to a Notifications
+// struct.
+type rawNotifications struct {
+	UnreadCount   responseNotificationCounts `json:"unread_count"`
+	Notifications responseNotificationItems  `json:"notifications"`
+}
+
+// export creates an exported Notifications from a rawNotifications struct,
+// allowing for more useful structures to be created for client consumption.
+func (r *rawNotifications) export() *Notifications {
+	items := make([]*Notification, len(r.Notifications))
+	for i := range r.Notifications {
+		items[i] = r.Notifications[i].export()
+	}
+
+	return &Notifications{
+		Unread: NotificationCounts(r.UnreadCount),
+		Items:  items,
+	}
+}
+
+// rawNotification is the raw JSON representation of an Untappd notification.
+// Its data is unmarshaled from JSON and then exported to a Notification struct.
+type rawNotification struct {
+	ID        int64        `json:"notification_id"`
+	Type      string       `json:"notification_type"`
+	CheckinID int64        `json:"checkin_id"`
+	Created   responseTime `json:"created_at"`
+	User      *rawUser     `json:"user"`
+}
+
+// export creates an exported Notification from a rawNotification struct,
+// allowing for more useful structures to be created for client consumption.
+func (r *rawNotification) export() *Notification {
+	n := &Notification{
+		ID:        r.ID,
+		Type:      r.Type,
+		CheckinID: r.CheckinID,
+		Created:   time.Time(r.Created),
+	}
+
+	if r.User != nil {
+		n.User = r.User.export()
+	}
+
+	return n
+}
@@ -0,0 +1,141 @@
+package untappd
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// Pagination contains the cursors returned by the Untappd APIv4 with a page
+// of checkins, which can be used to retrieve newer or older checkins.
+type Pagination struct {
+	// ID of the newest checkin in this page.  Pass this value as the
+	// minimum ID of a CheckinsMinMaxIDLimit method to query for newer
+	// checkins.
+	SinceID int64 `json:"since_id"`
+
+	// ID to pass as the maximum ID of a CheckinsMinMaxIDLimit method to
+	// query for the next, older page of checkins.  If zero, no older
+	// checkins remain.
+	MaxID int64 `json:"max_id"`
+
+	// URLs which the Untappd APIv4 provides to query for newer or older
+	// checkins, respectively.
+	SinceURL url.URL `json:"since_url"`
+	NextURL  url.URL `json:"next_url"`
+}
+
+// MarshalJSON implements json.Marshaler, so that a Pagination's URLs are
+// encoded as strings.
+func (p Pagination) MarshalJSON() ([]byte, error) {
+	type pagination Pagination
+	return json.Marshal(struct {
+		pagination
+		SinceURL string `json:"since_url"`
+		NextURL  string `json:"next_url"`
+	}{
+		pagination: pagination(p),
+		SinceURL:   p.SinceURL.String(),
+		NextURL:    p.NextURL.String(),
+	})
+}
+
+// UnmarshalJSON implements json.Unmarshaler, so that JSON produced by
+// MarshalJSON can be decoded into a Pagination.
+func (p *Pagination) UnmarshalJSON(data []byte) error {
+	type pagination Pagination
+	v := struct {
+		*pagination
+		SinceURL string `json:"since_url"`
+		NextURL  string `json:"next_url"`
+	}{
+		pagination: (*pagination)(p),
+	}
+
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+
+	for _, u := range []struct {
+		s   string
+		dst *url.URL
+	}{
+		{s: v.SinceURL, dst: &p.SinceURL},
+		{s: v.NextURL, dst: &p.NextURL},
+	} {
+		pu, err := url.Parse(u.s)
+		if err != nil {
+			return err
+		}
+		*u.dst = *pu
+	}
+
+	return nil
+}
+
+// ResponsePagination returns the Pagination from the *http.Response returned
+// by a method which queries for checkins, such as User.CheckinsMinMaxIDLimit
+// or Venue.CheckinsMinMaxIDLimit.  If the response contains no pagination
+// information, ResponsePagination returns nil and no error.
+//
+// The response body is left intact, so ResponsePagination may be called
+// more than once for the same response.
+func ResponsePagination(res *http.Response) (*Pagination, error) {
+	body, err := peekBody(res)
+	if err != nil || body == nil {
+		return nil, err
+	}
+
+	var v struct {
+		Response struct {
+			Pagination json.RawMessage `json:"pagination"`
+		} `json:"response"`
+	}
+
+	if err := json.Unmarshal(body, &v); err != nil {
+		return nil, err
+	}
+
+	// If no pagination exists, the API may return an empty array instead
+	// of a nil or empty object
+	p := bytes.TrimSpace(v.Response.Pagination)
+	if len(p) == 0 || bytes.Equal(p, []byte("[]")) || bytes.Equal(p, []byte("null")) {
+		return nil, nil
+	}
+
+	var rp rawPagination
+	if err := json.Unmarshal(p, &rp); err != nil {
+		return nil, err
+	}
+
+	return rp.export(), nil
+}
+
+// rawPagination is the raw JSON representation of Untappd pagination.  Its
+// data is unmarshaled from JSON and then exported to a Pagination struct.
+type rawPagination struct {
+	SinceURL responseURL     `json:"since_url"`
+	NextURL  responseURL     `json:"next_url"`
+	MaxID    json.RawMessage `json:"max_id"`
+}
+
+// export creates an exported Pagination from a rawPagination struct,
+// allowing for more useful structures to be created for client consumption.
+func (r *rawPagination) export() *Pagination {
+	p := &Pagination{
+		SinceURL: url.URL(r.SinceURL),
+		NextURL:  url.URL(r.NextURL),
+	}
+
+	// The maximum ID may be returned as a number or a string, which is
+	// empty if no older checkins remain
+	p.MaxID, _ = strconv.ParseInt(strings.Trim(string(r.MaxID), `"`), 10, 64)
+
+	// The since ID is only available in the since URL
+	p.SinceID, _ = strconv.ParseInt(p.SinceURL.Query().Get("min_id"), 10, 64)
+
+	return p
+}
@@ -0,0 +1,155 @@
+package untappd
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"reflect"
+	"testing"
+)
+
+// TestResponsePaginationCheckins verifies that ResponsePagination returns
+// the same Pagination for each method which queries for checkins.
+func TestResponsePaginationCheckins(t *testing.T) {
+	want := &Pagination{
+		SinceID: 171626491,
+		MaxID:   161830366,
+	}
+	want.SinceURL = mustParseURL(t, "https://api.untappd.com/v4/user/checkins/gregavola?min_id=171626491")
+	want.NextURL = mustParseURL(t, "https://api.untappd.com/v4/user/checkins/gregavola?max_id=161830366")
+
+	tests := []struct {
+		desc string
+		fn   func(c *Client) (*http.Response, error)
+	}{
+		{
+			desc: "venue",
+			fn: func(c *Client) (*http.Response, error) {
+				_, res, err := c.Venue.CheckinsMinMaxIDLimit(1, 0, 0, 25)
+				return res, err
+			},
+		},
+		{
+			desc: "brewery",
+			fn: func(c *Client) (*http.Response, error) {
+				_, res, err := c.Brewery.CheckinsMinMaxIDLimit(1, 0, 0, 25)
+				return res, err
+			},
+		},
+		{
+			desc: "beer",
+			fn: func(c *Client) (*http.Response, error) {
+				_, res, err := c.Beer.CheckinsMinMaxIDLimit(1, 0, 0, 25)
+				return res, err
+			},
+		},
+		{
+			desc: "auth",
+			fn: func(c *Client) (*http.Response, error) {
+				_, res, err := c.Auth.CheckinsMinMaxIDLimit(0, 0, 25)
+				return res, err
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		c, done := testClient(t, func(t *testing.T, w http.ResponseWriter, r *http.Request) {
+			// JSON is in same format as /v4/user/checkins, so we can
+			// reuse it here
+			w.Write(userCheckinsJSON)
+		})
+
+		res, err := tt.fn(c)
+		done()
+		if err != nil {
+			t.Fatalf("[%s] %v", tt.desc, err)
+		}
+
+		// Pagination must be available more than once
+		for i := 0; i < 2; i++ {
+			p, err := ResponsePagination(res)
+			if err != nil {
+				t.Fatalf("[%s] %v", tt.desc, err)
+			}
+
+			if !reflect.DeepEqual(p, want) {
+				t.Fatalf("[%s] unexpected pagination:\n- want: %+v\n-  got: %+v", tt.desc, want, p)
+			}
+		}
+	}
+}
+
+// TestResponsePaginationNone verifies that ResponsePagination returns nil
+// when no pagination information is present.
+func TestResponsePaginationNone(t *testing.T) {
+	tests := []struct {
+		desc string
+		res  *http.Response
+	}{
+		{
+			desc: "nil response",
+		},
+		{
+			desc: "no pagination",
+			res:  paginationResponse(`{"response":{}}`),
+		},
+		{
+			desc: "empty array pagination",
+			res:  paginationResponse(`{"response":{"pagination":[]}}`),
+		},
+	}
+
+	for _, tt := range tests {
+		p, err := ResponsePagination(tt.res)
+		if err != nil {
+			t.Fatalf("[%s] %v", tt.desc, err)
+		}
+		if p != nil {
+			t.Fatalf("[%s] unexpected pagination: %+v", tt.desc, p)
+		}
+	}
+}
+
+// TestPaginationJSON verifies that a Pagination can be encoded to and decoded
+// from JSON.
+func TestPaginationJSON(t *testing.T) {
+	p := &Pagination{
+		SinceID:  2,
+		MaxID:    1,
+		SinceURL: mustParseURL(t, "https://api.untappd.com/v4/checkin/recent?min_id=2"),
+		NextURL:  mustParseURL(t, "https://api.untappd.com/v4/checkin/recent?max_id=1"),
+	}
+
+	b, err := json.Marshal(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var out Pagination
+	if err := json.Unmarshal(b, &out); err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(*p, out) {
+		t.Fatalf("unexpected pagination:\n- want: %+v\n-  got: %+v", *p, out)
+	}
+}
+
+// paginationResponse creates a *http.Response with the input body.
+func paginationResponse(body string) *http.Response {
+	return &http.Response{
+		Body: ioutil.NopCloser(bytes.NewReader([]byte(body))),
+	}
+}
+
+// mustParseURL parses s as a URL, failing the test if it cannot be parsed.
+func mustParseURL(t *testing.T, s string) url.URL {
+	u, err := url.Parse(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return *u
+}
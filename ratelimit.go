@@ -0,0 +1,70 @@
+package untappd
+
+import (
+	"net/http"
+	"strconv"
+)
+
+const (
+	// rateLimitHeader and rateLimitRemainingHeader are the HTTP headers used
+	// by the Untappd APIv4 to report an API key's hourly rate limit.
+	rateLimitHeader          = "X-Ratelimit-Limit"
+	rateLimitRemainingHeader = "X-Ratelimit-Remaining"
+)
+
+// RateLimit is a snapshot of the Untappd APIv4 rate limit for the credentials
+// used by a Client, as reported by the most recent HTTP response.
+type RateLimit struct {
+	// The maximum number of requests allowed per hour.
+	Limit int `json:"limit"`
+
+	// The number of requests remaining in the current hour.
+	Remaining int `json:"remaining"`
+}
+
+// LastRateLimit returns the rate limit reported by the Untappd APIv4 in
+// its most recent response to this Client.  If no response has reported
+// a rate limit yet, ok is false.
+//
+// LastRateLimit is safe to call while other goroutines perform requests
+// using the same Client.
+func (c *Client) LastRateLimit() (rl RateLimit, ok bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.rateLimit, c.hasRateLimit
+}
+
+// updateRateLimit stores the rate limit reported in the headers of res, if
+// any, as the Client's most recent rate limit snapshot.
+func (c *Client) updateRateLimit(res *http.Response) {
+	rl, ok := parseRateLimit(res.Header)
+	if !ok {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.rateLimit = rl
+	c.hasRateLimit = true
+}
+
+// parseRateLimit parses a RateLimit from HTTP headers.  If either header
+// is missing or invalid, ok is false.
+func parseRateLimit(h http.Header) (rl RateLimit, ok bool) {
+	limit, err := strconv.Atoi(h.Get(rateLimitHeader))
+	if err != nil {
+		return RateLimit{}, false
+	}
+
+	remaining, err := strconv.Atoi(h.Get(rateLimitRemainingHeader))
+	if err != nil {
+		return RateLimit{}, false
+	}
+
+	return RateLimit{
+		Limit:     limit,
+		Remaining: remaining,
+	}, true
+}
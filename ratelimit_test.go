@@ -0,0 +1,139 @@
+package untappd
+
+import (
+	"net/http"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+// Test_parseRateLimit verifies that parseRateLimit only reports a rate limit
+// when both rate limit headers are present and valid.
+func Test_parseRateLimit(t *testing.T) {
+	var tests = []struct {
+		description string
+		limit       string
+		remaining   string
+		rl          RateLimit
+		ok          bool
+	}{
+		{
+			description: "no headers",
+		},
+		{
+			description: "no remaining header",
+			limit:       "100",
+		},
+		{
+			description: "invalid limit header",
+			limit:       "foo",
+			remaining:   "10",
+		},
+		{
+			description: "OK",
+			limit:       "100",
+			remaining:   "10",
+			rl:          RateLimit{Limit: 100, Remaining: 10},
+			ok:          true,
+		},
+	}
+
+	for _, tt := range tests {
+		h := make(http.Header)
+		if tt.limit != "" {
+			h.Set(rateLimitHeader, tt.limit)
+		}
+		if tt.remaining != "" {
+			h.Set(rateLimitRemainingHeader, tt.remaining)
+		}
+
+		rl, ok := parseRateLimit(h)
+		if ok != tt.ok {
+			t.Fatalf("unexpected ok for test %q: %v != %v", tt.description, ok, tt.ok)
+		}
+		if rl != tt.rl {
+			t.Fatalf("unexpected RateLimit for test %q: %+v != %+v", tt.description, rl, tt.rl)
+		}
+	}
+}
+
+// TestClientLastRateLimit verifies that Client.LastRateLimit reports the
+// rate limit from the most recent response, including error responses.
+func TestClientLastRateLimit(t *testing.T) {
+	c, done := testClient(t, func(t *testing.T, w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(rateLimitHeader, "100")
+		w.Header().Set(rateLimitRemainingHeader, "99")
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write(invalidUserErrJSON)
+	})
+	defer done()
+
+	if _, ok := c.LastRateLimit(); ok {
+		t.Fatal("expected no rate limit before any requests")
+	}
+
+	if _, _, err := c.User.Info("foo", false); err == nil {
+		t.Fatal("expected an error, but none occurred")
+	}
+
+	rl, ok := c.LastRateLimit()
+	if !ok {
+		t.Fatal("expected a rate limit after a request")
+	}
+	if want := (RateLimit{Limit: 100, Remaining: 99}); rl != want {
+		t.Fatalf("unexpected RateLimit: %+v != %+v", rl, want)
+	}
+}
+
+// TestClientLastRateLimitConcurrent verifies that a Client may perform
+// requests from many goroutines while its rate limit is read concurrently.
+// Run with the race detector to catch unsynchronized access.
+func TestClientLastRateLimitConcurrent(t *testing.T) {
+	var remaining int64 = 1000
+	c, done := testClient(t, func(t *testing.T, w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt64(&remaining, -1)
+		w.Header().Set(rateLimitHeader, "1000")
+		w.Header().Set(rateLimitRemainingHeader, strconv.FormatInt(n, 10))
+		w.Write(gregavolaUserJSON)
+	})
+	defer done()
+
+	const workers = 8
+	const requests = 10
+
+	var wg sync.WaitGroup
+	wg.Add(workers * 2)
+	for i := 0; i < workers; i++ {
+		go func() {
+			defer wg.Done()
+
+			for j := 0; j < requests; j++ {
+				if _, _, err := c.User.Info("gregavola", false); err != nil {
+					t.Error(err)
+					return
+				}
+			}
+		}()
+
+		go func() {
+			defer wg.Done()
+
+			for j := 0; j < requests; j++ {
+				if rl, ok := c.LastRateLimit(); ok && rl.Limit != 1000 {
+					t.Errorf("unexpected rate limit: %d != %d", rl.Limit, 1000)
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	rl, ok := c.LastRateLimit()
+	if !ok {
+		t.Fatal("expected a rate limit after requests")
+	}
+	if rl.Remaining < 1000-workers*requests || rl.Remaining >= 1000 {
+		t.Fatalf("unexpected remaining requests: %d", rl.Remaining)
+	}
+}
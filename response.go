@@ -6,6 +6,7 @@ import (
 	"errors"
 	"fmt"
 	"net/url"
+	"strconv"
 	"strings"
 	"time"
 )
@@ -63,6 +64,13 @@ func (r *responseTime) UnmarshalJSON(data []byte) error {
 		return err
 	}
 
+	// Some responses contain an empty string or null in place of a
+	// timestamp; treat these as a zero time.Time
+	if v == "" {
+		*r = responseTime(time.Time{})
+		return nil
+	}
+
 	// Parse a Go time.Time from string
 	t, err := time.Parse(time.RFC1123Z, v)
 	if err != nil {
@@ -73,6 +81,43 @@ func (r *responseTime) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+// responseTimeZone implements json.Unmarshaler, so that the timezone hints
+// returned alongside some timestamps in the Untappd APIv4, such as "-5",
+// can be decoded directly into a fixed offset *time.Location.  If no hint
+// is present, the location is nil.
+type responseTimeZone struct {
+	loc *time.Location
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (r *responseTimeZone) UnmarshalJSON(data []byte) error {
+	// Hints may be encoded as either strings or numbers, and may contain
+	// fractional hours, such as "5.5"
+	s := strings.Trim(string(data), `"`)
+	if s == "" || s == "null" {
+		return nil
+	}
+
+	hours, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return err
+	}
+
+	offset := int(hours * 60 * 60)
+	r.loc = time.FixedZone("", offset)
+	return nil
+}
+
+// in returns t in the hinted timezone, or t unmodified if no timezone hint
+// was present.
+func (r responseTimeZone) in(t time.Time) time.Time {
+	if r.loc == nil || t.IsZero() {
+		return t
+	}
+
+	return t.In(r.loc)
+}
+
 // responseURL implements json.Unmarshaler, so that URL string responses
 // in the Untappd APIv4 can be decoded directly into Go *url.URL structs.
 type responseURL url.URL
@@ -95,10 +140,21 @@ func (r *responseURL) UnmarshalJSON(data []byte) error {
 
 // responseBool implements json.Unmarshaler, so that integer 0 or 1 responses
 // in the Untappd APIv4 can be decoded directly into Go boolean values.
+// JSON boolean responses are also accepted, since the Untappd APIv4 is
+// inconsistent about which form it uses for some values.
 type responseBool bool
 
 // UnmarshalJSON implements json.Unmarshaler.
 func (r *responseBool) UnmarshalJSON(data []byte) error {
+	switch string(data) {
+	case "true":
+		*r = true
+		return nil
+	case "false":
+		*r = false
+		return nil
+	}
+
 	var v int
 	if err := json.Unmarshal(data, &v); err != nil {
 		return err
@@ -116,17 +172,66 @@ func (r *responseBool) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
-// responseBadgeLevels implements json.Unmarshaler, so that an empty array on
-// a badge with no levels can be appropriately handled.
-type responseBadgeLevels struct {
+// or returns the value of r, or def if r is nil because the value was
+// absent from a response.
+func (r *responseBool) or(def bool) bool {
+	if r == nil {
+		return def
+	}
+
+	return bool(*r)
+}
+
+// responseNumber implements json.Unmarshaler, so that numeric values which
+// the Untappd APIv4 returns as either JSON numbers or strings, such as a
+// beer's ABV and IBU, can be decoded directly into Go float64 values.  Empty
+// strings and null are decoded as zero.
+type responseNumber float64
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (r *responseNumber) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, []byte("null")) {
+		*r = 0
+		return nil
+	}
+
+	// Strip quotes from string values, so they can be parsed as numbers
+	if len(data) > 0 && data[0] == '"' {
+		var s string
+		if err := json.Unmarshal(data, &s); err != nil {
+			return err
+		}
+
+		s = strings.TrimSpace(s)
+		if s == "" {
+			*r = 0
+			return nil
+		}
+
+		data = []byte(s)
+	}
+
+	f, err := strconv.ParseFloat(string(data), 64)
+	if err != nil {
+		return err
+	}
+
+	*r = responseNumber(f)
+	return nil
+}
+
+// responseBadges implements json.Unmarshaler, so that an empty array in
+// place of a list of badges, such as on a badge with no levels or a checkin
+// which earned no badges, can be appropriately handled.
+type responseBadges struct {
 	Count int
 	Items []*rawBadge
 }
 
 // UnmarshalJSON implements json.Unmarshaler.
-func (r *responseBadgeLevels) UnmarshalJSON(data []byte) error {
-	// If no levels exist for a badge, the API returns an empty array instead
-	// of a nil or empty object.  This method works around that.
+func (r *responseBadges) UnmarshalJSON(data []byte) error {
+	// If no badges exist, the API returns an empty array instead of a nil
+	// or empty object.  This method works around that.
 	if bytes.Equal(data, []byte("[]")) {
 		return nil
 	}
@@ -146,6 +251,58 @@ func (r *responseBadgeLevels) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+// responseScore implements json.Unmarshaler, so that the score reported
+// when a checkin is added can be decoded from either a number of points, or
+// an object containing the total number of points.
+type responseScore float64
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (r *responseScore) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if len(data) > 0 && data[0] == '{' {
+		var v struct {
+			TotalPoints float64 `json:"total_points"`
+		}
+		if err := json.Unmarshal(data, &v); err != nil {
+			return err
+		}
+
+		*r = responseScore(v.TotalPoints)
+		return nil
+	}
+
+	var f float64
+	if err := json.Unmarshal(data, &f); err != nil {
+		return err
+	}
+
+	*r = responseScore(f)
+	return nil
+}
+
+// responseNotifications implements json.Unmarshaler, so that the varying
+// shapes of the notifications block included in Untappd APIv4 responses can
+// be appropriately handled.
+type responseNotifications rawNotifications
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (r *responseNotifications) UnmarshalJSON(data []byte) error {
+	// If no notifications exist, the API returns an empty array, which may
+	// contain whitespace, instead of a nil or empty object.  This method
+	// works around that.
+	if bytes.Equal(bytes.Join(bytes.Fields(data), nil), []byte("[]")) {
+		return nil
+	}
+
+	var v rawNotifications
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+
+	*r = responseNotifications(v)
+	return nil
+}
+
 // responseVenue implements json.Unmarshaler, so that an empty array on
 // a checkin with no venue can be appropriately handled.
 type responseVenue rawVenue
@@ -166,3 +323,231 @@ func (r *responseVenue) UnmarshalJSON(data []byte) error {
 	*r = responseVenue(v)
 	return nil
 }
+
+// responsePhotos implements json.Unmarshaler, so that the varying shapes
+// of a venue's photos can be appropriately handled.
+type responsePhotos []*rawCheckinMedia
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (r *responsePhotos) UnmarshalJSON(data []byte) error {
+	// If no photos exist for a venue, the API returns an empty array
+	// instead of a nil or empty object.  This method works around that.
+	if bytes.Equal(data, []byte("[]")) {
+		return nil
+	}
+
+	var v struct {
+		Items json.RawMessage `json:"items"`
+	}
+
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+
+	var items []*rawCheckinMedia
+	if err := unmarshalItems(v.Items, &items); err != nil {
+		return err
+	}
+
+	*r = responsePhotos(items)
+	return nil
+}
+
+// export creates a slice of photo URLs from a responsePhotos value.  The
+// largest available image is used for each photo, and photos with no
+// images are skipped.
+func (r responsePhotos) export() []url.URL {
+	photos := make([]url.URL, 0, len(r))
+	for _, p := range r {
+		if p == nil {
+			continue
+		}
+
+		m := p.export()
+		for _, u := range []url.URL{m.OriginalImage, m.LargeImage, m.MediumImage, m.SmallImage} {
+			if u.String() != "" {
+				photos = append(photos, u)
+				break
+			}
+		}
+	}
+
+	return photos
+}
+
+// responseRecentBrews implements json.Unmarshaler, so that the varying shapes
+// of a user's recent brews can be appropriately handled.
+type responseRecentBrews []*rawRecentBrew
+
+// rawRecentBrew is the raw JSON representation of a beer and its brewery,
+// as embedded in a user's recent brews.
+type rawRecentBrew struct {
+	Beer    rawBeer    `json:"beer"`
+	Brewery rawBrewery `json:"brewery"`
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (r *responseRecentBrews) UnmarshalJSON(data []byte) error {
+	// If no recent brews exist for a user, the API returns an empty array
+	// instead of a nil or empty object.  This method works around that.
+	if bytes.Equal(data, []byte("[]")) {
+		return nil
+	}
+
+	var v struct {
+		Items json.RawMessage `json:"items"`
+	}
+
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+
+	var items []*rawRecentBrew
+	if err := unmarshalItems(v.Items, &items); err != nil {
+		return err
+	}
+
+	*r = responseRecentBrews(items)
+	return nil
+}
+
+// responseCollaborations implements json.Unmarshaler, so that the varying
+// shapes of a beer's collaborating breweries can be appropriately handled.
+type responseCollaborations []*rawBrewery
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (r *responseCollaborations) UnmarshalJSON(data []byte) error {
+	// If a beer is not a collaboration, the API returns an empty array
+	// instead of a nil or empty object.  This method works around that.
+	if bytes.Equal(data, []byte("[]")) {
+		return nil
+	}
+
+	var v struct {
+		Items json.RawMessage `json:"items"`
+	}
+
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+
+	var items []struct {
+		Brewery *rawBrewery `json:"brewery"`
+	}
+	if err := unmarshalItems(v.Items, &items); err != nil {
+		return err
+	}
+
+	breweries := make([]*rawBrewery, 0, len(items))
+	for _, item := range items {
+		if item.Brewery != nil {
+			breweries = append(breweries, item.Brewery)
+		}
+	}
+
+	*r = responseCollaborations(breweries)
+	return nil
+}
+
+// responseUserMedia implements json.Unmarshaler, so that the varying shapes
+// of a user's media can be appropriately handled.
+type responseUserMedia []*rawUserMedia
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (r *responseUserMedia) UnmarshalJSON(data []byte) error {
+	// If no media exists for a user, the API returns an empty array
+	// instead of a nil or empty object.  This method works around that.
+	if bytes.Equal(data, []byte("[]")) {
+		return nil
+	}
+
+	var v struct {
+		Items json.RawMessage `json:"items"`
+	}
+
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+
+	var items []*rawUserMedia
+	if err := unmarshalItems(v.Items, &items); err != nil {
+		return err
+	}
+
+	*r = responseUserMedia(items)
+	return nil
+}
+
+// unmarshalItems unmarshals an "items" value from the Untappd APIv4 into v,
+// which must be a pointer to a slice.  When only a single item is present,
+// the API may return a bare object instead of an array containing that object,
+// so objects are treated as an array with one element.
+func unmarshalItems(data []byte, v interface{}) error {
+	data = bytes.TrimSpace(data)
+	if len(data) == 0 || bytes.Equal(data, []byte("null")) {
+		return nil
+	}
+
+	if data[0] == '{' {
+		data = append(append([]byte{'['}, data...), ']')
+	}
+
+	return json.Unmarshal(data, v)
+}
+
+// responseNotificationCounts implements json.Unmarshaler, so that an empty
+// array for a user with no unread notifications can be appropriately handled.
+type responseNotificationCounts NotificationCounts
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (r *responseNotificationCounts) UnmarshalJSON(data []byte) error {
+	// If no unread notifications exist, the API may return an empty array
+	// instead of a nil or empty object.  This method works around that.
+	if bytes.Equal(data, []byte("[]")) {
+		return nil
+	}
+
+	var v struct {
+		Comments int `json:"comments"`
+		Toasts   int `json:"toasts"`
+		Friends  int `json:"friends"`
+		Messages int `json:"messages"`
+		News     int `json:"news"`
+	}
+
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+
+	*r = responseNotificationCounts(v)
+	return nil
+}
+
+// responseNotificationItems implements json.Unmarshaler, so that the varying
+// shapes of a user's notifications can be appropriately handled.
+type responseNotificationItems []*rawNotification
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (r *responseNotificationItems) UnmarshalJSON(data []byte) error {
+	// If no notifications exist for a user, the API returns an empty array
+	// instead of a nil or empty object.  This method works around that.
+	if bytes.Equal(data, []byte("[]")) {
+		return nil
+	}
+
+	var v struct {
+		Items json.RawMessage `json:"items"`
+	}
+
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+
+	var items []*rawNotification
+	if err := unmarshalItems(v.Items, &items); err != nil {
+		return err
+	}
+
+	*r = responseNotificationItems(items)
+	return nil
+}
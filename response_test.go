@@ -125,6 +125,16 @@ func Test_responseTimeUnmarshalJSON(t *testing.T) {
 			body:        []byte(`"` + time.RFC1123Z + `"`),
 			result:      time.Date(2006, time.January, 2, 15, 4, 5, 0, mst),
 		},
+		{
+			description: "empty string",
+			body:        []byte(`""`),
+			result:      time.Time{},
+		},
+		{
+			description: "null",
+			body:        []byte(`null`),
+			result:      time.Time{},
+		},
 		{
 			description: "bad time",
 			body:        []byte(`"01-01-2001"`),
@@ -276,6 +286,16 @@ func Test_responseBoolUnmarshalJSON(t *testing.T) {
 			body:        []byte(`2`),
 			err:         errInvalidBool,
 		},
+		{
+			description: "false",
+			body:        []byte(`false`),
+			result:      false,
+		},
+		{
+			description: "true",
+			body:        []byte(`true`),
+			result:      true,
+		},
 		{
 			description: "bad JSON",
 			body:        []byte(`}`),
@@ -299,25 +319,127 @@ func Test_responseBoolUnmarshalJSON(t *testing.T) {
 	}
 }
 
-// Test_responseBadgeLevelsUnmarshalJSON verifies that responseBadgeLevels.UnmarshalJSON
-// provides proper badge count and items values for a variety of responseBadgeLevels
+// Test_responseNumberUnmarshalJSON verifies that responseNumber.UnmarshalJSON
+// provides a proper number for a variety of responseNumber JSON values from
+// the Untappd APIv4.
+func Test_responseNumberUnmarshalJSON(t *testing.T) {
+	var tests = []struct {
+		description string
+		body        []byte
+		result      responseNumber
+		err         bool
+	}{
+		{
+			description: "zero",
+			body:        []byte(`0`),
+		},
+		{
+			description: "number",
+			body:        []byte(`5.8`),
+			result:      5.8,
+		},
+		{
+			description: "string",
+			body:        []byte(`"5.8"`),
+			result:      5.8,
+		},
+		{
+			description: "empty string",
+			body:        []byte(`""`),
+		},
+		{
+			description: "null",
+			body:        []byte(`null`),
+		},
+		{
+			description: "non-numeric string",
+			body:        []byte(`"N/A"`),
+			err:         true,
+		},
+	}
+
+	for _, tt := range tests {
+		r := new(responseNumber)
+		err := r.UnmarshalJSON(tt.body)
+		if err != nil && !tt.err {
+			t.Fatalf("unexpected error for test %q: %v", tt.description, err)
+		}
+		if err == nil && tt.err {
+			t.Fatalf("expected an error for test %q", tt.description)
+		}
+
+		if *r != tt.result {
+			t.Fatalf("unexpected responseNumber for test %q: %v != %v", tt.description, *r, tt.result)
+		}
+	}
+}
+
+// Test_responseScoreUnmarshalJSON verifies that responseScore.UnmarshalJSON
+// provides a proper score for a variety of responseScore JSON values from
+// the Untappd APIv4.
+func Test_responseScoreUnmarshalJSON(t *testing.T) {
+	var tests = []struct {
+		description string
+		body        []byte
+		result      responseScore
+		err         bool
+	}{
+		{
+			description: "number",
+			body:        []byte(`4.5`),
+			result:      4.5,
+		},
+		{
+			description: "object",
+			body:        []byte(`{"total_points":6}`),
+			result:      6,
+		},
+		{
+			description: "empty object",
+			body:        []byte(`{}`),
+		},
+		{
+			description: "string",
+			body:        []byte(`"foo"`),
+			err:         true,
+		},
+	}
+
+	for _, tt := range tests {
+		r := new(responseScore)
+		err := r.UnmarshalJSON(tt.body)
+		if err != nil && !tt.err {
+			t.Fatalf("unexpected error for test %q: %v", tt.description, err)
+		}
+		if err == nil && tt.err {
+			t.Fatalf("expected an error for test %q", tt.description)
+		}
+
+		if *r != tt.result {
+			t.Fatalf("unexpected responseScore for test %q: %v != %v", tt.description, r, tt.result)
+		}
+	}
+}
+
+// Test_responseBadgesUnmarshalJSON verifies that responseBadges.UnmarshalJSON
+// provides proper badge count and items values for a variety of responseBadges
 // JSON values from the Untappd APIv4.
-func Test_responseBadgeLevelsUnmarshalJSON(t *testing.T) {
+func Test_responseBadgesUnmarshalJSON(t *testing.T) {
 	var tests = []struct {
 		description string
 		body        []byte
-		result      responseBadgeLevels
+		result      responseBadges
 		err         error
 	}{
 		{
 			description: "no badge levels (special API case)",
 			body:        []byte(`[]`),
-			result:      responseBadgeLevels{},
+			result:      responseBadges{},
 		},
 		{
 			description: "no badge levels (possibly non-existant case)",
 			body:        []byte(`{"count":0,"items":[]}`),
-			result: responseBadgeLevels{
+			result: responseBadges{
 				Count: 0,
 				Items: []*rawBadge{},
 			},
@@ -325,7 +447,7 @@ func Test_responseBadgeLevelsUnmarshalJSON(t *testing.T) {
 		{
 			description: "1 badge level",
 			body:        []byte(`{"count":1,"items":[{"badge_name":"Foo (Level 1)"}]}`),
-			result: responseBadgeLevels{
+			result: responseBadges{
 				Count: 1,
 				Items: []*rawBadge{
 					&rawBadge{
@@ -337,7 +459,7 @@ func Test_responseBadgeLevelsUnmarshalJSON(t *testing.T) {
 		{
 			description: "2 badge levels",
 			body:        []byte(`{"count":2,"items":[{"badge_name":"Foo (Level 2)"},{"badge_name":"Foo (Level 1)"}]}`),
-			result: responseBadgeLevels{
+			result: responseBadges{
 				Count: 2,
 				Items: []*rawBadge{
 					&rawBadge{
@@ -357,7 +479,7 @@ func Test_responseBadgeLevelsUnmarshalJSON(t *testing.T) {
 	}
 
 	for _, tt := range tests {
-		r := new(responseBadgeLevels)
+		r := new(responseBadges)
 		err := r.UnmarshalJSON(tt.body)
 		if tt.err == nil && err != nil {
 			t.Fatal(err)
@@ -366,8 +488,8 @@ func Test_responseBadgeLevelsUnmarshalJSON(t *testing.T) {
 			t.Fatalf("unexpected error for test %q: %v != %v", tt.description, err, tt.err)
 		}
 
-		if !reflect.DeepEqual(*r, responseBadgeLevels(tt.result)) {
-			t.Fatalf("unexpected responseBadgeLevels for test %q: %v != %v", tt.description, r, tt.result)
+		if !reflect.DeepEqual(*r, responseBadges(tt.result)) {
+			t.Fatalf("unexpected responseBadges for test %q: %v != %v", tt.description, r, tt.result)
 		}
 	}
 }
@@ -400,6 +522,32 @@ func Test_responseVenueUnmarshalJSON(t *testing.T) {
 				Name: "foo",
 			},
 		},
+		{
+			description: "venue without last_updated",
+			body:        []byte(`{"venue_id":1,"venue_name":"foo","primary_category":"Bar / Pub"}`),
+			result: responseVenue{
+				ID:       1,
+				Name:     "foo",
+				Category: "Bar / Pub",
+			},
+		},
+		{
+			description: "venue with empty last_updated",
+			body:        []byte(`{"venue_id":1,"venue_name":"foo","last_updated":""}`),
+			result: responseVenue{
+				ID:   1,
+				Name: "foo",
+			},
+		},
+		{
+			description: "venue with last_updated",
+			body:        []byte(`{"venue_id":1,"venue_name":"foo","last_updated":"Sat, 10 Jan 2015 04:12:09 +0000"}`),
+			result: responseVenue{
+				ID:      1,
+				Name:    "foo",
+				Updated: responseTime(time.Date(2015, time.January, 10, 4, 12, 9, 0, time.UTC)),
+			},
+		},
 		{
 			description: "bad JSON",
 			body:        []byte(`}`),
@@ -417,8 +565,121 @@ func Test_responseVenueUnmarshalJSON(t *testing.T) {
 			t.Fatalf("unexpected error for test %q: %v != %v", tt.description, err, tt.err)
 		}
 
+		// Compare times separately, as locations may differ
+		if got, want := time.Time(r.Updated), time.Time(tt.result.Updated); !got.Equal(want) {
+			t.Fatalf("unexpected venue updated time for test %q: %v != %v", tt.description, got, want)
+		}
+		r.Updated, tt.result.Updated = responseTime{}, responseTime{}
+
 		if !reflect.DeepEqual(*r, responseVenue(tt.result)) {
 			t.Fatalf("unexpected responseVenue for test %q: %v != %v", tt.description, r, tt.result)
 		}
 	}
 }
+
+// Test_responseNotificationsUnmarshalJSON verifies that
+// responseNotifications.UnmarshalJSON handles each shape of the notifications
+// block returned by the Untappd APIv4.
+func Test_responseNotificationsUnmarshalJSON(t *testing.T) {
+	var tests = []struct {
+		description string
+		body        []byte
+		result      NotificationCounts
+		err         error
+	}{
+		{
+			description: "no notifications (empty array, special API case)",
+			body:        []byte(`[]`),
+		},
+		{
+			description: "no notifications (empty object)",
+			body:        []byte(`{}`),
+		},
+		{
+			description: "notifications exist",
+			body:        []byte(`{"type":"notifications","unread_count":{"comments":1,"toasts":2,"friends":3,"messages":4,"news":5}}`),
+			result: NotificationCounts{
+				Comments: 1,
+				Toasts:   2,
+				Friends:  3,
+				Messages: 4,
+				News:     5,
+			},
+		},
+		{
+			description: "bad JSON",
+			body:        []byte(`}`),
+			err:         errBadJSON,
+		},
+	}
+
+	for _, tt := range tests {
+		r := new(responseNotifications)
+		err := r.UnmarshalJSON(tt.body)
+		if tt.err == nil && err != nil {
+			t.Fatal(err)
+		}
+		if tt.err != nil && err.Error() != tt.err.Error() {
+			t.Fatalf("unexpected error for test %q: %v != %v", tt.description, err, tt.err)
+		}
+
+		if got := NotificationCounts(r.UnreadCount); got != tt.result {
+			t.Fatalf("unexpected unread counts for test %q: %+v != %+v", tt.description, got, tt.result)
+		}
+	}
+}
+
+// Test_responseRecentBrewsUnmarshalJSON verifies that responseRecentBrews.UnmarshalJSON
+// handles each shape of recent brews JSON returned by the Untappd APIv4.
+func Test_responseRecentBrewsUnmarshalJSON(t *testing.T) {
+	var tests = []struct {
+		description string
+		body        []byte
+		names       []string
+		err         error
+	}{
+		{
+			description: "no recent brews (special API case)",
+			body:        []byte(`[]`),
+		},
+		{
+			description: "no recent brews",
+			body:        []byte(`{"count":0,"items":[]}`),
+		},
+		{
+			description: "1 recent brew as object",
+			body:        []byte(`{"count":1,"items":{"beer":{"beer_name":"Foo"}}}`),
+			names:       []string{"Foo"},
+		},
+		{
+			description: "2 recent brews as array",
+			body:        []byte(`{"count":2,"items":[{"beer":{"beer_name":"Foo"}},{"beer":{"beer_name":"Bar"}}]}`),
+			names:       []string{"Foo", "Bar"},
+		},
+		{
+			description: "bad JSON",
+			body:        []byte(`}`),
+			err:         errBadJSON,
+		},
+	}
+
+	for _, tt := range tests {
+		r := new(responseRecentBrews)
+		err := r.UnmarshalJSON(tt.body)
+		if tt.err == nil && err != nil {
+			t.Fatal(err)
+		}
+		if tt.err != nil && err.Error() != tt.err.Error() {
+			t.Fatalf("unexpected error for test %q: %v != %v", tt.description, err, tt.err)
+		}
+
+		if l := len(*r); l != len(tt.names) {
+			t.Fatalf("unexpected number of recent brews for test %q: %d != %d", tt.description, l, len(tt.names))
+		}
+		for i := range *r {
+			if n := (*r)[i].Beer.Name; n != tt.names[i] {
+				t.Fatalf("unexpected recent brew name for test %q: %q != %q", tt.description, n, tt.names[i])
+			}
+		}
+	}
+}
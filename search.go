@@ -0,0 +1,164 @@
+package untappd
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// ErrInvalidSearchType is returned when a SearchType which is not one of the
+// provided SearchType constants is passed to Client.Search.
+var ErrInvalidSearchType = errors.New("invalid search type")
+
+// SearchType is a type of result which may be returned by Client.Search.
+// A set of SearchType constants are provided for ease of use.
+type SearchType string
+
+// Constants that define the types of results that the Untappd APIv4 can
+// return from a combined search.
+const (
+	// SearchAll searches for both beers and breweries.
+	SearchAll SearchType = "all"
+
+	// SearchBeer searches only for beers.
+	SearchBeer SearchType = "beer"
+
+	// SearchBrewery searches only for breweries.
+	SearchBrewery SearchType = "brewery"
+)
+
+// SearchResult is the result of a combined search using Client.Search.
+// Depending on the SearchType used, either Beers or Breweries may be empty.
+type SearchResult struct {
+	Beers     []*Beer
+	Breweries []*Brewery
+}
+
+// Search searches for information about beers and breweries, using the
+// specified search query.  The kind parameter determines which types of
+// results are returned, and must be one of the provided SearchType constants,
+// or ErrInvalidSearchType is returned.  In addition, it accepts offset and
+// limit parameters to enable paging through the results list.
+//
+// 50 results of each type is the maximum number of results which may be
+// returned by one call.  If limit exceeds MaxBeersLimit, ErrInvalidLimit is
+// returned.
+//
+// Search is useful for a single search box which should query for both beers
+// and breweries.  To sort beer results, use Beer.SearchOffsetLimitSort instead.
+func (c *Client) Search(query string, kind SearchType, offset int, limit int) (*SearchResult, *http.Response, error) {
+	switch kind {
+	case SearchAll, SearchBeer, SearchBrewery:
+	default:
+		return nil, nil, ErrInvalidSearchType
+	}
+
+	if err := checkLimit(limit, MaxBeersLimit); err != nil {
+		return nil, nil, err
+	}
+
+	q := url.Values{
+		"q":      []string{query},
+		"type":   []string{string(kind)},
+		"offset": []string{strconv.Itoa(offset)},
+		"limit":  []string{strconv.Itoa(limit)},
+	}
+
+	// Temporary struct to unmarshal combined search JSON
+	var v struct {
+		Response struct {
+			Beers struct {
+				Items []struct {
+					CheckinCount int        `json:"checkin_count"`
+					Beer         rawBeer    `json:"beer"`
+					Brewery      rawBrewery `json:"brewery"`
+				} `json:"items"`
+			} `json:"beers"`
+			Brewery struct {
+				Items []struct {
+					Brewery rawBrewery `json:"brewery"`
+				} `json:"items"`
+			} `json:"brewery"`
+		} `json:"response"`
+	}
+
+	// Perform request for combined search
+	res, err := c.request("GET", "search", nil, q, &v)
+	if err != nil {
+		return nil, res, err
+	}
+
+	// Build result slices from struct
+	beers := make([]*Beer, len(v.Response.Beers.Items))
+	for i, item := range v.Response.Beers.Items {
+		// Information about the beer itself, and its brewery
+		beers[i] = item.Beer.exportWithBrewery(item.Brewery)
+		beers[i].OverallCount = item.CheckinCount
+		beers[i].CheckinCount = item.CheckinCount
+	}
+
+	breweries := make([]*Brewery, len(v.Response.Brewery.Items))
+	for i := range v.Response.Brewery.Items {
+		breweries[i] = v.Response.Brewery.Items[i].Brewery.export()
+	}
+
+	return &SearchResult{
+		Beers:     beers,
+		Breweries: breweries,
+	}, res, nil
+}
+
+// SearchTotal contains the number of results found by a search, as returned
+// by the Untappd APIv4 alongside a page of search results.
+type SearchTotal struct {
+	// The total number of results which matched the search query.
+	Found int `json:"found"`
+
+	// The number of results returned in this page.
+	Count int `json:"count"`
+}
+
+// More reports whether more results exist beyond the page which was
+// requested using the specified offset.
+func (s *SearchTotal) More(offset int) bool {
+	return offset+s.Count < s.Found
+}
+
+// ResponseSearchTotal returns the SearchTotal from the *http.Response returned
+// by a method which searches for beers or breweries, such as
+// Beer.SearchOffsetLimitSort or Brewery.SearchOffsetLimit.  It can be used to
+// determine when to stop paging through search results.
+//
+// The response body is left intact, so ResponseSearchTotal may be called
+// more than once for the same response.
+func ResponseSearchTotal(res *http.Response) (*SearchTotal, error) {
+	body, err := peekBody(res)
+	if err != nil || body == nil {
+		return nil, err
+	}
+
+	var v struct {
+		Response struct {
+			Found int `json:"found"`
+			Beers struct {
+				Count int `json:"count"`
+			} `json:"beers"`
+			Brewery struct {
+				Count int `json:"count"`
+			} `json:"brewery"`
+		} `json:"response"`
+	}
+
+	if err := json.Unmarshal(body, &v); err != nil {
+		return nil, err
+	}
+
+	// Only one of beers or breweries is returned by each search method
+	r := v.Response
+	return &SearchTotal{
+		Found: r.Found,
+		Count: r.Beers.Count + r.Brewery.Count,
+	}, nil
+}
@@ -0,0 +1,171 @@
+package untappd
+
+import (
+	"net/http"
+	"net/url"
+	"strconv"
+	"testing"
+)
+
+// TestClientSearchBadSearchType verifies that Client.Search returns
+// ErrInvalidSearchType when an unknown SearchType is used.
+func TestClientSearchBadSearchType(t *testing.T) {
+	c, done := searchTestClient(t, func(t *testing.T, w http.ResponseWriter, r *http.Request) {
+		t.Fatal("request should not be performed")
+	})
+	defer done()
+
+	if _, _, err := c.Search("foo", SearchType("venue"), 0, 25); err != ErrInvalidSearchType {
+		t.Fatalf("unexpected error: %v != %v", err, ErrInvalidSearchType)
+	}
+}
+
+// TestClientSearchBadLimit verifies that Client.Search returns
+// ErrInvalidLimit when a limit exceeding MaxBeersLimit is used.
+func TestClientSearchBadLimit(t *testing.T) {
+	c, done := searchTestClient(t, func(t *testing.T, w http.ResponseWriter, r *http.Request) {
+		t.Fatal("request should not be performed")
+	})
+	defer done()
+
+	if _, _, err := c.Search("foo", SearchAll, 0, MaxBeersLimit+1); err != ErrInvalidLimit {
+		t.Fatalf("unexpected error: %v != %v", err, ErrInvalidLimit)
+	}
+}
+
+// TestClientSearchOK verifies that Client.Search returns a combined list
+// of beers and breweries, when used with correct parameters.
+func TestClientSearchOK(t *testing.T) {
+	var offset int
+	var limit = 25
+
+	query := "russian river"
+	c, done := searchTestClient(t, func(t *testing.T, w http.ResponseWriter, r *http.Request) {
+		assertParameters(t, r, url.Values{
+			"q":      []string{query},
+			"type":   []string{string(SearchAll)},
+			"offset": []string{strconv.Itoa(offset)},
+			"limit":  []string{strconv.Itoa(limit)},
+		})
+
+		w.Write(searchJSON)
+	})
+	defer done()
+
+	sr, _, err := c.Search(query, SearchAll, offset, limit)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if l := len(sr.Beers); l != 1 {
+		t.Fatalf("unexpected number of beers: %d != %d", l, 1)
+	}
+	b := sr.Beers[0]
+	if b.ID != 1 || b.Name != "Pliny the Elder" || b.CheckinCount != 123 {
+		t.Fatalf("unexpected beer: %+v", b)
+	}
+	if b.Brewery.Name != "Russian River Brewing Company" {
+		t.Fatalf("unexpected beer Brewery.Name: %q", b.Brewery.Name)
+	}
+
+	if l := len(sr.Breweries); l != 2 {
+		t.Fatalf("unexpected number of breweries: %d != %d", l, 2)
+	}
+	for i, name := range []string{"Russian River Brewing Company", "Russian River Valley Brewing"} {
+		if n := sr.Breweries[i].Name; n != name {
+			t.Fatalf("unexpected brewery Name: %q != %q", n, name)
+		}
+	}
+}
+
+// TestClientSearchBeerOnly verifies that Client.Search returns an empty
+// list of breweries when only beers are returned.
+func TestClientSearchBeerOnly(t *testing.T) {
+	c, done := searchTestClient(t, func(t *testing.T, w http.ResponseWriter, r *http.Request) {
+		assertParameters(t, r, url.Values{
+			"type": []string{string(SearchBeer)},
+		})
+
+		w.Write(beerSearchJSON)
+	})
+	defer done()
+
+	sr, _, err := c.Search("pliny", SearchBeer, 0, 25)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if l := len(sr.Beers); l != 2 {
+		t.Fatalf("unexpected number of beers: %d != %d", l, 2)
+	}
+	if l := len(sr.Breweries); l != 0 {
+		t.Fatalf("unexpected number of breweries: %d != %d", l, 0)
+	}
+}
+
+// searchTestClient builds upon testClient, and adds additional sanity checks
+// for tests which target the combined search API.
+func searchTestClient(t *testing.T, fn func(t *testing.T, w http.ResponseWriter, r *http.Request)) (*Client, func()) {
+	return testClient(t, func(t *testing.T, w http.ResponseWriter, r *http.Request) {
+		// Always GET request
+		method := "GET"
+		if m := r.Method; m != method {
+			t.Fatalf("unexpected HTTP method: %q != %q", m, method)
+		}
+
+		// Always uses specific path
+		path := "/v4/search/"
+		if p := r.URL.Path; p != path {
+			t.Fatalf("unexpected HTTP path: %q != %q", p, path)
+		}
+
+		// Guard against panics
+		if fn != nil {
+			fn(t, w, r)
+		}
+	})
+}
+
+// Canned combined search JSON response, modeled after the beer and brewery
+// search responses: https://untappd.com/api/docs#beersearch
+var searchJSON = []byte(`{
+  "meta": {
+    "code": 200
+  },
+  "notifications": {},
+  "response": {
+    "beers": {
+      "count": 1,
+      "items": [
+        {
+          "checkin_count": 123,
+          "beer": {
+            "bid": 1,
+            "beer_name": "Pliny the Elder",
+            "beer_style": "Imperial / Double IPA"
+          },
+          "brewery": {
+            "brewery_name": "Russian River Brewing Company"
+          }
+        }
+      ]
+    },
+    "brewery": {
+      "count": 2,
+      "items": [
+        {
+          "brewery": {
+            "brewery_id": 5143,
+            "brewery_name": "Russian River Brewing Company"
+          }
+        },
+        {
+          "brewery": {
+            "brewery_id": 5144,
+            "brewery_name": "Russian River Valley Brewing"
+          }
+        }
+      ]
+    }
+  }
+}`)
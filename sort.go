@@ -1,5 +1,13 @@
 package untappd
 
+import "errors"
+
+// ErrInvalidSort is returned when a Sort which is not returned by Sorts is
+// passed to a method which sorts its results.  Unknown sorts are rejected
+// without performing a request, because the Untappd APIv4 silently falls
+// back to its default sort for them.
+var ErrInvalidSort = errors.New("invalid sort")
+
 // Sort is a sorting method accepted by the Untappd APIv4.
 // A set of Sort constants are provided for ease of use.
 type Sort string
@@ -10,6 +18,9 @@ const (
 	// SortDate sorts a list of beers by most recent date checked in.
 	SortDate Sort = "date"
 
+	// SortDateAscending sorts a list of beers by least recent date checked in.
+	SortDateAscending Sort = "date_asc"
+
 	// SortCheckin sorts a list of beers by highest number of checkins.
 	SortCheckin Sort = "checkin"
 
@@ -32,12 +43,27 @@ const (
 
 	// SortLowestABV sorts a list of beers by lowest alcohol by volume on Untappd.
 	SortLowestABV Sort = "lowest_abv"
+
+	// SortBeerName sorts a list of beers alphabetically by beer name.
+	SortBeerName Sort = "beer_name_asc"
+
+	// SortBeerNameDescending sorts a list of beers reverse alphabetically
+	// by beer name.
+	SortBeerNameDescending Sort = "beer_name_desc"
+
+	// SortBreweryName sorts a list of beers alphabetically by brewery name.
+	SortBreweryName Sort = "brewery_name_asc"
+
+	// SortBreweryNameDescending sorts a list of beers reverse alphabetically
+	// by brewery name.
+	SortBreweryNameDescending Sort = "brewery_name_desc"
 )
 
 // Sorts returns a slice of all available Sort constants.
 func Sorts() []Sort {
 	return []Sort{
 		SortDate,
+		SortDateAscending,
 		SortCheckin,
 		SortHighestRated,
 		SortLowestRated,
@@ -45,5 +71,50 @@ func Sorts() []Sort {
 		SortUserLowestRated,
 		SortHighestABV,
 		SortLowestABV,
+		SortBeerName,
+		SortBeerNameDescending,
+		SortBreweryName,
+		SortBreweryNameDescending,
 	}
 }
+
+// sortDescriptions contains a human-readable description of each Sort
+// returned by Sorts.
+var sortDescriptions = map[Sort]string{
+	SortDate:                  "Most recent",
+	SortDateAscending:         "Least recent",
+	SortCheckin:               "Most checkins",
+	SortHighestRated:          "Highest rated",
+	SortLowestRated:           "Lowest rated",
+	SortUserHighestRated:      "Highest rated by you",
+	SortUserLowestRated:       "Lowest rated by you",
+	SortHighestABV:            "Highest ABV",
+	SortLowestABV:             "Lowest ABV",
+	SortBeerName:              "Beer name (A-Z)",
+	SortBeerNameDescending:    "Beer name (Z-A)",
+	SortBreweryName:           "Brewery name (A-Z)",
+	SortBreweryNameDescending: "Brewery name (Z-A)",
+}
+
+// SortDescription returns a short, human-readable description of s, such as
+// "Most recent" for SortDate, which is suitable for display in a menu of
+// sorting options.  If s is not one of the values returned by Sorts, its
+// string value is returned.
+func SortDescription(s Sort) string {
+	if d, ok := sortDescriptions[s]; ok {
+		return d
+	}
+
+	return string(s)
+}
+
+// valid determines if s is one of the Sort constants returned by Sorts.
+func (s Sort) valid() bool {
+	for _, ss := range Sorts() {
+		if s == ss {
+			return true
+		}
+	}
+
+	return false
+}
@@ -6,6 +6,7 @@ import "testing"
 func TestSorts(t *testing.T) {
 	for _, s := range []Sort{
 		SortDate,
+		SortDateAscending,
 		SortCheckin,
 		SortHighestRated,
 		SortLowestRated,
@@ -13,6 +14,10 @@ func TestSorts(t *testing.T) {
 		SortUserLowestRated,
 		SortHighestABV,
 		SortLowestABV,
+		SortBeerName,
+		SortBeerNameDescending,
+		SortBreweryName,
+		SortBreweryNameDescending,
 	} {
 		var found bool
 		for _, ss := range Sorts() {
@@ -28,3 +33,39 @@ func TestSorts(t *testing.T) {
 		t.Fatalf("unknown Sort type: %q", s)
 	}
 }
+
+// TestSortValid verifies that only Sort types returned by Sorts are valid.
+func TestSortValid(t *testing.T) {
+	for _, s := range Sorts() {
+		if !s.valid() {
+			t.Fatalf("Sort type should be valid: %q", s)
+		}
+	}
+
+	for _, s := range []Sort{"", "foo", "DATE"} {
+		if s.valid() {
+			t.Fatalf("Sort type should be invalid: %q", s)
+		}
+	}
+}
+
+// TestSortDescription verifies that every Sort returned by Sorts has a
+// unique description, and that unknown Sorts are described by their value.
+func TestSortDescription(t *testing.T) {
+	seen := make(map[string]Sort)
+	for _, s := range Sorts() {
+		d := SortDescription(s)
+		if d == "" || d == string(s) {
+			t.Fatalf("missing description for Sort: %q", s)
+		}
+
+		if ss, ok := seen[d]; ok {
+			t.Fatalf("duplicate description %q for Sorts %q and %q", d, ss, s)
+		}
+		seen[d] = s
+	}
+
+	if got, want := SortDescription("foo"), "foo"; got != want {
+		t.Fatalf("unexpected description for unknown Sort: %q != %q", got, want)
+	}
+}
@@ -8,21 +8,21 @@ import (
 // regarding the toast, and the User who performed the toast.
 type Toast struct {
 	// Metadata from Untappd.
-	ID     int
-	UserID int
+	ID     int64 `json:"id"`
+	UserID int64 `json:"user_id"`
 
 	// Time when this toast was submitted to Untappd.
-	Created time.Time
+	Created time.Time `json:"created"`
 
 	// The user who performed the Toast.
-	User *User
+	User *User `json:"user"`
 }
 
 // rawToast is the raw JSON representation of an Untappd toast.  Its data is
 // unmarshaled from JSON and then exported to a Toast struct.
 type rawToast struct {
-	ID      int          `json:"like_id"`
-	UserID  int          `json:"uid"`
+	ID      int64        `json:"like_id"`
+	UserID  int64        `json:"uid"`
 	Created responseTime `json:"created_at"`
 	User    *rawUser     `json:"user"`
 }
@@ -37,3 +37,51 @@ func (r *rawToast) export() *Toast {
 		User:    r.User.export(),
 	}
 }
+
+// ToastResult is the result of toasting a Checkin, and contains the created
+// Toast, as well as the updated total number of toasts for the Checkin.
+type ToastResult struct {
+	// The toast which was created.  If toasting removed an existing toast
+	// from the Checkin, Toast is nil.
+	Toast *Toast `json:"toast"`
+
+	// Updated total number of toasts for the Checkin.
+	TotalCount int `json:"total_count"`
+}
+
+// rawToastResult is the raw JSON representation of an Untappd toast result.
+// Its data is unmarshaled from JSON and then exported to a ToastResult struct.
+type rawToastResult struct {
+	LikeID   int64  `json:"like_id"`
+	LikeType string `json:"like_type"`
+	Toasts   struct {
+		TotalCount int         `json:"total_count"`
+		Items      []*rawToast `json:"items"`
+	} `json:"toasts"`
+}
+
+// export creates an exported ToastResult from a rawToastResult struct,
+// allowing for more useful structures to be created for client consumption.
+func (r *rawToastResult) export() *ToastResult {
+	res := &ToastResult{
+		TotalCount: r.Toasts.TotalCount,
+	}
+
+	// An "untoast" indicates that an existing toast was removed
+	if r.LikeID == 0 || r.LikeType == "untoast" {
+		return res
+	}
+
+	// Prefer the full toast from the list of toasts, if present
+	for _, t := range r.Toasts.Items {
+		if t != nil && t.ID == r.LikeID && t.User != nil {
+			res.Toast = t.export()
+			return res
+		}
+	}
+
+	res.Toast = &Toast{
+		ID: r.LikeID,
+	}
+	return res
+}
@@ -0,0 +1,65 @@
+package untappd
+
+import "net/http"
+
+// AuthTransport is an http.RoundTripper which adds Untappd APIv4 credentials
+// to the query string of each request before passing it to an underlying
+// http.RoundTripper.
+//
+// Every Client uses an AuthTransport to apply its credentials, unless the
+// WithNoAutoCredentials option is used.  AuthTransport is exported so that
+// credentials may also be applied by an http.Client which is used to perform
+// requests outside of this package, such as when composing other middleware
+// for proxies or signed requests.
+//
+// If AccessToken is set, it is always preferred.  Otherwise, ClientID and
+// ClientSecret are used.
+//
+// If Host is set, credentials are only added to requests whose URL host is
+// Host, and other requests, such as redirects to another host, are performed
+// without credentials.  A Client always sets Host to the host of the Untappd
+// APIv4.
+type AuthTransport struct {
+	AccessToken  string
+	ClientID     string
+	ClientSecret string
+	Host         string
+
+	// Transport is the http.RoundTripper used to perform requests.  If nil,
+	// http.DefaultTransport is used.
+	Transport http.RoundTripper
+}
+
+// RoundTrip implements http.RoundTripper.  The input request is not
+// modified; credentials are added to a copy of the request.
+func (t *AuthTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	// Never leak credentials to a host other than the API
+	if t.Host != "" && req.URL.Host != t.Host {
+		return t.transport().RoundTrip(req)
+	}
+
+	r := req.Clone(req.Context())
+
+	// Always prefer authenticated client access, using an access token.
+	// If no token is found, fall back to unauthenticated client ID and
+	// client secret.
+	q := r.URL.Query()
+	if t.AccessToken != "" {
+		q.Set("access_token", t.AccessToken)
+	} else {
+		q.Set("client_id", t.ClientID)
+		q.Set("client_secret", t.ClientSecret)
+	}
+	r.URL.RawQuery = q.Encode()
+
+	return t.transport().RoundTrip(r)
+}
+
+// transport returns the http.RoundTripper used by an AuthTransport.
+func (t *AuthTransport) transport() http.RoundTripper {
+	if t.Transport == nil {
+		return http.DefaultTransport
+	}
+
+	return t.Transport
+}
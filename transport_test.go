@@ -0,0 +1,137 @@
+package untappd
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+// TestAuthTransport verifies that AuthTransport adds the appropriate
+// credentials to a request, without modifying the input request.
+func TestAuthTransport(t *testing.T) {
+	var tests = []struct {
+		description string
+		t           *AuthTransport
+		params      url.Values
+	}{
+		{
+			description: "client ID and secret",
+			t: &AuthTransport{
+				ClientID:     "foo",
+				ClientSecret: "bar",
+			},
+			params: url.Values{
+				"client_id":     []string{"foo"},
+				"client_secret": []string{"bar"},
+				"access_token":  []string{""},
+			},
+		},
+		{
+			description: "access token preferred",
+			t: &AuthTransport{
+				AccessToken:  "baz",
+				ClientID:     "foo",
+				ClientSecret: "bar",
+			},
+			params: url.Values{
+				"access_token":  []string{"baz"},
+				"client_id":     []string{""},
+				"client_secret": []string{""},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assertParameters(t, r, tt.params)
+			assertParameters(t, r, url.Values{
+				"q": []string{"pliny"},
+			})
+		}))
+
+		req, err := http.NewRequest("GET", srv.URL+"/?q=pliny", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		res, err := tt.t.RoundTrip(req)
+		srv.Close()
+		if err != nil {
+			t.Fatalf("[%s] %v", tt.description, err)
+		}
+		res.Body.Close()
+
+		if q := req.URL.RawQuery; q != "q=pliny" {
+			t.Fatalf("[%s] input request should not have been modified: %q", tt.description, q)
+		}
+	}
+}
+
+// TestAuthTransportHost verifies that AuthTransport only adds credentials to
+// requests for its Host.
+func TestAuthTransportHost(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if q := r.URL.RawQuery; q != "q=pliny" {
+			t.Fatalf("unexpected credentials for another host: %q", q)
+		}
+	}))
+	defer srv.Close()
+
+	req, err := http.NewRequest("GET", srv.URL+"/?q=pliny", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	at := &AuthTransport{
+		AccessToken: "foo",
+		Host:        "api.untappd.com",
+	}
+
+	res, err := at.RoundTrip(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	res.Body.Close()
+}
+
+// TestClientRedirectCredentials verifies that a Client does not send its
+// credentials to another host when the API redirects a request.
+func TestClientRedirectCredentials(t *testing.T) {
+	other := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		for _, k := range []string{"access_token", "client_id", "client_secret"} {
+			if _, ok := q[k]; ok {
+				t.Fatalf("unexpected credential sent to another host: %q", k)
+			}
+		}
+
+		w.Header().Set("Content-Type", jsonContentType)
+		io.WriteString(w, "{}")
+	}))
+	defer other.Close()
+
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assertParameters(t, r, url.Values{
+			"access_token": []string{"SECRET_TOKEN"},
+		})
+
+		http.Redirect(w, r, other.URL+"/v4/foo/", http.StatusFound)
+	}))
+	defer api.Close()
+
+	u, err := url.Parse(api.URL + "/v4")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c, err := NewAuthenticatedClient("SECRET_TOKEN", &http.Client{}, withURL(u))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := c.request("GET", "foo", nil, nil, nil); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,276 @@
+package untappdtest
+
+// Fixtures contains the canned JSON responses used by a Server, keyed by
+// Untappd APIv4 endpoint.  The responses are modeled after the examples in
+// the Untappd APIv4 documentation: https://untappd.com/api/docs.
+//
+// Fixtures should not be modified; use Server.Handle to change the responses
+// returned by a single Server.
+var Fixtures = map[string][]byte{
+	"user/info":      UserInfoJSON,
+	"user/checkins":  UserCheckinsJSON,
+	"beer/info":      BeerInfoJSON,
+	"brewery/info":   BreweryInfoJSON,
+	"search/beer":    BeerSearchJSON,
+	"search/brewery": BrewerySearchJSON,
+}
+
+// UserInfoJSON is a canned response for the user/info endpoint, describing
+// the user "gregavola".
+var UserInfoJSON = []byte(`{
+  "meta": {
+    "code": 200,
+    "response_time": {
+      "time": 0.081,
+      "measure": "seconds"
+    }
+  },
+  "notifications": [],
+  "response": {
+    "user": {
+      "uid": 1,
+      "id": 1,
+      "user_name": "gregavola",
+      "first_name": "Greg",
+      "last_name": "Avola",
+      "user_avatar": "https://gravatar.com/avatar/0c6922e238dae5cccce96a32889fc911?size=100",
+      "is_private": 0,
+      "location": "New York, NY",
+      "url": "http://gregavola.com",
+      "bio": "Co-Founder and CTO of Untappd",
+      "is_supporter": 1,
+      "untappd_url": "http://untappd.com/user/gregavola",
+      "stats": {
+        "total_badges": 379,
+        "total_friends": 1723,
+        "total_checkins": 2197,
+        "total_beers": 1187,
+        "total_created_beers": 65,
+        "total_followings": 176,
+        "total_photos": 325
+      }
+    }
+  }
+}`)
+
+// UserCheckinsJSON is a canned response for the user/checkins endpoint,
+// containing a single checkin.
+var UserCheckinsJSON = []byte(`{
+  "meta": {
+    "code": 200,
+    "response_time": {
+      "time": 0.841,
+      "measure": "seconds"
+    }
+  },
+  "notifications": [],
+  "response": {
+    "pagination": {
+      "since_url": "https://api.untappd.com/v4/user/checkins/gregavola?min_id=171626491",
+      "next_url": "",
+      "max_id": 171626491
+    },
+    "checkins": {
+      "count": 1,
+      "items": [
+        {
+          "checkin_id": 171626491,
+          "created_at": "Sat, 21 Mar 2015 21:36:54 +0000",
+          "checkin_comment": "Great brew!",
+          "rating_score": 4.25,
+          "user": {
+            "uid": 1,
+            "user_name": "gregavola",
+            "first_name": "Greg",
+            "last_name": "Avola"
+          },
+          "beer": {
+            "bid": 16630,
+            "beer_name": "Oberon Ale",
+            "beer_abv": 5.8,
+            "beer_style": "American Pale Wheat Ale"
+          },
+          "brewery": {
+            "brewery_id": 2507,
+            "brewery_name": "Bell's Brewery, Inc.",
+            "country_name": "United States"
+          },
+          "venue": []
+        }
+      ]
+    }
+  }
+}`)
+
+// BeerInfoJSON is a canned response for the beer/info endpoint, describing
+// the beer "Oberon Ale".
+var BeerInfoJSON = []byte(`{
+  "meta": {
+    "code": 200,
+    "response_time": {
+      "time": 0.112,
+      "measure": "seconds"
+    }
+  },
+  "notifications": [],
+  "response": {
+    "beer": {
+      "bid": 16630,
+      "beer_name": "Oberon Ale",
+      "beer_label": "https://untappd.akamaized.net/site/beer_logos/beer-16630.jpeg",
+      "beer_abv": 5.8,
+      "beer_ibu": 0,
+      "beer_description": "A wheat ale spiced with Saaz hops.",
+      "beer_style": "American Pale Wheat Ale",
+      "is_in_production": 1,
+      "beer_slug": "bell-s-brewery-oberon-ale",
+      "is_homebrew": 0,
+      "created_at": "Sat, 21 Aug 2010 07:34:34 +0000",
+      "rating_count": 123,
+      "rating_score": 3.6,
+      "weighted_rating_score": 3.59,
+      "stats": {
+        "total_count": 456,
+        "monthly_count": 12,
+        "total_user_count": 78,
+        "user_count": 0
+      },
+      "brewery": {
+        "brewery_id": 2507,
+        "brewery_name": "Bell's Brewery, Inc.",
+        "country_name": "United States"
+      }
+    }
+  }
+}`)
+
+// BreweryInfoJSON is a canned response for the brewery/info endpoint,
+// describing the brewery "Bell's Brewery, Inc.".
+var BreweryInfoJSON = []byte(`{
+  "meta": {
+    "code": 200,
+    "response_time": {
+      "time": 0.098,
+      "measure": "seconds"
+    }
+  },
+  "notifications": [],
+  "response": {
+    "brewery": {
+      "brewery_id": 2507,
+      "brewery_name": "Bell's Brewery, Inc.",
+      "brewery_slug": "bell-s-brewery-inc",
+      "brewery_label": "https://untappd.akamaized.net/site/brewery_logos/brewery-2507.jpeg",
+      "country_name": "United States",
+      "brewery_active": 1,
+      "brewery_type": "Regional Brewery",
+      "brewery_type_id": 6,
+      "beer_count": 1,
+      "contact": {
+        "twitter": "BellsBrewery",
+        "url": "http://www.bellsbeer.com"
+      },
+      "location": {
+        "brewery_city": "Kalamazoo",
+        "brewery_state": "MI",
+        "lat": 42.2848,
+        "lng": -85.4535
+      },
+      "beer_list": {
+        "count": 1,
+        "items": [
+          {
+            "beer": {
+              "bid": 16630,
+              "beer_name": "Oberon Ale",
+              "beer_style": "American Pale Wheat Ale"
+            },
+            "brewery": {
+              "brewery_id": 2507,
+              "brewery_name": "Bell's Brewery, Inc."
+            }
+          }
+        ]
+      }
+    }
+  }
+}`)
+
+// BeerSearchJSON is a canned response for the search/beer endpoint,
+// containing two beers.
+var BeerSearchJSON = []byte(`{
+  "meta": {
+    "code": 200,
+    "response_time": {
+      "time": 0.157,
+      "measure": "seconds"
+    }
+  },
+  "notifications": [],
+  "response": {
+    "found": 2,
+    "beers": {
+      "count": 2,
+      "items": [
+        {
+          "checkin_count": 123,
+          "beer": {
+            "bid": 1,
+            "beer_name": "Pliny the Elder",
+            "beer_style": "Imperial / Double IPA",
+            "rating_count": 12
+          },
+          "brewery": {
+            "brewery_id": 5143,
+            "brewery_name": "Russian River Brewing Company"
+          }
+        },
+        {
+          "checkin_count": 456,
+          "beer": {
+            "bid": 2,
+            "beer_name": "Pliny the Younger",
+            "beer_style": "Triple IPA",
+            "rating_count": 45
+          },
+          "brewery": {
+            "brewery_id": 5143,
+            "brewery_name": "Russian River Brewing Company"
+          }
+        }
+      ]
+    }
+  }
+}`)
+
+// BrewerySearchJSON is a canned response for the search/brewery endpoint,
+// containing a single brewery.
+var BrewerySearchJSON = []byte(`{
+  "meta": {
+    "code": 200,
+    "response_time": {
+      "time": 0.062,
+      "measure": "seconds"
+    }
+  },
+  "notifications": [],
+  "response": {
+    "found": 1,
+    "brewery": {
+      "count": 1,
+      "items": [
+        {
+          "brewery": {
+            "brewery_id": 5143,
+            "brewery_name": "Russian River Brewing Company",
+            "country_name": "United States"
+          }
+        }
+      ]
+    }
+  }
+}`)
+
+// notFoundJSON is the error response returned by a Server for an endpoint
+// which has no response.
+var notFoundJSON = []byte(`{"meta":{"code":404,"error_detail":"The requested resource could not be found.","error_type":"invalid_resource","response_time":{"time":0,"measure":"seconds"}},"response":[]}`)
@@ -0,0 +1,132 @@
+// Package untappdtest provides a fake Untappd APIv4 server for use in tests
+// of code which uses package untappd.
+//
+// A Server answers requests using canned JSON responses modeled after the
+// Untappd APIv4 documentation, so tests do not need to reproduce them.
+// Responses may be added or replaced using Server.Handle.
+package untappdtest
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/mdlayher/untappd"
+)
+
+const (
+	// apiPrefix is the path prefix used by the Untappd APIv4.
+	apiPrefix = "/v4/"
+
+	// jsonContentType is the content type for JSON data.
+	jsonContentType = "application/json"
+)
+
+// A Server is a fake Untappd APIv4 server.  Its embedded *httptest.Server
+// must be closed when the Server is no longer needed.
+type Server struct {
+	*httptest.Server
+
+	mu        sync.RWMutex
+	responses map[string][]byte
+}
+
+// NewServer starts a Server which answers requests using the canned
+// responses in Fixtures.
+func NewServer() *Server {
+	s := &Server{
+		responses: make(map[string][]byte, len(Fixtures)),
+	}
+	for path, body := range Fixtures {
+		s.responses[path] = body
+	}
+
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	return s
+}
+
+// Handle sets the JSON response body returned for requests to an Untappd
+// APIv4 endpoint, such as "user/info".  Requests to any path beneath the
+// endpoint, such as "user/info/gregavola", also receive this response,
+// unless a more specific endpoint is handled.
+func (s *Server) Handle(endpoint string, body []byte) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.responses[strings.Trim(endpoint, "/")] = body
+}
+
+// Client creates an *untappd.Client which performs all of its requests
+// against the Server.  The Client uses fake credentials, which the Server
+// does not check.  Zero or more ClientOptions may be provided to further
+// configure the Client.
+func (s *Server) Client(options ...untappd.ClientOption) (*untappd.Client, error) {
+	u, err := url.Parse(s.URL)
+	if err != nil {
+		return nil, err
+	}
+
+	options = append(options, untappd.WithTransport(&rewriteTransport{
+		url:       u,
+		transport: s.Server.Client().Transport,
+	}))
+
+	return untappd.NewClient("untappdtest", "untappdtest", nil, options...)
+}
+
+// serveHTTP answers a request using the response for the longest endpoint
+// which matches the request's path.
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", jsonContentType)
+
+	body, ok := s.lookup(r.URL.Path)
+	if !ok {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write(notFoundJSON)
+		return
+	}
+
+	w.Write(body)
+}
+
+// lookup finds the response for the longest endpoint which matches path.
+func (s *Server) lookup(path string) ([]byte, bool) {
+	if !strings.HasPrefix(path, apiPrefix) {
+		return nil, false
+	}
+	endpoint := strings.Trim(strings.TrimPrefix(path, apiPrefix), "/")
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for {
+		if body, ok := s.responses[endpoint]; ok {
+			return body, true
+		}
+
+		i := strings.LastIndex(endpoint, "/")
+		if i == -1 {
+			return nil, false
+		}
+		endpoint = endpoint[:i]
+	}
+}
+
+// rewriteTransport is an http.RoundTripper which sends requests intended for
+// the Untappd APIv4 to a Server instead.
+type rewriteTransport struct {
+	url       *url.URL
+	transport http.RoundTripper
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *rewriteTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	r := req.Clone(req.Context())
+	r.URL.Scheme = t.url.Scheme
+	r.URL.Host = t.url.Host
+	r.Host = t.url.Host
+
+	return t.transport.RoundTrip(r)
+}
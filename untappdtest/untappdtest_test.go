@@ -0,0 +1,146 @@
+package untappdtest
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/mdlayher/untappd"
+)
+
+// TestServerUserInfo verifies that a Server answers user info requests using
+// its canned response.
+func TestServerUserInfo(t *testing.T) {
+	c, done := testClient(t)
+	defer done()
+
+	u, _, err := c.User.Info("gregavola", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got, want := u.UserName, "gregavola"; got != want {
+		t.Fatalf("unexpected UserName: %q != %q", got, want)
+	}
+	if got, want := u.Stats.TotalCheckins, 2197; got != want {
+		t.Fatalf("unexpected TotalCheckins: %d != %d", got, want)
+	}
+}
+
+// TestServerBeerSearch verifies that a Server answers beer search requests
+// using its canned response.
+func TestServerBeerSearch(t *testing.T) {
+	c, done := testClient(t)
+	defer done()
+
+	beers, _, err := c.Beer.Search("pliny")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if l := len(beers); l != 2 {
+		t.Fatalf("unexpected number of beers: %d != %d", l, 2)
+	}
+	for i, want := range []string{"Pliny the Elder", "Pliny the Younger"} {
+		if got := beers[i].Name; got != want {
+			t.Fatalf("unexpected beer %d Name: %q != %q", i, got, want)
+		}
+		if got, want := beers[i].Brewery.Name, "Russian River Brewing Company"; got != want {
+			t.Fatalf("unexpected beer %d Brewery.Name: %q != %q", i, got, want)
+		}
+	}
+}
+
+// TestServerFixtures verifies that each of a Server's other canned responses
+// can be decoded by a Client.
+func TestServerFixtures(t *testing.T) {
+	c, done := testClient(t)
+	defer done()
+
+	checkins, _, err := c.User.Checkins("gregavola")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if l := len(checkins); l != 1 {
+		t.Fatalf("unexpected number of checkins: %d != %d", l, 1)
+	}
+
+	beer, _, err := c.Beer.Info(16630, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := beer.Name, "Oberon Ale"; got != want {
+		t.Fatalf("unexpected beer Name: %q != %q", got, want)
+	}
+
+	brewery, _, err := c.Brewery.Info(2507, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := brewery.Name, "Bell's Brewery, Inc."; got != want {
+		t.Fatalf("unexpected brewery Name: %q != %q", got, want)
+	}
+
+	breweries, _, err := c.Brewery.Search("russian river")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if l := len(breweries); l != 1 {
+		t.Fatalf("unexpected number of breweries: %d != %d", l, 1)
+	}
+}
+
+// TestServerHandle verifies that Server.Handle replaces a canned response,
+// and that the response is matched for requests beneath its endpoint.
+func TestServerHandle(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+
+	s.Handle("user/info/foo", []byte(`{"response":{"user":{"user_name":"foo"}}}`))
+
+	c, err := s.Client()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, name := range []string{"foo", "gregavola"} {
+		u, _, err := c.User.Info(name, false)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if got := u.UserName; got != name {
+			t.Fatalf("unexpected UserName: %q != %q", got, name)
+		}
+	}
+}
+
+// TestServerNotFound verifies that a Server returns an Untappd APIv4 error
+// for endpoints which have no response.
+func TestServerNotFound(t *testing.T) {
+	c, done := testClient(t)
+	defer done()
+
+	_, _, err := c.Venue.Info(1, false)
+
+	var uErr *untappd.Error
+	if !errors.As(err, &uErr) {
+		t.Fatalf("unexpected error type: %T", err)
+	}
+	if got, want := uErr.Code, 404; got != want {
+		t.Fatalf("unexpected error code: %d != %d", got, want)
+	}
+}
+
+// testClient creates a Server and a Client which uses it, and returns a
+// function which stops the Server.
+func testClient(t *testing.T) (*untappd.Client, func()) {
+	s := NewServer()
+
+	c, err := s.Client()
+	if err != nil {
+		s.Close()
+		t.Fatal(err)
+	}
+
+	return c, s.Close
+}
@@ -1,7 +1,9 @@
 package untappd
 
 import (
+	"encoding/json"
 	"net/url"
+	"time"
 )
 
 // UserService is a "service" which allows access to API methods involving users.
@@ -13,24 +15,56 @@ type UserService struct {
 // username, first and last name, avatar, cover photo, and various other attributes.
 type User struct {
 	// Metadata from Untappd.
-	UID       int
-	ID        int
-	UserName  string
-	FirstName string
-	LastName  string
-	Location  string
-	Bio       string
-	Supporter bool
+	UID       int64  `json:"uid"`
+	ID        int64  `json:"id"`
+	UserName  string `json:"user_name"`
+	FirstName string `json:"first_name"`
+	LastName  string `json:"last_name"`
+	Location  string `json:"location"`
+	Bio       string `json:"bio"`
+	Supporter bool   `json:"supporter"`
 
 	// Links to the user's avatar, cover photo, custom URL, and Untappd profile.
-	Avatar     url.URL
-	CoverPhoto url.URL
-	URL        url.URL
-	UntappdURL url.URL
+	Avatar     url.URL `json:"avatar"`
+	CoverPhoto url.URL `json:"cover_photo"`
+	URL        url.URL `json:"url"`
+	UntappdURL url.URL `json:"untappd_url"`
 
 	// Struct containing this user's total badges, friends, checkins,
 	// and other various totals.
-	Stats UserStats
+	Stats UserStats `json:"stats"`
+
+	// If applicable, friends in common between this user and the user
+	// whose friends were queried.  Only populated for friend list results.
+	MutualFriends []*User `json:"mutual_friends"`
+
+	// If applicable, beers this user has recently checked in.  Only
+	// populated for user info results.
+	RecentBrews []*Beer `json:"recent_brews"`
+
+	// If applicable, photos this user has recently attached to checkins.
+	// Only populated for user info results.
+	Media []*UserMedia `json:"media"`
+}
+
+// UserMedia contains links to a photo which a User attached to a checkin.
+// Included are links to a small, medium, large, and original size image,
+// as well as information about the checkin and beer which were photographed.
+type UserMedia struct {
+	ID            int64   `json:"id"`
+	SmallImage    url.URL `json:"small_image"`
+	MediumImage   url.URL `json:"medium_image"`
+	LargeImage    url.URL `json:"large_image"`
+	OriginalImage url.URL `json:"original_image"`
+
+	// ID of the checkin this photo is attached to, and time when the
+	// photo was added.
+	CheckinID int64     `json:"checkin_id"`
+	Created   time.Time `json:"created"`
+
+	// The beer and brewery which were photographed.
+	Beer    *Beer    `json:"beer"`
+	Brewery *Brewery `json:"brewery"`
 }
 
 // UserStats is a struct which contains various statistics regarding an Untappd
@@ -48,8 +82,8 @@ type UserStats struct {
 // rawUser is the raw JSON representation of an Untappd user.  Its data is
 // unmarshaled from JSON and then exported to a User struct.
 type rawUser struct {
-	UID        int          `json:"uid"`
-	ID         int          `json:"id"`
+	UID        int64        `json:"uid"`
+	ID         int64        `json:"id"`
 	UserName   string       `json:"user_name"`
 	FirstName  string       `json:"first_name"`
 	LastName   string       `json:"last_name"`
@@ -62,6 +96,36 @@ type rawUser struct {
 	Supporter  responseBool `json:"is_supporter"`
 	UntappdURL responseURL  `json:"untappd_url"`
 	Stats      UserStats    `json:"stats"`
+
+	RecentBrews responseRecentBrews `json:"recent_brews"`
+	Media       responseUserMedia   `json:"media"`
+}
+
+// rawUserMedia is the raw JSON representation of Untappd user media.  Its
+// data is unmarshaled from JSON and then exported to a UserMedia struct.
+type rawUserMedia struct {
+	rawCheckinMedia
+	CheckinID int64        `json:"checkin_id"`
+	Created   responseTime `json:"created_at"`
+	Beer      rawBeer      `json:"beer"`
+	Brewery   rawBrewery   `json:"brewery"`
+}
+
+// export creates an exported UserMedia from a rawUserMedia struct, allowing
+// for more useful structures to be created for client consumption.
+func (r *rawUserMedia) export() *UserMedia {
+	cm := r.rawCheckinMedia.export()
+	return &UserMedia{
+		ID:            cm.ID,
+		SmallImage:    cm.SmallImage,
+		MediumImage:   cm.MediumImage,
+		LargeImage:    cm.LargeImage,
+		OriginalImage: cm.OriginalImage,
+		CheckinID:     r.CheckinID,
+		Created:       time.Time(r.Created),
+		Beer:          r.Beer.export(),
+		Brewery:       r.Brewery.export(),
+	}
 }
 
 // export creates an exported User from a rawUser struct, allowing for more
@@ -83,6 +147,24 @@ func (r *rawUser) export() *User {
 		Stats:      r.Stats,
 	}
 
+	// If recent brews are present, as is the case with /v4/user/info/USERNAME,
+	// add them now
+	if len(r.RecentBrews) > 0 {
+		u.RecentBrews = make([]*Beer, len(r.RecentBrews))
+		for i := range r.RecentBrews {
+			u.RecentBrews[i] = r.RecentBrews[i].Beer.exportWithBrewery(r.RecentBrews[i].Brewery)
+		}
+	}
+
+	// If media is present, as is the case with /v4/user/info/USERNAME,
+	// add it now
+	if len(r.Media) > 0 {
+		u.Media = make([]*UserMedia, len(r.Media))
+		for i := range r.Media {
+			u.Media[i] = r.Media[i].export()
+		}
+	}
+
 	// If high resolution avatar is available, use it instead
 	if a := url.URL(r.AvatarHD); a.String() != "" {
 		u.Avatar = a
@@ -90,3 +172,126 @@ func (r *rawUser) export() *User {
 
 	return u
 }
+
+// String returns a concise string representation of a User, containing the
+// user's username and name, such as "mdlayher (Matt Layher)".  Name is
+// omitted if unavailable.
+func (u *User) String() string {
+	if u == nil {
+		return "<nil>"
+	}
+
+	return withDetail(u.UserName, joinNonEmpty(" ", u.FirstName, u.LastName))
+}
+
+// MarshalJSON implements json.Marshaler, so that a User's URLs are encoded
+// as strings.
+func (u User) MarshalJSON() ([]byte, error) {
+	type user User
+	return json.Marshal(struct {
+		user
+		Avatar     string `json:"avatar"`
+		CoverPhoto string `json:"cover_photo"`
+		URL        string `json:"url"`
+		UntappdURL string `json:"untappd_url"`
+	}{
+		user:       user(u),
+		Avatar:     u.Avatar.String(),
+		CoverPhoto: u.CoverPhoto.String(),
+		URL:        u.URL.String(),
+		UntappdURL: u.UntappdURL.String(),
+	})
+}
+
+// UnmarshalJSON implements json.Unmarshaler, so that JSON produced by
+// MarshalJSON can be decoded into a User.
+func (u *User) UnmarshalJSON(data []byte) error {
+	type user User
+	v := struct {
+		*user
+		Avatar     string `json:"avatar"`
+		CoverPhoto string `json:"cover_photo"`
+		URL        string `json:"url"`
+		UntappdURL string `json:"untappd_url"`
+	}{
+		user: (*user)(u),
+	}
+
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+
+	for _, p := range []struct {
+		s string
+		u *url.URL
+	}{
+		{s: v.Avatar, u: &u.Avatar},
+		{s: v.CoverPhoto, u: &u.CoverPhoto},
+		{s: v.URL, u: &u.URL},
+		{s: v.UntappdURL, u: &u.UntappdURL},
+	} {
+		pu, err := url.Parse(p.s)
+		if err != nil {
+			return err
+		}
+		*p.u = *pu
+	}
+
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler, so that UserMedia's URLs are
+// encoded as strings.
+func (m UserMedia) MarshalJSON() ([]byte, error) {
+	type userMedia UserMedia
+	return json.Marshal(struct {
+		userMedia
+		SmallImage    string `json:"small_image"`
+		MediumImage   string `json:"medium_image"`
+		LargeImage    string `json:"large_image"`
+		OriginalImage string `json:"original_image"`
+	}{
+		userMedia:     userMedia(m),
+		SmallImage:    m.SmallImage.String(),
+		MediumImage:   m.MediumImage.String(),
+		LargeImage:    m.LargeImage.String(),
+		OriginalImage: m.OriginalImage.String(),
+	})
+}
+
+// UnmarshalJSON implements json.Unmarshaler, so that JSON produced by
+// MarshalJSON can be decoded into UserMedia.
+func (m *UserMedia) UnmarshalJSON(data []byte) error {
+	type userMedia UserMedia
+	v := struct {
+		*userMedia
+		SmallImage    string `json:"small_image"`
+		MediumImage   string `json:"medium_image"`
+		LargeImage    string `json:"large_image"`
+		OriginalImage string `json:"original_image"`
+	}{
+		userMedia: (*userMedia)(m),
+	}
+
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+
+	for _, p := range []struct {
+		s string
+		u *url.URL
+	}{
+		{s: v.SmallImage, u: &m.SmallImage},
+		{s: v.MediumImage, u: &m.MediumImage},
+		{s: v.LargeImage, u: &m.LargeImage},
+		{s: v.OriginalImage, u: &m.OriginalImage},
+	} {
+		pu, err := url.Parse(p.s)
+		if err != nil {
+			return err
+		}
+		*p.u = *pu
+	}
+
+	return nil
+}
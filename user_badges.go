@@ -1,6 +1,7 @@
 package untappd
 
 import (
+	"context"
 	"net/http"
 	"net/url"
 	"strconv"
@@ -23,7 +24,17 @@ func (u *UserService) Badges(username string) ([]*Badge, *http.Response, error)
 // returned.
 //
 // 50 badges is the maximum number of badges which may be returned by one call.
+// If limit exceeds MaxBadgesLimit, ErrInvalidLimit is returned.
 func (u *UserService) BadgesOffsetLimit(username string, offset int, limit int) ([]*Badge, *http.Response, error) {
+	return u.badgesOffsetLimit(context.Background(), username, offset, limit)
+}
+
+// badgesOffsetLimit is the backing method for BadgesOffsetLimit and Profile.
+func (u *UserService) badgesOffsetLimit(ctx context.Context, username string, offset int, limit int) ([]*Badge, *http.Response, error) {
+	if err := checkLimit(limit, MaxBadgesLimit); err != nil {
+		return nil, nil, err
+	}
+
 	q := url.Values{
 		"offset": []string{strconv.Itoa(offset)},
 		"limit":  []string{strconv.Itoa(limit)},
@@ -38,15 +49,20 @@ func (u *UserService) BadgesOffsetLimit(username string, offset int, limit int)
 	}
 
 	// Perform request for user badges by username
-	res, err := u.client.request("GET", "user/badges/"+username, nil, q, &v)
+	res, err := u.client.requestContext(ctx, "GET", "user/badges/"+username, nil, q, &v)
 	if err != nil {
 		return nil, res, err
 	}
 
-	// Build result slice from struct
-	badges := make([]*Badge, v.Response.Count)
-	for i := range v.Response.Items {
-		badges[i] = v.Response.Items[i].export()
+	// Build result slice from struct, sized by the number of items actually
+	// returned, since the reported count may not match
+	badges := make([]*Badge, 0, len(v.Response.Items))
+	for _, item := range v.Response.Items {
+		if item == nil {
+			continue
+		}
+
+		badges = append(badges, item.export())
 	}
 
 	return badges, res, nil
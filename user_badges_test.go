@@ -94,6 +94,80 @@ func TestClientUserBadgesOffsetLimitOK(t *testing.T) {
 	}
 }
 
+// TestClientUserBadgesHint verifies that Client.User.BadgesOffsetLimit
+// populates Hint separately from Description, for both badges and their
+// levels.
+func TestClientUserBadgesHint(t *testing.T) {
+	c, done := userBadgesTestClient(t, func(t *testing.T, w http.ResponseWriter, r *http.Request) {
+		w.Write(userBadgesJSON)
+	})
+	defer done()
+
+	badges, _, err := c.User.BadgesOffsetLimit("mdlayher", 0, 50)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	b := badges[0]
+	if s, want := b.Hint, "Check in a beer at a concert venue."; s != want {
+		t.Fatalf("unexpected badge Hint: %q != %q", s, want)
+	}
+	if s, want := b.Description, "Description Here"; s != want {
+		t.Fatalf("unexpected badge Description: %q != %q", s, want)
+	}
+	if s, want := b.Levels[0].Hint, "Check in a beer at 5 concert venues."; s != want {
+		t.Fatalf("unexpected badge level Hint: %q != %q", s, want)
+	}
+
+	// Badges without a hint leave it empty
+	if s := badges[1].Hint; s != "" {
+		t.Fatalf("unexpected badge Hint: %q", s)
+	}
+}
+
+// TestClientUserBadgesOffsetLimitCountMismatch verifies that
+// Client.User.BadgesOffsetLimit returns only the badges present in a
+// response, even if the reported count does not match.
+func TestClientUserBadgesOffsetLimitCountMismatch(t *testing.T) {
+	var tests = []struct {
+		description string
+		body        string
+		n           int
+	}{
+		{
+			description: "count lower than items",
+			body:        `{"meta":{"code":200},"response":{"count":1,"items":[{"badge_id":1},{"badge_id":2}]}}`,
+			n:           2,
+		},
+		{
+			description: "count higher than items, with null item",
+			body:        `{"meta":{"code":200},"response":{"count":25,"items":[{"badge_id":1},null]}}`,
+			n:           1,
+		},
+	}
+
+	for _, tt := range tests {
+		c, done := userBadgesTestClient(t, func(t *testing.T, w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(tt.body))
+		})
+
+		badges, _, err := c.User.BadgesOffsetLimit("foo", 0, 25)
+		done()
+		if err != nil {
+			t.Fatalf("unexpected error for test %q: %v", tt.description, err)
+		}
+
+		if l := len(badges); l != tt.n {
+			t.Fatalf("unexpected number of badges for test %q: %d != %d", tt.description, l, tt.n)
+		}
+		for _, x := range badges {
+			if x == nil || x.ID == 0 {
+				t.Fatalf("unexpected badge for test %q: %+v", tt.description, x)
+			}
+		}
+	}
+}
+
 // userBadgesTestClient builds upon testClient, and adds additional sanity checks
 // for tests which target the user badges API.
 func userBadgesTestClient(t *testing.T, fn func(t *testing.T, w http.ResponseWriter, r *http.Request)) (*Client, func()) {
@@ -131,6 +205,7 @@ var userBadgesJSON = []byte(`{
     "checkin_id": 137117722,
     "badge_name": "Taste the Music",
     "badge_description": "Description Here",
+    "badge_hint": "Check in a beer at a concert venue.",
     "badge_active_status": 1,
     "media": {
       "badge_image_sm": "https://d1c8v1qci5en44.cloudfront.net/badges/bdg_ConcertVenue_sm.jpg",
@@ -149,6 +224,7 @@ var userBadgesJSON = []byte(`{
           "checkin_id": 137117722,
           "badge_name": "Taste the Music",
           "badge_description": "Descriptio  here",
+          "badge_hint": "Check in a beer at 5 concert venues.",
           "media": {
             "badge_image_sm": "https://d1c8v1qci5en44.cloudfront.net/badges/bdg_ConcertVenue_sm.jpg",
             "badge_image_md": "https://d1c8v1qci5en44.cloudfront.net/badges/bdg_ConcertVenue_md.jpg",
@@ -33,6 +33,35 @@ func TestClientUserBeersOK(t *testing.T) {
 	}
 }
 
+// TestClientUserBeersDefaultLimit verifies that Client.User.Beers uses the
+// Client's DefaultLimit, clamped to the endpoint's maximum.
+func TestClientUserBeersDefaultLimit(t *testing.T) {
+	tests := []struct {
+		defaultLimit int
+		limit        string
+	}{
+		{defaultLimit: 10, limit: "10"},
+		{defaultLimit: MaxBeersLimit + 1, limit: strconv.Itoa(MaxBeersLimit)},
+	}
+
+	for _, tt := range tests {
+		c, done := userBeersTestClient(t, func(t *testing.T, w http.ResponseWriter, r *http.Request) {
+			assertParameters(t, r, url.Values{
+				"limit": []string{tt.limit},
+			})
+
+			w.Write([]byte("{}"))
+		})
+
+		c.DefaultLimit = tt.defaultLimit
+		_, _, err := c.User.Beers("foo")
+		done()
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
 // TestClientUserBeersOffsetLimitSortBadUser verifies that
 // Client.User.BeersOffsetLimitSort returns an error when an invalid user
 // is queried.
@@ -47,6 +76,56 @@ func TestClientUserBeersOffsetLimitBadUser(t *testing.T) {
 	assertInvalidUserErr(t, err)
 }
 
+// TestClientUserBeersOffsetLimitSortBadSort verifies that Client.User.BeersOffsetLimitSort
+// returns ErrInvalidSort without performing a request when an unknown sort
+// is used.
+func TestClientUserBeersOffsetLimitSortBadSort(t *testing.T) {
+	c, done := userBeersTestClient(t, func(t *testing.T, w http.ResponseWriter, r *http.Request) {
+		t.Fatal("request should not have been performed")
+	})
+	defer done()
+
+	if _, _, err := c.User.BeersOffsetLimitSort("foo", 0, 25, Sort("foo")); err != ErrInvalidSort {
+		t.Fatalf("unexpected error: %v != %v", err, ErrInvalidSort)
+	}
+}
+
+// TestClientUserBeersOffsetLimitSortBadLimit verifies that
+// Client.User.BeersOffsetLimitSort returns ErrInvalidLimit without performing
+// a request when a limit greater than MaxBeersLimit is used.
+func TestClientUserBeersOffsetLimitSortBadLimit(t *testing.T) {
+	c, done := userBeersTestClient(t, func(t *testing.T, w http.ResponseWriter, r *http.Request) {
+		t.Fatal("request should not have been performed")
+	})
+	defer done()
+
+	if _, _, err := c.User.BeersOffsetLimitSort("foo", 0, 500, SortDate); err != ErrInvalidLimit {
+		t.Fatalf("unexpected error: %v != %v", err, ErrInvalidLimit)
+	}
+}
+
+// TestClientUserBeersOffsetLimitSortCountMismatch verifies that
+// Client.User.BeersOffsetLimitSort returns only the beers present in a
+// response, even if the reported count is larger.
+func TestClientUserBeersOffsetLimitSortCountMismatch(t *testing.T) {
+	c, done := userBeersTestClient(t, func(t *testing.T, w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"meta":{"code":200},"response":{"beers":{"count":50,"items":[{"beer":{"bid":1}}]}}}`))
+	})
+	defer done()
+
+	beers, _, err := c.User.BeersOffsetLimitSort("foo", 0, 50, SortDate)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if l := len(beers); l != 1 {
+		t.Fatalf("unexpected number of beers: %d != %d", l, 1)
+	}
+	if beers[0] == nil {
+		t.Fatal("unexpected nil beer")
+	}
+}
+
 // TestClientUserBeersOffsetLimitOK verifies that Client.User.BeersOffsetLimit
 // returns a valid beers list, when used with correct parameters.
 func TestClientUserBeersOffsetLimitOK(t *testing.T) {
@@ -126,6 +205,9 @@ func TestClientUserBeersOffsetLimitOK(t *testing.T) {
 		if !beers[i].RecentHad.Equal(expected[i].RecentHad) {
 			t.Fatalf("unexpected beer RecentHad: %q != %q", beers[i].RecentHad, expected[i].RecentHad)
 		}
+		if h := beers[i].RecentHadLocal.Hour(); h != 19 {
+			t.Fatalf("unexpected beer RecentHadLocal hour: %d != %d", h, 19)
+		}
 		if beers[i].UserRating != expected[i].UserRating {
 			t.Fatalf("unexpected beer UserRating: %f != %f", beers[i].UserRating, expected[i].UserRating)
 		}
@@ -1,40 +1,160 @@
 package untappd
 
 import (
-	"math"
+	"context"
 	"net/http"
-	"net/url"
-	"strconv"
 )
 
 // Checkins queries for information about a User's checkins.
 // The username parameter specifies the User whose checkins will be
 // returned.
 //
-// This method returns up to 25 of the User's most recent checkins.
-// For more granular control, and to page through the checkins list using ID
-// parameters, use CheckinsMinMaxIDLimit instead.
+// This method returns up to Client.DefaultLimit of the User's most recent
+// checkins.  For more granular control, and to page through the checkins list
+// using ID parameters, use CheckinsMinMaxIDLimit instead.
 func (u *UserService) Checkins(username string) ([]*Checkin, *http.Response, error) {
-	// Use default parameters as specified by API.  Max ID is somewhat
-	// arbitrary, but should provide plenty of headroom, just in case.
-	return u.CheckinsMinMaxIDLimit(username, 0, math.MaxInt32, 25)
+	return u.CheckinsMinMaxIDLimit(username, 0, 0, u.client.limit(MaxUserCheckinsLimit))
 }
 
 // CheckinsMinMaxIDLimit queries for information about a User's checkins,
 // but also accepts minimum checkin ID, maximum checkin ID, and a limit
 // parameter to enable paging through checkins. The username parameter
-// specifies the User whose checkins will be returned.
+// specifies the User whose checkins will be returned.  A minID or maxID of
+// zero means the checkins are not bounded in that direction.
 //
 // 50 checkins is the maximum number of checkins which may be returned by
-// one call.
-func (u *UserService) CheckinsMinMaxIDLimit(username string, minID int, maxID int, limit int) ([]*Checkin, *http.Response, error) {
-	v := url.Values{}
-	if minID != 0 {
-		v.Set("min_id", strconv.Itoa(minID))
+// one call.  If limit exceeds MaxUserCheckinsLimit, ErrInvalidLimit is returned.
+func (u *UserService) CheckinsMinMaxIDLimit(username string, minID int64, maxID int64, limit int) ([]*Checkin, *http.Response, error) {
+	return u.checkinsMinMaxIDLimit(context.Background(), username, minID, maxID, limit)
+}
+
+// checkinsMinMaxIDLimit is the backing method for CheckinsMinMaxIDLimit and
+// Profile.
+func (u *UserService) checkinsMinMaxIDLimit(ctx context.Context, username string, minID int64, maxID int64, limit int) ([]*Checkin, *http.Response, error) {
+	checkins, _, res, err := u.checkinsPage(ctx, username, minID, maxID, limit)
+	return checkins, res, err
+}
+
+// checkinsPage is the same as checkinsMinMaxIDLimit, but also returns the
+// number of items in the response, including any null items which are
+// skipped.
+func (u *UserService) checkinsPage(ctx context.Context, username string, minID int64, maxID int64, limit int) ([]*Checkin, int, *http.Response, error) {
+	if err := checkLimit(limit, MaxUserCheckinsLimit); err != nil {
+		return nil, 0, nil, err
+	}
+
+	return u.client.getCheckinsPage(ctx, "user/checkins/"+username, checkinsQuery(minID, maxID, limit))
+}
+
+// CheckinsSince queries for a User's checkins which are newer than the
+// checkin with the specified sinceID.  The username parameter specifies the
+// User whose checkins will be returned.  The checkin with sinceID itself is
+// never returned, so polling with the most recent ID seen does not produce
+// duplicates.
+//
+// sinceID is typically the ID of the most recent checkin seen by a previous
+// call, mirroring the since_url returned in the pagination information of
+// an Untappd APIv4 response.  This makes CheckinsSince useful for polling a
+// User's feed for new activity.
+//
+// 50 checkins is the maximum number of checkins which may be returned by
+// one call.  If limit exceeds MaxUserCheckinsLimit, ErrInvalidLimit is returned.
+func (u *UserService) CheckinsSince(username string, sinceID int64, limit int) ([]*Checkin, *http.Response, error) {
+	checkins, res, err := u.CheckinsMinMaxIDLimit(username, sinceID, 0, limit)
+	if err != nil {
+		return nil, res, err
+	}
+
+	// The API's minimum ID is inclusive, so the sinceID checkin is
+	// returned as well, and must be discarded
+	out := make([]*Checkin, 0, len(checkins))
+	for _, c := range checkins {
+		if c.ID > sinceID {
+			out = append(out, c)
+		}
+	}
+
+	return out, res, nil
+}
+
+// CheckinsOffsetLimit queries for information about a User's checkins, but
+// accepts offset and limit parameters to enable paging through checkins by
+// page number, rather than by checkin ID.  The username parameter specifies
+// the User whose checkins will be returned.
+//
+// The Untappd APIv4 does not support an offset for a User's checkins, so
+// the offset is emulated by walking the User's checkins from newest to oldest
+// using checkin ID cursors, and discarding the first offset checkins.  This
+// requires one request for every 50 checkins which are skipped or returned,
+// so large offsets are costly, and count against the API rate limit.  For
+// efficient paging, use CheckinsMinMaxIDLimit instead.  Checkins which appear
+// more than once while walking the User's checkins are only returned once.
+//
+// 50 checkins is the maximum number of checkins which may be returned by
+// one call.  If limit exceeds MaxUserCheckinsLimit, ErrInvalidLimit is
+// returned.  If limit is zero, Client.DefaultLimit checkins are returned.
+func (u *UserService) CheckinsOffsetLimit(username string, offset int, limit int) ([]*Checkin, *http.Response, error) {
+	if err := checkLimit(limit, MaxUserCheckinsLimit); err != nil {
+		return nil, nil, err
 	}
-	if maxID != math.MaxInt32 {
-		v.Set("max_id", strconv.Itoa(maxID))
+	if offset < 0 {
+		offset = 0
 	}
-	v.Set("limit", strconv.Itoa(limit))
-	return u.client.getCheckins("user/checkins/"+username, v)
+	if limit == 0 {
+		limit = u.client.limit(MaxUserCheckinsLimit)
+	}
+
+	var (
+		checkins []*Checkin
+		res      *http.Response
+		maxID    int64
+	)
+
+	// Walk checkins until enough are retrieved to satisfy both offset and
+	// limit, or until no more checkins remain
+	for want := offset + limit; len(checkins) < want; {
+		n := want - len(checkins)
+		if n > MaxUserCheckinsLimit {
+			n = MaxUserCheckinsLimit
+		}
+
+		page, items, r, err := u.checkinsPage(context.Background(), username, 0, maxID, n)
+		res = r
+		if err != nil {
+			return nil, res, err
+		}
+
+		// Determine the oldest checkin in this page before any duplicates
+		// are discarded, so the cursor always moves past the whole page
+		var oldest int64
+		for _, c := range page {
+			if oldest == 0 || c.ID < oldest {
+				oldest = c.ID
+			}
+		}
+
+		// Pages may overlap, so duplicates are discarded across all pages
+		checkins = DedupCheckins(append(checkins, page...))
+
+		// A short page indicates that no more checkins remain, and a page
+		// which does not move the cursor would repeat forever.  No checkins
+		// precede ID 1, and a maximum ID of zero would restart the walk.
+		if items < n || oldest <= 1 || (maxID != 0 && oldest > maxID) {
+			break
+		}
+
+		// Continue from the checkin before the oldest in this page
+		maxID = oldest - 1
+	}
+
+	if offset >= len(checkins) {
+		return []*Checkin{}, res, nil
+	}
+
+	checkins = checkins[offset:]
+	if len(checkins) > limit {
+		checkins = checkins[:limit]
+	}
+
+	return checkins, res, nil
 }
@@ -1,9 +1,10 @@
 package untappd
 
 import (
-	"math"
+	"fmt"
 	"net/http"
 	"net/url"
+	"reflect"
 	"strconv"
 	"strings"
 	"testing"
@@ -29,6 +30,72 @@ func TestClientUserCheckinsOK(t *testing.T) {
 	}
 }
 
+// TestClientUserCheckinsSinceOK verifies that Client.User.CheckinsSince
+// sets the minimum ID parameter from its since ID.
+func TestClientUserCheckinsSinceOK(t *testing.T) {
+	sinceID := int64(137117722)
+	limit := 10
+
+	c, done := userCheckinsTestClient(t, func(t *testing.T, w http.ResponseWriter, r *http.Request) {
+		assertParameters(t, r, url.Values{
+			"min_id": []string{strconv.FormatInt(sinceID, 10)},
+			"limit":  []string{strconv.Itoa(limit)},
+		})
+
+		// Empty JSON response since we already passed checks
+		w.Write([]byte("{}"))
+	})
+	defer done()
+
+	if _, _, err := c.User.CheckinsSince("foo", sinceID, limit); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// TestClientUserCheckinsSinceExcludesSinceID verifies that
+// Client.User.CheckinsSince does not return the checkin with the since ID,
+// which is included by the API's inclusive minimum ID.
+func TestClientUserCheckinsSinceExcludesSinceID(t *testing.T) {
+	sinceID := int64(2)
+
+	c, done := userCheckinsTestClient(t, func(t *testing.T, w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"meta":{"code":200},"response":{"checkins":{"count":2,"items":[{"checkin_id":3},{"checkin_id":2}]}}}`))
+	})
+	defer done()
+
+	checkins, _, err := c.User.CheckinsSince("foo", sinceID, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if l := len(checkins); l != 1 {
+		t.Fatalf("unexpected number of checkins: %d != %d", l, 1)
+	}
+	if id := checkins[0].ID; id != 3 {
+		t.Fatalf("unexpected checkin ID: %d != %d", id, 3)
+	}
+}
+
+// TestClientUserCheckinsMinMaxIDLimitLargeIDs verifies that
+// Client.User.CheckinsMinMaxIDLimit sends checkin IDs which exceed the range
+// of a 32-bit integer.
+func TestClientUserCheckinsMinMaxIDLimitLargeIDs(t *testing.T) {
+	c, done := userCheckinsTestClient(t, func(t *testing.T, w http.ResponseWriter, r *http.Request) {
+		assertParameters(t, r, url.Values{
+			"min_id": []string{"3000000000"},
+			"max_id": []string{"3000000050"},
+			"limit":  []string{"25"},
+		})
+
+		w.Write([]byte("{}"))
+	})
+	defer done()
+
+	if _, _, err := c.User.CheckinsMinMaxIDLimit("foo", 3000000000, 3000000050, 25); err != nil {
+		t.Fatal(err)
+	}
+}
+
 // TestClientUserCheckinsMinMaxIDLimitBadUser verifies that
 // Client.User.CheckinsMinMaxIDLimit returns an error when an invalid user
 // is queried.
@@ -39,15 +106,14 @@ func TestClientUserCheckinsMinMaxIDLimitBadUser(t *testing.T) {
 	})
 	defer done()
 
-	_, _, err := c.User.CheckinsMinMaxIDLimit("foo", 0, math.MaxInt32, 25)
+	_, _, err := c.User.CheckinsMinMaxIDLimit("foo", 0, 0, 25)
 	assertInvalidUserErr(t, err)
 }
 
 // TestClientUserCheckinsMinMaxIDLimitOK verifies that Client.User.CheckinsMinMaxIDLimit
 // returns a valid checkins list, when used with correct parameters.
 func TestClientUserCheckinsMinMaxIDLimitOffsetLimitOK(t *testing.T) {
-	var minID int
-	var maxID = math.MaxInt32
+	var minID, maxID int64
 	var limit = 25
 	sLimit := strconv.Itoa(limit)
 
@@ -58,8 +124,11 @@ func TestClientUserCheckinsMinMaxIDLimitOffsetLimitOK(t *testing.T) {
 			t.Fatalf("unexpected URL path: %q != %q", p, path)
 		}
 
+		// Zero minimum and maximum IDs are omitted
 		assertParameters(t, r, url.Values{
-			"limit": []string{sLimit},
+			"min_id": []string{""},
+			"max_id": []string{""},
+			"limit":  []string{sLimit},
 		})
 
 		w.Write(userCheckinsJSON)
@@ -75,6 +144,222 @@ func TestClientUserCheckinsMinMaxIDLimitOffsetLimitOK(t *testing.T) {
 	assertExpectedCheckins(t, checkins)
 }
 
+// TestClientUserCheckinsMinMaxIDLimitCountMismatch verifies that
+// Client.User.CheckinsMinMaxIDLimit returns only the checkins present in a
+// response, even if the reported count is larger.
+func TestClientUserCheckinsMinMaxIDLimitCountMismatch(t *testing.T) {
+	c, done := userCheckinsTestClient(t, func(t *testing.T, w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"meta":{"code":200},"response":{"checkins":{"count":50,"items":[{"checkin_id":1}]}}}`))
+	})
+	defer done()
+
+	checkins, _, err := c.User.CheckinsMinMaxIDLimit("foo", 0, 0, 50)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if l := len(checkins); l != 1 {
+		t.Fatalf("unexpected number of checkins: %d != %d", l, 1)
+	}
+	if checkins[0] == nil {
+		t.Fatal("unexpected nil checkin")
+	}
+}
+
+// TestClientUserCheckinsMinMaxIDLimitNullItems verifies that
+// Client.User.CheckinsMinMaxIDLimit skips null items in a response, rather
+// than returning nil checkins.
+func TestClientUserCheckinsMinMaxIDLimitNullItems(t *testing.T) {
+	c, done := userCheckinsTestClient(t, func(t *testing.T, w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"meta":{"code":200},"response":{"checkins":{"count":3,"items":[{"checkin_id":2},null,{"checkin_id":1}]}}}`))
+	})
+	defer done()
+
+	checkins, _, err := c.User.CheckinsMinMaxIDLimit("foo", 0, 0, 25)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var ids []int64
+	for _, c := range checkins {
+		ids = append(ids, c.ID)
+	}
+
+	if want := []int64{2, 1}; !reflect.DeepEqual(ids, want) {
+		t.Fatalf("unexpected checkin IDs: %v != %v", ids, want)
+	}
+}
+
+// TestClientUserCheckinsOffsetLimitOK verifies that Client.User.CheckinsOffsetLimit
+// emulates an offset by walking checkin ID cursors across several pages.
+func TestClientUserCheckinsOffsetLimitOK(t *testing.T) {
+	tests := []struct {
+		desc     string
+		offset   int
+		limit    int
+		ids      []int64
+		requests int
+	}{
+		{
+			desc:     "two pages",
+			offset:   48,
+			limit:    5,
+			ids:      []int64{52, 51, 50, 49, 48},
+			requests: 2,
+		},
+		{
+			desc:     "past end of checkins",
+			offset:   98,
+			limit:    5,
+			ids:      []int64{2, 1},
+			requests: 2,
+		},
+		{
+			desc:     "offset beyond all checkins",
+			offset:   200,
+			limit:    5,
+			requests: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		var requests int
+		c, done := userCheckinsTestClient(t, func(t *testing.T, w http.ResponseWriter, r *http.Request) {
+			requests++
+			w.Write(userCheckinsPageJSON(t, r, 100))
+		})
+
+		checkins, _, err := c.User.CheckinsOffsetLimit("mdlayher", tt.offset, tt.limit)
+		done()
+		if err != nil {
+			t.Fatalf("[%s] %v", tt.desc, err)
+		}
+
+		var ids []int64
+		for _, c := range checkins {
+			ids = append(ids, c.ID)
+		}
+
+		if !reflect.DeepEqual(ids, tt.ids) {
+			t.Fatalf("[%s] unexpected checkin IDs: %v != %v", tt.desc, ids, tt.ids)
+		}
+		if requests != tt.requests {
+			t.Fatalf("[%s] unexpected number of requests: %d != %d", tt.desc, requests, tt.requests)
+		}
+	}
+}
+
+// TestClientUserCheckinsOffsetLimitDuplicates verifies that
+// Client.User.CheckinsOffsetLimit continues paging when a page contains
+// duplicate or null checkins, and discards duplicates across pages.
+func TestClientUserCheckinsOffsetLimitDuplicates(t *testing.T) {
+	tests := []struct {
+		desc     string
+		limit    int
+		pages    []string
+		maxIDs   []string
+		ids      []int64
+		requests int
+	}{
+		{
+			desc:     "duplicate in page",
+			limit:    3,
+			pages:    []string{`10,10,9`, `8,7`},
+			maxIDs:   []string{"", "8"},
+			ids:      []int64{10, 9, 8},
+			requests: 2,
+		},
+		{
+			desc:     "null in page",
+			limit:    3,
+			pages:    []string{`10,null,9`, `8,7,6`},
+			maxIDs:   []string{"", "8"},
+			ids:      []int64{10, 9, 8},
+			requests: 2,
+		},
+		{
+			desc:     "duplicate across pages",
+			limit:    5,
+			pages:    []string{`10,9,9,8,7`, `7`},
+			maxIDs:   []string{"", "6"},
+			ids:      []int64{10, 9, 8, 7},
+			requests: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		var requests int
+		c, done := userCheckinsTestClient(t, func(t *testing.T, w http.ResponseWriter, r *http.Request) {
+			if requests >= len(tt.pages) {
+				t.Fatalf("[%s] unexpected request %d", tt.desc, requests)
+			}
+			if got, want := r.URL.Query().Get("max_id"), tt.maxIDs[requests]; got != want {
+				t.Fatalf("[%s] unexpected max_id: %q != %q", tt.desc, got, want)
+			}
+
+			var items []string
+			for _, id := range strings.Split(tt.pages[requests], ",") {
+				if id == "null" {
+					items = append(items, id)
+					continue
+				}
+				items = append(items, fmt.Sprintf(`{"checkin_id":%s}`, id))
+			}
+			requests++
+
+			w.Write([]byte(fmt.Sprintf(`{"meta":{"code":200},"response":{"checkins":{"count":%d,"items":[%s]}}}`,
+				len(items), strings.Join(items, ","))))
+		})
+
+		checkins, _, err := c.User.CheckinsOffsetLimit("mdlayher", 0, tt.limit)
+		done()
+		if err != nil {
+			t.Fatalf("[%s] %v", tt.desc, err)
+		}
+
+		var ids []int64
+		for _, c := range checkins {
+			ids = append(ids, c.ID)
+		}
+
+		if !reflect.DeepEqual(ids, tt.ids) {
+			t.Fatalf("[%s] unexpected checkin IDs: %v != %v", tt.desc, ids, tt.ids)
+		}
+		if requests != tt.requests {
+			t.Fatalf("[%s] unexpected number of requests: %d != %d", tt.desc, requests, tt.requests)
+		}
+	}
+}
+
+// userCheckinsPageJSON generates a page of user checkins JSON, for a user
+// with checkin IDs from total down to 1, using the max_id and limit
+// parameters from the input request.
+func userCheckinsPageJSON(t *testing.T, r *http.Request, total int64) []byte {
+	maxID := total
+	if s := r.URL.Query().Get("max_id"); s != "" {
+		id, err := strconv.ParseInt(s, 10, 64)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if id < maxID {
+			maxID = id
+		}
+	}
+
+	limit, err := strconv.Atoi(r.URL.Query().Get("limit"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var items []string
+	for id := maxID; id > 0 && len(items) < limit; id-- {
+		items = append(items, fmt.Sprintf(`{"checkin_id":%d}`, id))
+	}
+
+	return []byte(fmt.Sprintf(`{"meta":{"code":200},"response":{"checkins":{"count":%d,"items":[%s]}}}`,
+		len(items), strings.Join(items, ",")))
+}
+
 // userCheckinTestClient builds upon testClient, and adds additional sanity checks
 // for tests which target the user checkin API.
 func userCheckinsTestClient(t *testing.T, fn func(t *testing.T, w http.ResponseWriter, r *http.Request)) (*Client, func()) {
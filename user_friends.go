@@ -9,7 +9,7 @@ import (
 // Friends queries for information about a User's friends.  The username
 // parameter specifies the User whose friends will be returned.
 //
-// This method returns up to a maximum of 25 friends.  For more granular
+// This method returns up to Client.DefaultLimit friends.  For more granular
 // control, and to page through the friends list, use FriendsOffsetLimit
 // instead.
 //
@@ -18,7 +18,7 @@ import (
 // user ID, username, first name, last name, bio, etc. is available.
 func (u *UserService) Friends(username string) ([]*User, *http.Response, error) {
 	// Use default parameters as specified by API
-	return u.FriendsOffsetLimit(username, 0, 25)
+	return u.FriendsOffsetLimit(username, 0, u.client.limit(MaxFriendsLimit))
 }
 
 // FriendsOffsetLimit queries for information about a User's friends, but also
@@ -27,7 +27,12 @@ func (u *UserService) Friends(username string) ([]*User, *http.Response, error)
 // returned.
 //
 // 25 friends is the maximum number of friends which may be returned by one call.
+// If limit exceeds MaxFriendsLimit, ErrInvalidLimit is returned.
 func (u *UserService) FriendsOffsetLimit(username string, offset int, limit int) ([]*User, *http.Response, error) {
+	if err := checkLimit(limit, MaxFriendsLimit); err != nil {
+		return nil, nil, err
+	}
+
 	q := url.Values{
 		"offset": []string{strconv.Itoa(offset)},
 		"limit":  []string{strconv.Itoa(limit)},
@@ -37,8 +42,12 @@ func (u *UserService) FriendsOffsetLimit(username string, offset int, limit int)
 	var v struct {
 		Response struct {
 			Count int `json:"count"`
-			Items []struct {
-				User rawUser `json:"user"`
+			Items []*struct {
+				User          rawUser `json:"user"`
+				MutualFriends struct {
+					Count int        `json:"count"`
+					Items []*rawUser `json:"items"`
+				} `json:"mutual_friends"`
 			} `json:"items"`
 		} `json:"response"`
 	}
@@ -49,10 +58,28 @@ func (u *UserService) FriendsOffsetLimit(username string, offset int, limit int)
 		return nil, res, err
 	}
 
-	// Build result slice from struct
-	users := make([]*User, v.Response.Count)
-	for i := range v.Response.Items {
-		users[i] = v.Response.Items[i].User.export()
+	// Build result slice from struct, sized by the number of items actually
+	// returned, since the reported count may not match
+	users := make([]*User, 0, len(v.Response.Items))
+	for _, item := range v.Response.Items {
+		if item == nil {
+			continue
+		}
+
+		user := item.User.export()
+
+		// Friends in common with this friend
+		mutual := make([]*User, 0, len(item.MutualFriends.Items))
+		for _, m := range item.MutualFriends.Items {
+			if m == nil {
+				continue
+			}
+
+			mutual = append(mutual, m.export())
+		}
+		user.MutualFriends = mutual
+
+		users = append(users, user)
 	}
 
 	return users, res, nil
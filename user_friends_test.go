@@ -77,6 +77,10 @@ func TestClientUserFriendsOffsetLimitOK(t *testing.T) {
 		&User{
 			UID:      123456,
 			UserName: "XXXXXX",
+			MutualFriends: []*User{
+				{UID: 1, UserName: "gregavola"},
+				{UID: 2, UserName: "timmyg"},
+			},
 		},
 		&User{
 			UID:      789123,
@@ -91,6 +95,58 @@ func TestClientUserFriendsOffsetLimitOK(t *testing.T) {
 		if friends[i].UserName != expected[i].UserName {
 			t.Fatalf("unexpected friend UserName: %q != %q", friends[i].UserName, expected[i].UserName)
 		}
+
+		if got, want := len(friends[i].MutualFriends), len(expected[i].MutualFriends); got != want {
+			t.Fatalf("unexpected number of friend MutualFriends: %d != %d", got, want)
+		}
+		for j := range friends[i].MutualFriends {
+			if got, want := friends[i].MutualFriends[j].UserName, expected[i].MutualFriends[j].UserName; got != want {
+				t.Fatalf("unexpected mutual friend UserName: %q != %q", got, want)
+			}
+		}
+	}
+}
+
+// TestClientUserFriendsOffsetLimitCountMismatch verifies that
+// Client.User.FriendsOffsetLimit returns only the users present in a
+// response, even if the reported count does not match.
+func TestClientUserFriendsOffsetLimitCountMismatch(t *testing.T) {
+	var tests = []struct {
+		description string
+		body        string
+		n           int
+	}{
+		{
+			description: "count lower than items",
+			body:        `{"meta":{"code":200},"response":{"count":1,"items":[{"user":{"uid":1}},{"user":{"uid":2}}]}}`,
+			n:           2,
+		},
+		{
+			description: "count higher than items, with null item",
+			body:        `{"meta":{"code":200},"response":{"count":25,"items":[{"user":{"uid":1}},null]}}`,
+			n:           1,
+		},
+	}
+
+	for _, tt := range tests {
+		c, done := userFriendsTestClient(t, func(t *testing.T, w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(tt.body))
+		})
+
+		users, _, err := c.User.FriendsOffsetLimit("foo", 0, 25)
+		done()
+		if err != nil {
+			t.Fatalf("unexpected error for test %q: %v", tt.description, err)
+		}
+
+		if l := len(users); l != tt.n {
+			t.Fatalf("unexpected number of users for test %q: %d != %d", tt.description, l, tt.n)
+		}
+		for _, x := range users {
+			if x == nil || x.UID == 0 {
+				t.Fatalf("unexpected user for test %q: %+v", tt.description, x)
+			}
+		}
 	}
 }
 
@@ -145,8 +201,17 @@ var userFriendsJSON = []byte(`{
       "user_avatar": "https://d1c8v1qci5en44.cloudfront.net/profile/844124b9ff349b226018dd7bf549f052_thumb.jpg"
     },
     "mutual_friends": {
-      "count": 0,
-      "items": []
+      "count": 2,
+      "items": [
+        {
+          "uid": 1,
+          "user_name": "gregavola"
+        },
+        {
+          "uid": 2,
+          "user_name": "timmyg"
+        }
+      ]
     }
   },
   {
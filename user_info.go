@@ -1,6 +1,7 @@
 package untappd
 
 import (
+	"context"
 	"net/http"
 	"net/url"
 )
@@ -9,6 +10,11 @@ import (
 // If the compact parameter is set to 'true', only basic user information will
 // be populated.
 func (u *UserService) Info(username string, compact bool) (*User, *http.Response, error) {
+	return u.info(context.Background(), username, compact)
+}
+
+// info is the backing method for Info and Profile.
+func (u *UserService) info(ctx context.Context, username string, compact bool) (*User, *http.Response, error) {
 	// Determine if a compact response is requested
 	q := url.Values{}
 	if compact {
@@ -23,7 +29,7 @@ func (u *UserService) Info(username string, compact bool) (*User, *http.Response
 	}
 
 	// Perform request for user information by username
-	res, err := u.client.request("GET", "user/info/"+username, nil, q, &v)
+	res, err := u.client.requestContext(ctx, "GET", "user/info/"+username, nil, q, &v)
 	if err != nil {
 		return nil, res, err
 	}
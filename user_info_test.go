@@ -76,6 +76,82 @@ func TestClientUserInfoOK(t *testing.T) {
 	}
 }
 
+// TestClientUserInfoRecentBrews verifies that Client.User.Info returns a
+// user's recent brews, which the API embeds as a single object rather than
+// an array when only one is present.
+func TestClientUserInfoRecentBrews(t *testing.T) {
+	c, done := userInfoTestClient(t, func(t *testing.T, w http.ResponseWriter, r *http.Request) {
+		w.Write(gregavolaUserJSON)
+	})
+	defer done()
+
+	u, _, err := c.User.Info("gregavola", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if l := len(u.RecentBrews); l != 1 {
+		t.Fatalf("unexpected number of recent brews: %d != %d", l, 1)
+	}
+
+	b := u.RecentBrews[0]
+	beerName := "Brooklyn Bowl Pale Ale"
+	if n := b.Name; n != beerName {
+		t.Fatalf("unexpected recent brew Name: %q != %q", n, beerName)
+	}
+	breweryName := "Kelso of Brooklyn"
+	if n := b.Brewery.Name; n != breweryName {
+		t.Fatalf("unexpected recent brew Brewery.Name: %q != %q", n, breweryName)
+	}
+}
+
+// TestClientUserInfoMedia verifies that Client.User.Info returns a user's
+// media, which the API embeds as a single object rather than an array when
+// only one is present.
+func TestClientUserInfoMedia(t *testing.T) {
+	c, done := userInfoTestClient(t, func(t *testing.T, w http.ResponseWriter, r *http.Request) {
+		w.Write(gregavolaUserJSON)
+	})
+	defer done()
+
+	u, _, err := c.User.Info("gregavola", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if l := len(u.Media); l != 1 {
+		t.Fatalf("unexpected number of media: %d != %d", l, 1)
+	}
+
+	m := u.Media[0]
+	if id := m.ID; id != 24739915 {
+		t.Fatalf("unexpected media ID: %d != %d", id, 24739915)
+	}
+	if id := m.CheckinID; id != 133319903 {
+		t.Fatalf("unexpected media CheckinID: %d != %d", id, 133319903)
+	}
+	beerName := "Holiday Ale"
+	if n := m.Beer.Name; n != beerName {
+		t.Fatalf("unexpected media Beer.Name: %q != %q", n, beerName)
+	}
+
+	prefix := "https://d1c8v1qci5en44.cloudfront.net/photo/2014_11_28/0a418014e88d2bc841b0f4f688714ce7_"
+	for _, tt := range []struct {
+		description string
+		u           url.URL
+		suffix      string
+	}{
+		{description: "small", u: m.SmallImage, suffix: "100x100.jpg"},
+		{description: "medium", u: m.MediumImage, suffix: "320x320.jpg"},
+		{description: "large", u: m.LargeImage, suffix: "640x640.jpg"},
+		{description: "original", u: m.OriginalImage, suffix: "raw.jpg"},
+	} {
+		if got, want := tt.u.String(), prefix+tt.suffix; got != want {
+			t.Fatalf("unexpected %s image URL: %q != %q", tt.description, got, want)
+		}
+	}
+}
+
 // userInfoTestClient builds upon testClient, and adds additional sanity checks
 // for tests which target the user info API.
 func userInfoTestClient(t *testing.T, fn func(t *testing.T, w http.ResponseWriter, r *http.Request)) (*Client, func()) {
@@ -237,3 +313,35 @@ var gregavolaUserJSON = []byte(`
     "date_joined": "Wed, 07 Jul 2010 05:51:10 +0000"
   }
 }}`)
+
+// TestUserString verifies that User.String produces a concise description
+// of a User, including when fields are unavailable.
+func TestUserString(t *testing.T) {
+	tests := []struct {
+		u *User
+		s string
+	}{
+		{
+			u: nil,
+			s: "<nil>",
+		},
+		{
+			u: &User{UserName: "mdlayher"},
+			s: "mdlayher",
+		},
+		{
+			u: &User{UserName: "mdlayher", FirstName: "Matt"},
+			s: "mdlayher (Matt)",
+		},
+		{
+			u: &User{UserName: "mdlayher", FirstName: "Matt", LastName: "Layher"},
+			s: "mdlayher (Matt Layher)",
+		},
+	}
+
+	for i, tt := range tests {
+		if s := tt.u.String(); s != tt.s {
+			t.Fatalf("%02d: unexpected string: %q != %q", i, s, tt.s)
+		}
+	}
+}
@@ -0,0 +1,74 @@
+package untappd
+
+import (
+	"context"
+	"sync"
+)
+
+// UserProfile contains the information typically needed to display a User's
+// profile: information about the User, along with their recent checkins and
+// recently earned badges.
+type UserProfile struct {
+	User     *User      `json:"user"`
+	Checkins []*Checkin `json:"checkins"`
+	Badges   []*Badge   `json:"badges"`
+}
+
+// Profile queries for a User's information, recent checkins, and recently
+// earned badges, using concurrent requests.  The username parameter
+// specifies the User whose profile will be returned.  The User's total
+// number of beers is available in the returned User's Stats.
+//
+// Up to Client.DefaultLimit checkins and 50 badges are returned, and no
+// more than Client.Concurrency requests are performed at once.
+//
+// If some requests fail, Profile returns a UserProfile containing the results
+// of the requests which succeeded, along with a MultiError containing each
+// failure.  Members of UserProfile whose requests failed are nil.
+func (u *UserService) Profile(ctx context.Context, username string) (*UserProfile, error) {
+	var (
+		p    UserProfile
+		errs [3]error
+	)
+
+	fns := [...]func(){
+		func() {
+			p.User, _, errs[0] = u.info(ctx, username, false)
+		},
+		func() {
+			p.Checkins, _, errs[1] = u.checkinsMinMaxIDLimit(ctx, username, 0, 0, u.client.limit(MaxUserCheckinsLimit))
+		},
+		func() {
+			p.Badges, _, errs[2] = u.badgesOffsetLimit(ctx, username, 0, MaxBadgesLimit)
+		},
+	}
+
+	// Bound the number of concurrent requests using a semaphore
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, u.client.concurrency())
+	for _, fn := range fns {
+		wg.Add(1)
+		go func(fn func()) {
+			defer wg.Done()
+
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			fn()
+		}(fn)
+	}
+	wg.Wait()
+
+	// Return any partial results, along with every failure
+	var merr MultiError
+	for _, err := range errs {
+		if err != nil {
+			merr = append(merr, err)
+		}
+	}
+	if len(merr) > 0 {
+		return &p, merr
+	}
+
+	return &p, nil
+}
@@ -0,0 +1,104 @@
+package untappd
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+// TestClientUserProfileOK verifies that Client.User.Profile queries for a
+// user's information, checkins, and badges, and assembles them into a
+// UserProfile.
+func TestClientUserProfileOK(t *testing.T) {
+	c, done := userProfileTestClient(t, nil)
+	defer done()
+
+	p, err := c.User.Profile(context.Background(), "gregavola")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if s, want := p.User.UserName, "gregavola"; s != want {
+		t.Fatalf("unexpected user UserName: %q != %q", s, want)
+	}
+
+	// Check data against expected set of checkins
+	assertExpectedCheckins(t, p.Checkins)
+
+	if l := len(p.Badges); l != 2 {
+		t.Fatalf("unexpected number of badges: %d != %d", l, 2)
+	}
+}
+
+// TestClientUserProfilePartial verifies that Client.User.Profile returns
+// partial results, along with an error, when some requests fail.
+func TestClientUserProfilePartial(t *testing.T) {
+	c, done := userProfileTestClient(t, map[string]bool{
+		"badges": true,
+	})
+	defer done()
+
+	p, err := c.User.Profile(context.Background(), "gregavola")
+	if err == nil {
+		t.Fatal("an error was expected, but none occurred")
+	}
+	if !strings.Contains(err.Error(), "There is no user with that username.") {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var merr MultiError
+	if !errors.As(err, &merr) {
+		t.Fatalf("unexpected error type: %T", err)
+	}
+	if l := len(merr); l != 1 {
+		t.Fatalf("unexpected number of errors: %d != %d", l, 1)
+	}
+
+	if p.User == nil {
+		t.Fatal("user should have been returned")
+	}
+	if p.Checkins == nil {
+		t.Fatal("checkins should have been returned")
+	}
+	if p.Badges != nil {
+		t.Fatalf("unexpected badges: %v", p.Badges)
+	}
+}
+
+// userProfileTestClient builds upon testClient, and stubs each of the
+// endpoints queried by Client.User.Profile.  Endpoints present in fail
+// return an error.
+func userProfileTestClient(t *testing.T, fail map[string]bool) (*Client, func()) {
+	return testClient(t, func(t *testing.T, w http.ResponseWriter, r *http.Request) {
+		// Always GET request
+		method := "GET"
+		if m := r.Method; m != method {
+			t.Fatalf("unexpected HTTP method: %q != %q", m, method)
+		}
+
+		endpoints := map[string][]byte{
+			"info":     gregavolaUserJSON,
+			"checkins": userCheckinsJSON,
+			"badges":   userBadgesJSON,
+		}
+
+		for name, body := range endpoints {
+			if r.URL.Path != "/v4/user/"+name+"/gregavola/" {
+				continue
+			}
+
+			if fail[name] {
+				w.WriteHeader(http.StatusInternalServerError)
+				w.Write(invalidUserErrJSON)
+				return
+			}
+
+			w.Write(body)
+			return
+		}
+
+		t.Fatalf("unexpected HTTP path: %q", r.URL.Path)
+	})
+}
@@ -10,12 +10,12 @@ import (
 // WishList queries for information about a User's wish list beers.
 // The username parameter specifies the User whose beers will be returned.
 //
-// This method returns up to 25 of the User's wish list beers.
+// This method returns up to Client.DefaultLimit of the User's wish list beers.
 // For more granular control, and to page through and sort the beers list, use
 // WishListOffsetLimitSort instead.
 func (u *UserService) WishList(username string) ([]*Beer, *http.Response, error) {
 	// Use default parameters as specified by API
-	return u.WishListOffsetLimitSort(username, 0, 25, SortDate)
+	return u.WishListOffsetLimitSort(username, 0, u.client.limit(MaxBeersLimit), SortDate)
 }
 
 // WishListOffsetLimitSort queries for information about a User's wish list beers,
@@ -25,7 +25,19 @@ func (u *UserService) WishList(username string) ([]*Beer, *http.Response, error)
 // Sort constants with this package.
 //
 // 50 beers is the maximum number of beers which may be returned by one call.
+// If limit exceeds MaxBeersLimit, ErrInvalidLimit is returned.
+//
+// If sort is not one of the values returned by Sorts, ErrInvalidSort is
+// returned and no request is performed.
 func (u *UserService) WishListOffsetLimitSort(username string, offset int, limit int, sort Sort) ([]*Beer, *http.Response, error) {
+	if !sort.valid() {
+		return nil, nil, ErrInvalidSort
+	}
+
+	if err := checkLimit(limit, MaxBeersLimit); err != nil {
+		return nil, nil, err
+	}
+
 	q := url.Values{
 		"offset": []string{strconv.Itoa(offset)},
 		"limit":  []string{strconv.Itoa(limit)},
@@ -52,14 +64,12 @@ func (u *UserService) WishListOffsetLimitSort(username string, offset int, limit
 		return nil, res, err
 	}
 
-	// Build result slice from struct
-	beers := make([]*Beer, v.Response.Beers.Count)
+	// Build result slice from struct, sized by the number of items actually
+	// returned, since the reported count may not match
+	beers := make([]*Beer, len(v.Response.Beers.Items))
 	for i := range v.Response.Beers.Items {
-		// Information about the beer itself
-		beers[i] = v.Response.Beers.Items[i].Beer.export()
-
-		// Information about the beer's brewery
-		beers[i].Brewery = v.Response.Beers.Items[i].Brewery.export()
+		// Information about the beer itself, and its brewery
+		beers[i] = v.Response.Beers.Items[i].Beer.exportWithBrewery(v.Response.Beers.Items[i].Brewery)
 
 		// Information related to this user and this beer
 		beers[i].WishListed = time.Time(v.Response.Beers.Items[i].WishListed)
@@ -46,6 +46,42 @@ func TestClientUserWishListOffsetLimitBadUser(t *testing.T) {
 	assertInvalidUserErr(t, err)
 }
 
+// TestClientUserWishListOffsetLimitSortBadSort verifies that Client.User.WishListOffsetLimitSort
+// returns ErrInvalidSort without performing a request when an unknown sort
+// is used.
+func TestClientUserWishListOffsetLimitSortBadSort(t *testing.T) {
+	c, done := userWishListTestClient(t, func(t *testing.T, w http.ResponseWriter, r *http.Request) {
+		t.Fatal("request should not have been performed")
+	})
+	defer done()
+
+	if _, _, err := c.User.WishListOffsetLimitSort("foo", 0, 25, Sort("foo")); err != ErrInvalidSort {
+		t.Fatalf("unexpected error: %v != %v", err, ErrInvalidSort)
+	}
+}
+
+// TestClientUserWishListOffsetLimitSortCountMismatch verifies that
+// Client.User.WishListOffsetLimitSort returns only the beers present in a
+// response, even if the reported count is larger.
+func TestClientUserWishListOffsetLimitSortCountMismatch(t *testing.T) {
+	c, done := userWishListTestClient(t, func(t *testing.T, w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"meta":{"code":200},"response":{"beers":{"count":50,"items":[{"beer":{"bid":1}}]}}}`))
+	})
+	defer done()
+
+	beers, _, err := c.User.WishListOffsetLimitSort("foo", 0, 50, SortDate)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if l := len(beers); l != 1 {
+		t.Fatalf("unexpected number of beers: %d != %d", l, 1)
+	}
+	if beers[0] == nil {
+		t.Fatal("unexpected nil beer")
+	}
+}
+
 // TestClientUserWishListOffsetLimitSortOK verifies that Client.User.WishListOffsetLimitSort
 // returns a valid beers list, when used with correct parameters.
 func TestClientUserWishListOffsetLimitSortOK(t *testing.T) {
@@ -1,6 +1,8 @@
 package untappd
 
 import (
+	"encoding/json"
+	"net/url"
 	"time"
 )
 
@@ -8,27 +10,41 @@ import (
 // venue's name, location, categories, and various other metadata.
 type Venue struct {
 	// Metadata from Untappd.
-	ID      int
-	Name    string
-	Updated time.Time
+	ID   int64  `json:"id"`
+	Name string `json:"name"`
+
+	// Time when this venue was last updated.  Not all responses contain
+	// this information, in which case Updated is the zero time.Time.
+	Updated time.Time `json:"updated"`
 
 	// Category of thie venue.
-	Category string
+	Category string `json:"category"`
 
 	// Is this a public venue?
-	Public bool
+	Public bool `json:"public"`
 
 	// Location of this venue.
-	Location VenueLocation
+	Location VenueLocation `json:"location"`
 
 	// Foursquare data.
-	Foursquare VenueFoursquare
+	Foursquare VenueFoursquare `json:"foursquare"`
+
+	// Contact information for this venue.
+	Contact VenueContact `json:"contact"`
+
+	// Icons for this venue's category, in several sizes.
+	Icon VenueIcon `json:"icon"`
+
+	// Photos of this venue.  Not all responses contain photos, in which
+	// case Photos is empty.
+	Photos []url.URL `json:"photos"`
 
-	// Popular beers at this venue.
-	TopBeers []*Beer
+	// Popular beers at this venue, along with statistics about each beer
+	// at this venue.  To retrieve only the beers, use TopBeerList.
+	TopBeers []VenueTopBeer `json:"top_beers"`
 
 	// Checkins at this venue.
-	Checkins []*Checkin
+	Checkins []*Checkin `json:"checkins"`
 }
 
 // VenueService is a "service" which allows access to API methods involving
@@ -56,16 +72,77 @@ type VenueFoursquare struct {
 	URL string `json:"foursquare_url"`
 }
 
+// VenueContact represents an Untappd venue's contact information, and
+// contains the venue's Twitter handle and website URL.
+type VenueContact struct {
+	Twitter string  `json:"twitter"`
+	URL     url.URL `json:"url"`
+}
+
+// VenueTopBeer represents a popular beer at an Untappd venue, and contains
+// statistics about checkins of that beer at the venue.
+type VenueTopBeer struct {
+	// The popular beer.
+	Beer *Beer `json:"beer"`
+
+	// Time when this beer was most recently checked in at the venue.
+	Created time.Time `json:"created"`
+
+	// Total number of checkins of this beer at the venue.
+	TotalCount int `json:"total_count"`
+
+	// Number of checkins of this beer at the venue by the authenticated
+	// user.  Only populated for authenticated requests.
+	YourCount int `json:"your_count"`
+}
+
+// TopBeerList returns only the beers from a Venue's TopBeers, in the same
+// order.  If the Venue is nil, TopBeerList returns nil.
+func (v *Venue) TopBeerList() []*Beer {
+	if v == nil {
+		return nil
+	}
+
+	beers := make([]*Beer, 0, len(v.TopBeers))
+	for _, tb := range v.TopBeers {
+		beers = append(beers, tb.Beer)
+	}
+
+	return beers
+}
+
+// VenueIcon represents the icons for an Untappd venue's category, and
+// contains URLs to small, medium, and large versions of the icon.
+type VenueIcon struct {
+	SmallImage  url.URL `json:"small_image"`
+	MediumImage url.URL `json:"medium_image"`
+	LargeImage  url.URL `json:"large_image"`
+}
+
+// String returns a concise string representation of a Venue, containing its
+// name and location, such as "Untappd HQ (Wilmington, DE)".  Location is
+// omitted if unavailable.
+func (v *Venue) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+
+	return withDetail(v.Name, joinNonEmpty(", ", v.Location.City, v.Location.State))
+}
+
 // rawVenue is the raw JSON representation of an Untappd venue.  Its data is
 // unmarshaled from JSON and then exported to a Venue struct.
 type rawVenue struct {
-	ID         int             `json:"venue_id"`
+	ID         int64           `json:"venue_id"`
 	Name       string          `json:"venue_name"`
 	Updated    responseTime    `json:"last_updated"`
 	Category   string          `json:"primary_category"`
 	Public     bool            `json:"public_venue"`
 	Location   VenueLocation   `json:"location"`
 	Foursquare VenueFoursquare `json:"foursquare"`
+	Contact    rawVenueContact `json:"contact"`
+	Icon       rawVenueIcon    `json:"venue_icon"`
+	Photos     responsePhotos  `json:"venue_photos"`
 	TopBeers   struct {
 		Offset int `json:"offset"`
 		Limit  int `json:"limit"`
@@ -88,15 +165,23 @@ type rawVenue struct {
 // export creates an exported Venue from a rawVenue struct, allowing for
 // more useful structures to be created for client consumption.
 func (r *rawVenue) export() *Venue {
-	beers := make([]*Beer, r.TopBeers.Count)
-	for i := range r.TopBeers.Items {
-		beers[i] = r.TopBeers.Items[i].Beer.export()
-		beers[i].Brewery = r.TopBeers.Items[i].Brewery.export()
+	beers := make([]VenueTopBeer, len(r.TopBeers.Items))
+	for i, item := range r.TopBeers.Items {
+		beers[i] = VenueTopBeer{
+			Beer:       item.Beer.exportWithBrewery(item.Brewery),
+			Created:    time.Time(item.Created),
+			TotalCount: item.TotalCount,
+			YourCount:  item.YourCount,
+		}
 	}
 
-	checkins := make([]*Checkin, r.Checkins.Count)
-	for i := range r.Checkins.Items {
-		checkins[i] = r.Checkins.Items[i].export()
+	checkins := make([]*Checkin, 0, len(r.Checkins.Items))
+	for _, item := range r.Checkins.Items {
+		if item == nil {
+			continue
+		}
+
+		checkins = append(checkins, item.export())
 	}
 
 	return &Venue{
@@ -107,7 +192,168 @@ func (r *rawVenue) export() *Venue {
 		Public:     r.Public,
 		Location:   r.Location,
 		Foursquare: r.Foursquare,
+		Contact:    r.Contact.export(),
+		Icon:       r.Icon.export(),
+		Photos:     r.Photos.export(),
 		TopBeers:   beers,
 		Checkins:   checkins,
 	}
 }
+
+// MarshalJSON implements json.Marshaler, so that a Venue's photo URLs are
+// encoded as strings.
+func (v Venue) MarshalJSON() ([]byte, error) {
+	type venue Venue
+	photos := make([]string, 0, len(v.Photos))
+	for _, p := range v.Photos {
+		photos = append(photos, p.String())
+	}
+
+	return json.Marshal(struct {
+		venue
+		Photos []string `json:"photos"`
+	}{
+		venue:  venue(v),
+		Photos: photos,
+	})
+}
+
+// UnmarshalJSON implements json.Unmarshaler, so that JSON produced by
+// MarshalJSON can be decoded into a Venue.
+func (v *Venue) UnmarshalJSON(data []byte) error {
+	type venue Venue
+	vv := struct {
+		*venue
+		Photos []string `json:"photos"`
+	}{
+		venue: (*venue)(v),
+	}
+
+	if err := json.Unmarshal(data, &vv); err != nil {
+		return err
+	}
+
+	v.Photos = make([]url.URL, 0, len(vv.Photos))
+	for _, p := range vv.Photos {
+		u, err := url.Parse(p)
+		if err != nil {
+			return err
+		}
+		v.Photos = append(v.Photos, *u)
+	}
+
+	return nil
+}
+
+// rawVenueIcon is the raw JSON representation of an Untappd venue's icon.  Its
+// data is unmarshaled from JSON and then exported to a VenueIcon struct.
+type rawVenueIcon struct {
+	SmallImage  responseURL `json:"sm"`
+	MediumImage responseURL `json:"md"`
+	LargeImage  responseURL `json:"lg"`
+}
+
+// export creates an exported VenueIcon from a rawVenueIcon struct, allowing
+// for more useful structures to be created for client consumption.
+func (r *rawVenueIcon) export() VenueIcon {
+	return VenueIcon{
+		SmallImage:  url.URL(r.SmallImage),
+		MediumImage: url.URL(r.MediumImage),
+		LargeImage:  url.URL(r.LargeImage),
+	}
+}
+
+// MarshalJSON implements json.Marshaler, so that VenueIcon's URLs are
+// encoded as strings.
+func (i VenueIcon) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		SmallImage  string `json:"small_image"`
+		MediumImage string `json:"medium_image"`
+		LargeImage  string `json:"large_image"`
+	}{
+		SmallImage:  i.SmallImage.String(),
+		MediumImage: i.MediumImage.String(),
+		LargeImage:  i.LargeImage.String(),
+	})
+}
+
+// UnmarshalJSON implements json.Unmarshaler, so that JSON produced by
+// MarshalJSON can be decoded into a VenueIcon.
+func (i *VenueIcon) UnmarshalJSON(data []byte) error {
+	var v struct {
+		SmallImage  string `json:"small_image"`
+		MediumImage string `json:"medium_image"`
+		LargeImage  string `json:"large_image"`
+	}
+
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+
+	for _, p := range []struct {
+		s string
+		u *url.URL
+	}{
+		{s: v.SmallImage, u: &i.SmallImage},
+		{s: v.MediumImage, u: &i.MediumImage},
+		{s: v.LargeImage, u: &i.LargeImage},
+	} {
+		u, err := url.Parse(p.s)
+		if err != nil {
+			return err
+		}
+		*p.u = *u
+	}
+
+	return nil
+}
+
+// rawVenueContact is the raw JSON representation of an Untappd venue's
+// contact information.  Its data is unmarshaled from JSON and then exported
+// to a VenueContact struct.
+type rawVenueContact struct {
+	Twitter string      `json:"twitter"`
+	URL     responseURL `json:"venue_url"`
+}
+
+// export creates an exported VenueContact from a rawVenueContact struct.
+func (r *rawVenueContact) export() VenueContact {
+	return VenueContact{
+		Twitter: r.Twitter,
+		URL:     url.URL(r.URL),
+	}
+}
+
+// MarshalJSON implements json.Marshaler, so that a VenueContact's URL is
+// encoded as a string.
+func (c VenueContact) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Twitter string `json:"twitter"`
+		URL     string `json:"url"`
+	}{
+		Twitter: c.Twitter,
+		URL:     c.URL.String(),
+	})
+}
+
+// UnmarshalJSON implements json.Unmarshaler, so that JSON produced by
+// MarshalJSON can be decoded into a VenueContact.
+func (c *VenueContact) UnmarshalJSON(data []byte) error {
+	var v struct {
+		Twitter string `json:"twitter"`
+		URL     string `json:"url"`
+	}
+
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+
+	u, err := url.Parse(v.URL)
+	if err != nil {
+		return err
+	}
+
+	c.Twitter = v.Twitter
+	c.URL = *u
+	return nil
+}
@@ -1,7 +1,7 @@
 package untappd
 
 import (
-	"math"
+	"context"
 	"net/http"
 	"net/url"
 	"strconv"
@@ -11,27 +11,85 @@ import (
 // The ID parameter specifies the Venue ID, which will return
 // a list of recent checkins for a given Venue.
 //
-// This method returns up to 25 of the Venue's most recent checkins.
-// For more granular control, and to page through the checkins list using ID
-// parameters, use CheckinsMinMaxIDLimit instead.
-func (v *VenueService) Checkins(id int) ([]*Checkin, *http.Response, error) {
-	// Use default parameters as specified by API.  Max ID is somewhat
-	// arbitrary, but should provide plenty of headroom, just in case.
-	return v.CheckinsMinMaxIDLimit(id, 0, math.MaxInt32, 25)
+// This method returns up to Client.DefaultLimit of the Venue's most recent
+// checkins.  For more granular control, and to page through the checkins list
+// using ID parameters, use CheckinsMinMaxIDLimit instead.
+func (v *VenueService) Checkins(id int64) ([]*Checkin, *http.Response, error) {
+	return v.CheckinsMinMaxIDLimit(id, 0, 0, v.client.limit(MaxCheckinsLimit))
 }
 
 // CheckinsMinMaxIDLimit queries for information about a Venue's checkins,
 // but also accepts minimum checkin ID, maximum checkin ID, and a limit
 // parameter to enable paging through checkins. The ID parameter
 // specifies the Venue ID, which will return a list of recent checkins
-// for a given Venue.
+// for a given Venue.  A minID or maxID of zero means the checkins are not
+// bounded in that direction.
 //
 // 25 checkins is the maximum number of checkins which may be returned by
-// one call.
-func (v *VenueService) CheckinsMinMaxIDLimit(id int, minID int, maxID int, limit int) ([]*Checkin, *http.Response, error) {
-	return v.client.getCheckins("venue/checkins/"+strconv.Itoa(id), url.Values{
-		"min_id": []string{strconv.Itoa(minID)},
-		"max_id": []string{strconv.Itoa(maxID)},
-		"limit":  []string{strconv.Itoa(limit)},
-	})
+// one call.  If limit exceeds MaxCheckinsLimit, ErrInvalidLimit is returned.
+func (v *VenueService) CheckinsMinMaxIDLimit(id int64, minID int64, maxID int64, limit int) ([]*Checkin, *http.Response, error) {
+	if err := checkLimit(limit, MaxCheckinsLimit); err != nil {
+		return nil, nil, err
+	}
+
+	return v.client.getCheckins("venue/checkins/"+strconv.FormatInt(id, 10), checkinsQuery(minID, maxID, limit))
+}
+
+// CheckinsEach pages through all of a Venue's checkins, from most recent to
+// least recent, and invokes fn for each checkin.  Each page of checkins is
+// read in full before fn is invoked for its checkins, but checkins are not
+// accumulated across pages, so CheckinsEach may be used to walk a Venue's
+// entire checkin feed without building a list of every checkin.
+//
+// Paging stops when a page contains fewer than MaxCheckinsLimit items, or
+// when no older checkins can be requested.  A page in which every item was
+// filtered by the API does not end the walk; the pagination cursor reported
+// by the API is used to continue past it instead.  If fn returns an error,
+// no further checkins are decoded or requested, and that error is returned.
+// If ctx is canceled, the error from the in-flight request is returned.
+func (v *VenueService) CheckinsEach(ctx context.Context, id int64, fn func(c *Checkin) error) error {
+	endpoint := "venue/checkins/" + strconv.FormatInt(id, 10)
+
+	var maxID int64
+	for {
+		q := url.Values{
+			"limit": []string{strconv.Itoa(MaxCheckinsLimit)},
+		}
+		if maxID != 0 {
+			q.Set("max_id", strconv.FormatInt(maxID, 10))
+		}
+
+		items, oldest, res, err := v.client.eachCheckin(ctx, endpoint, q, fn)
+		if err != nil {
+			return err
+		}
+
+		// A short page indicates that no more checkins remain
+		if items < MaxCheckinsLimit {
+			return nil
+		}
+
+		// Begin the next page immediately following the oldest checkin,
+		// unless every item in the page was null, in which case only the
+		// API's pagination cursor can move past the page
+		next := oldest - 1
+		if oldest == 0 {
+			p, err := ResponsePagination(res)
+			if err != nil {
+				return err
+			}
+			if p == nil {
+				return nil
+			}
+
+			next = p.MaxID
+		}
+
+		// A cursor which does not move would repeat forever
+		if next <= 0 || (maxID != 0 && next >= maxID) {
+			return nil
+		}
+
+		maxID = next
+	}
 }
@@ -1,19 +1,22 @@
 package untappd
 
 import (
-	"math"
+	"context"
+	"errors"
+	"fmt"
 	"net/http"
 	"net/url"
+	"reflect"
 	"strconv"
 	"strings"
 	"testing"
 )
 
-// TestClientVenueCheckinsOK verifies that Client.Venue.Checkins always sets the
-// appropriate default minimum ID, maximum ID, and limit values.
+// TestClientVenueCheckinsOK verifies that Client.Venue.Checkins omits the
+// minimum and maximum IDs, and sets the appropriate default limit.
 func TestClientVenueCheckinsOK(t *testing.T) {
-	minID := "0"
-	maxID := strconv.Itoa(math.MaxInt32)
+	// No bounds are sent by default
+	minID, maxID := "", ""
 	limit := "25"
 
 	c, done := venueCheckinsTestClient(t, func(t *testing.T, w http.ResponseWriter, r *http.Request) {
@@ -43,24 +46,53 @@ func TestClientVenueCheckinsMinMaxIDLimitBadVenue(t *testing.T) {
 	})
 	defer done()
 
-	_, _, err := c.Venue.CheckinsMinMaxIDLimit(-1, 0, math.MaxInt32, 25)
+	_, _, err := c.Venue.CheckinsMinMaxIDLimit(-1, 0, 0, 25)
 	assertInvalidVenueErr(t, err)
 }
 
+// TestClientVenueCheckinsMinMaxIDLimitBadLimit verifies that
+// Client.Venue.CheckinsMinMaxIDLimit returns ErrInvalidLimit without performing
+// a request when a limit greater than MaxCheckinsLimit is used.
+func TestClientVenueCheckinsMinMaxIDLimitBadLimit(t *testing.T) {
+	c, done := venueCheckinsTestClient(t, func(t *testing.T, w http.ResponseWriter, r *http.Request) {
+		t.Fatal("request should not have been performed")
+	})
+	defer done()
+
+	_, _, err := c.Venue.CheckinsMinMaxIDLimit(1, 0, 0, MaxCheckinsLimit+1)
+	if err != ErrInvalidLimit {
+		t.Fatalf("unexpected error: %v != %v", err, ErrInvalidLimit)
+	}
+}
+
+// TestClientVenueCheckinsMinMaxIDLimitZeroLimit verifies that
+// Client.Venue.CheckinsMinMaxIDLimit permits a zero limit, so that the API's
+// default limit is used.
+func TestClientVenueCheckinsMinMaxIDLimitZeroLimit(t *testing.T) {
+	c, done := venueCheckinsTestClient(t, func(t *testing.T, w http.ResponseWriter, r *http.Request) {
+		w.Write(userCheckinsJSON)
+	})
+	defer done()
+
+	if _, _, err := c.Venue.CheckinsMinMaxIDLimit(1, 0, 0, 0); err != nil {
+		t.Fatal(err)
+	}
+}
+
 // TestClientVenueCheckinsMinMaxIDLimitOK verifies that Client.Venue.CheckinsMinMaxIDLimit
 // returns a valid checkins list, when used with correct parameters.
 func TestClientVenueCheckinsMinMaxIDLimitOffsetLimitOK(t *testing.T) {
-	var minID int
-	sMinID := strconv.Itoa(minID)
+	var minID int64 = 137117700
+	sMinID := strconv.FormatInt(minID, 10)
 
-	var maxID = math.MaxInt32
-	sMaxID := strconv.Itoa(maxID)
+	var maxID int64 = 137117722
+	sMaxID := strconv.FormatInt(maxID, 10)
 
 	var limit = 25
 	sLimit := strconv.Itoa(limit)
 
-	id := 1
-	sID := strconv.Itoa(id)
+	id := int64(1)
+	sID := strconv.FormatInt(id, 10)
 	c, done := venueCheckinsTestClient(t, func(t *testing.T, w http.ResponseWriter, r *http.Request) {
 		path := "/v4/venue/checkins/" + sID + "/"
 		if p := r.URL.Path; p != path {
@@ -88,6 +120,201 @@ func TestClientVenueCheckinsMinMaxIDLimitOffsetLimitOK(t *testing.T) {
 	assertExpectedCheckins(t, checkins)
 }
 
+// TestClientVenueCheckinsEachOK verifies that Client.Venue.CheckinsEach
+// invokes its callback once for each checkin, and stops paging when a page
+// is not full.
+func TestClientVenueCheckinsEachOK(t *testing.T) {
+	var requests int
+	c, done := venueCheckinsTestClient(t, func(t *testing.T, w http.ResponseWriter, r *http.Request) {
+		requests++
+
+		assertParameters(t, r, url.Values{
+			"max_id": []string{""},
+			"limit":  []string{strconv.Itoa(MaxCheckinsLimit)},
+		})
+
+		w.Write(userCheckinsJSON)
+	})
+	defer done()
+
+	var checkins []*Checkin
+	err := c.Venue.CheckinsEach(context.Background(), 1, func(c *Checkin) error {
+		checkins = append(checkins, c)
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if requests != 1 {
+		t.Fatalf("unexpected number of requests: %d != %d", requests, 1)
+	}
+
+	// Check data against expected set of checkins
+	assertExpectedCheckins(t, checkins)
+}
+
+// TestClientVenueCheckinsEachNullItems verifies that Client.Venue.CheckinsEach
+// continues paging past full pages which contain null items, including pages
+// in which every item is null.
+func TestClientVenueCheckinsEachNullItems(t *testing.T) {
+	// items generates JSON checkin items with the input IDs, using null
+	// for IDs of zero
+	items := func(ids ...int64) string {
+		var out []string
+		for _, id := range ids {
+			if id == 0 {
+				out = append(out, "null")
+				continue
+			}
+			out = append(out, fmt.Sprintf(`{"checkin_id":%d}`, id))
+		}
+
+		return strings.Join(out, ",")
+	}
+
+	// full generates the IDs for a full page of checkins beginning with
+	// first, preceded by the specified number of null items
+	full := func(first int64, nulls int) []int64 {
+		ids := make([]int64, MaxCheckinsLimit)
+		for i := nulls; i < len(ids); i++ {
+			ids[i] = first - int64(i-nulls)
+		}
+
+		return ids
+	}
+
+	var (
+		requests int
+		maxIDs   []string
+	)
+	c, done := venueCheckinsTestClient(t, func(t *testing.T, w http.ResponseWriter, r *http.Request) {
+		requests++
+		maxIDs = append(maxIDs, r.URL.Query().Get("max_id"))
+
+		var body string
+		switch requests {
+		case 1:
+			// A full page with one null item
+			body = fmt.Sprintf(`{"checkins":{"count":%d,"items":[%s]}}`,
+				MaxCheckinsLimit, items(full(100, 1)...))
+		case 2:
+			// A full page of only null items, with a pagination cursor
+			body = fmt.Sprintf(`{"pagination":{"max_id":50},"checkins":{"count":%d,"items":[%s]}}`,
+				MaxCheckinsLimit, items(make([]int64, MaxCheckinsLimit)...))
+		case 3:
+			body = fmt.Sprintf(`{"checkins":{"count":2,"items":[%s]}}`, items(50, 49))
+		default:
+			t.Fatalf("unexpected request %d", requests)
+		}
+
+		fmt.Fprintf(w, `{"meta":{"code":200},"response":%s}`, body)
+	})
+	defer done()
+
+	var calls int
+	err := c.Venue.CheckinsEach(context.Background(), 1, func(c *Checkin) error {
+		calls++
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := []string{"", "76", "50"}; !reflect.DeepEqual(maxIDs, want) {
+		t.Fatalf("unexpected max_id parameters: %v != %v", maxIDs, want)
+	}
+	if want := MaxCheckinsLimit - 1 + 2; calls != want {
+		t.Fatalf("unexpected number of callback invocations: %d != %d", calls, want)
+	}
+}
+
+// TestClientVenueCheckinsEachPages verifies that Client.Venue.CheckinsEach
+// pages through all checkins using the last checkin ID of each page.
+func TestClientVenueCheckinsEachPages(t *testing.T) {
+	var requests int
+	c, done := venueCheckinsTestClient(t, func(t *testing.T, w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Write(userCheckinsPageJSON(t, r, 60))
+	})
+	defer done()
+
+	var calls int
+	want := int64(60)
+	err := c.Venue.CheckinsEach(context.Background(), 1, func(c *Checkin) error {
+		if c.ID != want {
+			t.Fatalf("unexpected checkin ID: %d != %d", c.ID, want)
+		}
+
+		calls++
+		want--
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if calls != 60 {
+		t.Fatalf("unexpected number of callback invocations: %d != %d", calls, 60)
+	}
+	if requests != 3 {
+		t.Fatalf("unexpected number of requests: %d != %d", requests, 3)
+	}
+}
+
+// TestClientVenueCheckinsEachStop verifies that Client.Venue.CheckinsEach
+// stops decoding and paging when its callback returns an error.
+func TestClientVenueCheckinsEachStop(t *testing.T) {
+	var requests int
+	c, done := venueCheckinsTestClient(t, func(t *testing.T, w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Write(userCheckinsPageJSON(t, r, 60))
+	})
+	defer done()
+
+	errStop := errors.New("stop")
+
+	var calls int
+	err := c.Venue.CheckinsEach(context.Background(), 1, func(c *Checkin) error {
+		calls++
+		if calls == 3 {
+			return errStop
+		}
+
+		return nil
+	})
+	if err != errStop {
+		t.Fatalf("unexpected error: %v != %v", err, errStop)
+	}
+
+	if calls != 3 {
+		t.Fatalf("unexpected number of callback invocations: %d != %d", calls, 3)
+	}
+	if requests != 1 {
+		t.Fatalf("unexpected number of requests: %d != %d", requests, 1)
+	}
+}
+
+// TestClientVenueCheckinsEachCanceled verifies that Client.Venue.CheckinsEach
+// returns an error without invoking its callback when its context is canceled.
+func TestClientVenueCheckinsEachCanceled(t *testing.T) {
+	c, done := venueCheckinsTestClient(t, func(t *testing.T, w http.ResponseWriter, r *http.Request) {
+		w.Write(userCheckinsJSON)
+	})
+	defer done()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err := c.Venue.CheckinsEach(ctx, 1, func(c *Checkin) error {
+		t.Fatal("callback should not have been invoked")
+		return nil
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
 // venueCheckinsTestClient builds upon testClient, and adds additional sanity checks
 // for tests which target the venue checkin API.
 func venueCheckinsTestClient(t *testing.T, fn func(t *testing.T, w http.ResponseWriter, r *http.Request)) (*Client, func()) {
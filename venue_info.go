@@ -9,9 +9,25 @@ import (
 // Info queries for information about a Venue with the specified ID.
 // If the compact parameter is set to 'true', only basic venue information will
 // be populated.
-func (b *VenueService) Info(id int, compact bool) (*Venue, *http.Response, error) {
+//
+// This method returns up to 25 of the Venue's top beers.  For more granular
+// control, and to page through the top beers list, use InfoTopBeers instead.
+func (b *VenueService) Info(id int64, compact bool) (*Venue, *http.Response, error) {
+	// Use default parameters as specified by API
+	return b.InfoTopBeers(id, compact, 0, 25)
+}
+
+// InfoTopBeers queries for information about a Venue with the specified ID,
+// but also accepts offset and limit parameters to enable paging through the
+// Venue's top beers.  If the compact parameter is set to 'true', only basic
+// venue information will be populated.
+func (b *VenueService) InfoTopBeers(id int64, compact bool, offset int, limit int) (*Venue, *http.Response, error) {
+	q := url.Values{
+		"offset": []string{strconv.Itoa(offset)},
+		"limit":  []string{strconv.Itoa(limit)},
+	}
+
 	// Determine if a compact response is requested
-	q := url.Values{}
 	if compact {
 		q.Set("compact", "true")
 	}
@@ -24,7 +40,7 @@ func (b *VenueService) Info(id int, compact bool) (*Venue, *http.Response, error
 	}
 
 	// Perform request for venue information by ID
-	res, err := b.client.request("GET", "venue/info/"+strconv.Itoa(id), nil, q, &v)
+	res, err := b.client.request("GET", "venue/info/"+strconv.FormatInt(id, 10), nil, q, &v)
 	if err != nil {
 		return nil, res, err
 	}
@@ -1,8 +1,10 @@
 package untappd
 
 import (
+	"encoding/json"
 	"net/http"
 	"net/url"
+	"reflect"
 	"strconv"
 	"strings"
 	"testing"
@@ -11,8 +13,8 @@ import (
 // TestClientVenueInfoBadVenue verifies that Client.Venue.Info returns an error when
 // an invalid venue is queried.
 func TestClientVenueInfoBadVenue(t *testing.T) {
-	venueID := -1
-	sVenueID := strconv.Itoa(venueID)
+	venueID := int64(-1)
+	sVenueID := strconv.FormatInt(venueID, 10)
 
 	c, done := venueInfoTestClient(t, func(t *testing.T, w http.ResponseWriter, r *http.Request) {
 		path := "/v4/venue/info/" + sVenueID + "/"
@@ -52,8 +54,8 @@ func TestClientVenueInfoCompactOK(t *testing.T) {
 // TestClientVenueInfoOK verifies that Client.Venue.Info returns a valid venue when
 // provided with correct input parameters.
 func TestClientVenueInfoOK(t *testing.T) {
-	venueID := 1021
-	sVenueID := strconv.Itoa(venueID)
+	venueID := int64(1021)
+	sVenueID := strconv.FormatInt(venueID, 10)
 
 	c, done := venueInfoTestClient(t, func(t *testing.T, w http.ResponseWriter, r *http.Request) {
 		path := "/v4/venue/info/" + sVenueID + "/"
@@ -91,11 +93,11 @@ func TestClientVenueInfoOK(t *testing.T) {
 	}
 
 	beerName := "Beer Name"
-	if c := v.TopBeers[0].Name; c != beerName {
+	if c := v.TopBeers[0].Beer.Name; c != beerName {
 		t.Fatalf("unexpected TopBeers[0].Name: %q != %q", c, beerName)
 	}
 	beerBrewery := "Brewery Name"
-	if c := v.TopBeers[0].Brewery.Name; c != beerBrewery {
+	if c := v.TopBeers[0].Beer.Brewery.Name; c != beerBrewery {
 		t.Fatalf("unexpected TopBeers[0].Brewery.Name: %q != %q", c, beerBrewery)
 	}
 	if c := v.Checkins[0].Beer.Name; c != beerName {
@@ -106,6 +108,220 @@ func TestClientVenueInfoOK(t *testing.T) {
 	}
 }
 
+// TestClientVenueInfoPhotos verifies that Client.Venue.Info returns a venue's
+// icon and photos, using the largest available image for each photo.
+func TestClientVenueInfoPhotos(t *testing.T) {
+	c, done := venueInfoTestClient(t, func(t *testing.T, w http.ResponseWriter, r *http.Request) {
+		w.Write(venuePhotosJSON)
+	})
+	defer done()
+
+	v, _, err := c.Venue.Info(1, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if s, want := v.Icon.LargeImage.String(), "https://ss3.4sqi.net/img/categories_v2/food/brewery_bg_88.png"; s != want {
+		t.Fatalf("unexpected venue icon URL: %q != %q", s, want)
+	}
+
+	var photos []string
+	for _, p := range v.Photos {
+		photos = append(photos, p.String())
+	}
+
+	want := []string{
+		"https://untappd.akamaized.net/photo/1_og.jpg",
+		"https://untappd.akamaized.net/photo/2_lg.jpg",
+	}
+	if !reflect.DeepEqual(photos, want) {
+		t.Fatalf("unexpected venue photos:\n- want: %v\n-  got: %v", want, photos)
+	}
+}
+
+// TestVenueMarshalJSON verifies that a Venue's URLs are encoded to JSON as
+// strings, and can be decoded again.
+func TestVenueMarshalJSON(t *testing.T) {
+	v := &Venue{
+		ID:     1,
+		Name:   "Untappd HQ",
+		Icon:   VenueIcon{SmallImage: mustParseURL(t, "https://example.com/sm.png")},
+		Photos: []url.URL{mustParseURL(t, "https://example.com/1.jpg")},
+	}
+
+	b, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var raw struct {
+		Icon struct {
+			SmallImage string `json:"small_image"`
+		} `json:"icon"`
+		Photos []string `json:"photos"`
+	}
+	if err := json.Unmarshal(b, &raw); err != nil {
+		t.Fatal(err)
+	}
+
+	if s, want := raw.Icon.SmallImage, "https://example.com/sm.png"; s != want {
+		t.Fatalf("unexpected encoded icon URL: %q != %q", s, want)
+	}
+	if want := []string{"https://example.com/1.jpg"}; !reflect.DeepEqual(raw.Photos, want) {
+		t.Fatalf("unexpected encoded photos: %v != %v", raw.Photos, want)
+	}
+
+	var out Venue
+	if err := json.Unmarshal(b, &out); err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(&out, v) {
+		t.Fatalf("unexpected decoded venue:\n- want: %+v\n-  got: %+v", v, &out)
+	}
+}
+
+// TestClientVenueInfoTopBeersOK verifies that Client.Venue.InfoTopBeers
+// requests the appropriate page of a venue's top beers.
+func TestClientVenueInfoTopBeersOK(t *testing.T) {
+	offset := 25
+	sOffset := strconv.Itoa(offset)
+
+	limit := 1
+	sLimit := strconv.Itoa(limit)
+
+	c, done := venueInfoTestClient(t, func(t *testing.T, w http.ResponseWriter, r *http.Request) {
+		assertParameters(t, r, url.Values{
+			"offset": []string{sOffset},
+			"limit":  []string{sLimit},
+		})
+
+		w.Write([]byte(`{"response":{"venue":{"venue_id":1,"top_beers":{
+			"offset":` + sOffset + `,"limit":` + sLimit + `,"count":1,
+			"items":[{"beer":{"bid":26,"beer_name":"Beer 26"},"brewery":{"brewery_name":"Brewery Name"}}]
+		}}}}`))
+	})
+	defer done()
+
+	v, _, err := c.Venue.InfoTopBeers(1, false, offset, limit)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got, want := len(v.TopBeers), limit; got != want {
+		t.Fatalf("unexpected number of TopBeers: %d != %d", got, want)
+	}
+	if got, want := v.TopBeers[0].Beer.ID, int64(26); got != want {
+		t.Fatalf("unexpected TopBeers[0].ID: %d != %d", got, want)
+	}
+}
+
+// TestClientVenueInfoTopBeersCounts verifies that Client.Venue.InfoTopBeers
+// returns the statistics for each of a venue's top beers, and that
+// Venue.TopBeerList returns only the beers.
+func TestClientVenueInfoTopBeersCounts(t *testing.T) {
+	c, done := venueInfoTestClient(t, func(t *testing.T, w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"response":{"venue":{"venue_id":1,"top_beers":{"count":2,"items":[
+			{"created_at":"Mon, 02 May 2016 00:48:33 +0000","total_count":10,"your_count":3,"beer":{"bid":1,"beer_name":"Oberon"}},
+			{"created_at":"Tue, 03 May 2016 00:48:33 +0000","total_count":7,"your_count":0,"beer":{"bid":2,"beer_name":"Two Hearted"}}
+		]}}}}`))
+	})
+	defer done()
+
+	v, _, err := c.Venue.InfoTopBeers(1, false, 0, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []struct {
+		id    int64
+		day   int
+		total int
+		yours int
+	}{
+		{id: 1, day: 2, total: 10, yours: 3},
+		{id: 2, day: 3, total: 7, yours: 0},
+	}
+
+	if got, want := len(v.TopBeers), len(want); got != want {
+		t.Fatalf("unexpected number of TopBeers: %d != %d", got, want)
+	}
+
+	for i, w := range want {
+		tb := v.TopBeers[i]
+		if tb.Beer.ID != w.id {
+			t.Fatalf("unexpected TopBeers[%d].Beer.ID: %d != %d", i, tb.Beer.ID, w.id)
+		}
+		if d := tb.Created.Day(); d != w.day {
+			t.Fatalf("unexpected TopBeers[%d].Created day: %d != %d", i, d, w.day)
+		}
+		if tb.TotalCount != w.total {
+			t.Fatalf("unexpected TopBeers[%d].TotalCount: %d != %d", i, tb.TotalCount, w.total)
+		}
+		if tb.YourCount != w.yours {
+			t.Fatalf("unexpected TopBeers[%d].YourCount: %d != %d", i, tb.YourCount, w.yours)
+		}
+	}
+
+	beers := v.TopBeerList()
+	if got, want := len(beers), 2; got != want {
+		t.Fatalf("unexpected number of beers: %d != %d", got, want)
+	}
+	for i := range beers {
+		if beers[i] != v.TopBeers[i].Beer {
+			t.Fatalf("unexpected beer at index %d: %v != %v", i, beers[i], v.TopBeers[i].Beer)
+		}
+	}
+
+	var nv *Venue
+	if beers := nv.TopBeerList(); beers != nil {
+		t.Fatalf("unexpected beers for nil Venue: %v", beers)
+	}
+}
+
+// TestClientVenueInfoCountMismatch verifies that Client.Venue.Info returns
+// only the checkins present in a response, even if the reported count does
+// not match.
+func TestClientVenueInfoCountMismatch(t *testing.T) {
+	var tests = []struct {
+		description string
+		body        string
+		n           int
+	}{
+		{
+			description: "count lower than items",
+			body:        `{"meta":{"code":200},"response":{"venue":{"venue_id":1,"checkins":{"count":1,"items":[{"checkin_id":1},{"checkin_id":2}]}}}}`,
+			n:           2,
+		},
+		{
+			description: "count higher than items, with null item",
+			body:        `{"meta":{"code":200},"response":{"venue":{"venue_id":1,"checkins":{"count":25,"items":[{"checkin_id":1},null]}}}}`,
+			n:           1,
+		},
+	}
+
+	for _, tt := range tests {
+		c, done := venueInfoTestClient(t, func(t *testing.T, w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(tt.body))
+		})
+
+		v, _, err := c.Venue.Info(1, false)
+		done()
+		if err != nil {
+			t.Fatalf("unexpected error for test %q: %v", tt.description, err)
+		}
+
+		if l := len(v.Checkins); l != tt.n {
+			t.Fatalf("unexpected number of checkins for test %q: %d != %d", tt.description, l, tt.n)
+		}
+		for _, c := range v.Checkins {
+			if c == nil || c.ID == 0 {
+				t.Fatalf("unexpected checkin for test %q: %+v", tt.description, c)
+			}
+		}
+	}
+}
+
 // venueInfoTestClient builds upon testClient, and adds additional sanity checks
 // for tests which target the venue info API.
 func venueInfoTestClient(t *testing.T, fn func(t *testing.T, w http.ResponseWriter, r *http.Request)) (*Client, func()) {
@@ -186,3 +402,81 @@ var venueJSON = []byte(`
     }
   }
 }`)
+
+// TestVenueString verifies that Venue.String produces a concise description
+// of a Venue, including when fields are unavailable.
+func TestVenueString(t *testing.T) {
+	tests := []struct {
+		v *Venue
+		s string
+	}{
+		{
+			v: nil,
+			s: "<nil>",
+		},
+		{
+			v: &Venue{Name: "Untappd HQ"},
+			s: "Untappd HQ",
+		},
+		{
+			v: &Venue{
+				Name: "Untappd HQ",
+				Location: VenueLocation{
+					City:  "Wilmington",
+					State: "DE",
+				},
+			},
+			s: "Untappd HQ (Wilmington, DE)",
+		},
+	}
+
+	for i, tt := range tests {
+		if s := tt.v.String(); s != tt.s {
+			t.Fatalf("%02d: unexpected string: %q != %q", i, s, tt.s)
+		}
+	}
+}
+
+// Canned venue JSON containing an icon and photos, with one photo lacking
+// an original image and one lacking any images
+var venuePhotosJSON = []byte(`{
+  "meta": {
+    "code": 200
+  },
+  "notifications": {},
+  "response": {
+    "venue": {
+      "venue_id": 1,
+      "venue_name": "Brewery",
+      "venue_icon": {
+        "sm": "https://ss3.4sqi.net/img/categories_v2/food/brewery_bg_64.png",
+        "md": "https://ss3.4sqi.net/img/categories_v2/food/brewery_bg_88.png",
+        "lg": "https://ss3.4sqi.net/img/categories_v2/food/brewery_bg_88.png"
+      },
+      "venue_photos": {
+        "count": 3,
+        "items": [
+          {
+            "photo_id": 1,
+            "photo": {
+              "photo_img_sm": "https://untappd.akamaized.net/photo/1_sm.jpg",
+              "photo_img_lg": "https://untappd.akamaized.net/photo/1_lg.jpg",
+              "photo_img_og": "https://untappd.akamaized.net/photo/1_og.jpg"
+            }
+          },
+          {
+            "photo_id": 2,
+            "photo": {
+              "photo_img_sm": "https://untappd.akamaized.net/photo/2_sm.jpg",
+              "photo_img_lg": "https://untappd.akamaized.net/photo/2_lg.jpg"
+            }
+          },
+          {
+            "photo_id": 3,
+            "photo": {}
+          }
+        ]
+      }
+    }
+  }
+}`)